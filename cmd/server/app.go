@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AndrivA89/orders/internal/application/services"
+	"github.com/AndrivA89/orders/internal/domain/constants"
+	domainServices "github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/infrastructure/carriers"
+	"github.com/AndrivA89/orders/internal/infrastructure/config"
+	"github.com/AndrivA89/orders/internal/infrastructure/database"
+	"github.com/AndrivA89/orders/internal/infrastructure/invoicing"
+	"github.com/AndrivA89/orders/internal/infrastructure/metrics"
+	"github.com/AndrivA89/orders/internal/infrastructure/notifications"
+	"github.com/AndrivA89/orders/internal/infrastructure/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/telemetry"
+	"github.com/AndrivA89/orders/internal/infrastructure/webhooks"
+
+	"github.com/sirupsen/logrus"
+)
+
+// app - подключение к БД и сервисы поверх него; одна и та же сборка используется сервером и служебными командами
+type app struct {
+	cfg    *config.Config
+	logger *logrus.Logger
+	db     *database.Connection
+	// metrics собираются и служебными командами, но отдаются только сервером по /metrics
+	metrics *metrics.Metrics
+
+	stubCarrier *carriers.StubClient
+
+	userService       domainServices.UserService
+	productService    domainServices.ProductService
+	priceService      domainServices.PriceService
+	catalogService    domainServices.CatalogService
+	categoryService   domainServices.CategoryService
+	orderService      domainServices.OrderService
+	shipmentService   domainServices.ShipmentService
+	returnService     domainServices.ReturnService
+	invoiceService    domainServices.InvoiceService
+	reportService     domainServices.ReportService
+	stockAlertService domainServices.StockAlertService
+	emailService      domainServices.EmailService
+	webhookService    domainServices.WebhookService
+}
+
+func newApp(ctx context.Context, cfg *config.Config, logger *logrus.Logger) (*app, error) {
+	dbConn, err := database.NewConnection(ctx, &cfg.Database, logger)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+
+	userRepo := repositories.NewUserRepository(dbConn.DB)
+	productRepo := repositories.NewProductRepository(dbConn.DB, dbConn.Reads)
+	orderRepo := repositories.NewOrderRepository(dbConn.DB, dbConn.Reads)
+	shipmentRepo := repositories.NewShipmentRepository(dbConn.DB)
+	returnRepo := repositories.NewReturnRepository(dbConn.DB)
+	invoiceRepo := repositories.NewInvoiceRepository(dbConn.DB)
+	reportRepo := repositories.NewReportRepository(dbConn.Reads)
+	categoryRepo := repositories.NewCategoryRepository(dbConn.DB)
+	priceRepo := repositories.NewPriceRepository(dbConn.DB)
+	stockAlertRepo := repositories.NewStockAlertRepository(dbConn.DB)
+	emailRepo := repositories.NewEmailDeliveryRepository(dbConn.DB)
+	webhookRepo := repositories.NewWebhookRepository(dbConn.DB)
+	txManager := repositories.NewTransactionManager(dbConn.DB, dbConn.Reads)
+
+	a := &app{cfg: cfg, logger: logger, db: dbConn, metrics: metrics.New()}
+
+	sqlDB, err := dbConn.DB.DB()
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("get database handle: %w", err)
+	}
+	a.metrics.RegisterDB(cfg.Database.DBName, sqlDB)
+	for i, replica := range dbConn.Replicas {
+		replicaDB, err := replica.DB()
+		if err != nil {
+			a.Close()
+			return nil, fmt.Errorf("get replica %d handle: %w", i+1, err)
+		}
+		a.metrics.RegisterDB(fmt.Sprintf("%s_replica_%d", cfg.Database.DBName, i+1), replicaDB)
+	}
+
+	a.userService = services.NewUserService(userRepo)
+	a.productService = services.NewProductService(productRepo, categoryRepo, txManager)
+	a.priceService = services.NewPriceService(priceRepo, productRepo, txManager)
+	a.catalogService = services.NewCatalogService(productRepo, txManager)
+	a.categoryService = services.NewCategoryService(categoryRepo)
+	a.orderService = metrics.NewOrderService(telemetry.NewOrderService(
+		services.NewOrderService(orderRepo, userRepo, productRepo, txManager, orderPolicy(&cfg.Orders))), a.metrics)
+
+	a.stubCarrier = carriers.NewStubClient(cfg.Carrier.StubStep, logger)
+	a.shipmentService = services.NewShipmentService(shipmentRepo, orderRepo, txManager,
+		[]domainServices.CarrierClient{a.stubCarrier})
+	a.stubCarrier.SetTrackingEventHandler(func(ctx context.Context, event *domainServices.TrackingEvent) error {
+		_, err := a.shipmentService.ProcessTrackingEvent(ctx, event)
+		return err
+	})
+
+	a.returnService = services.NewReturnService(returnRepo, orderRepo, txManager)
+	a.invoiceService = services.NewInvoiceService(invoiceRepo, txManager, invoicing.NewPDFRenderer(), cfg.Invoice.SellerName)
+	a.reportService = services.NewReportService(reportRepo)
+	a.stockAlertService = services.NewStockAlertService(stockAlertRepo, productRepo, userRepo, txManager, notifications.NewLogNotifier(logger))
+
+	emailRenderer, err := notifications.NewTemplateRenderer(constants.DefaultLanguage)
+	if err != nil {
+		a.Close()
+		return nil, fmt.Errorf("load email templates: %w", err)
+	}
+	a.emailService = services.NewEmailService(emailRepo, orderRepo, userRepo, emailRenderer, emailSender(&cfg.Email))
+	a.webhookService = services.NewWebhookService(webhookRepo, webhooks.NewHTTPSender(cfg.Webhooks.Timeout))
+
+	return a, nil
+}
+
+func (a *app) Close() {
+	if err := a.db.Close(); err != nil {
+		a.logger.Errorf("Failed to close database connection: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/infrastructure/catalog"
+	"github.com/AndrivA89/orders/internal/infrastructure/config"
+	"github.com/AndrivA89/orders/internal/infrastructure/database"
+
+	"github.com/sirupsen/logrus"
+)
+
+// runImportProducts загружает каталог из CSV или NDJSON тем же сервисом, что и POST /products/import
+func runImportProducts(ctx context.Context, cfg *config.Config, logger *logrus.Logger, args []string) error {
+	flags := flag.NewFlagSet("import-products", flag.ExitOnError)
+	formatFlag := flags.String("format", "csv", "input format: csv or ndjson")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return errUsage
+	}
+
+	format, err := catalog.ParseFormat(*formatFlag)
+	if err != nil {
+		return err
+	}
+
+	input := io.Reader(os.Stdin)
+	if path := flags.Arg(0); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	rows, err := catalog.Decode(input, format)
+	if err != nil {
+		return err
+	}
+
+	a, err := newApp(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	report, err := a.catalogService.ImportProducts(ctx, rows)
+	if err != nil {
+		return err
+	}
+
+	if err := printJSON(report); err != nil {
+		return err
+	}
+
+	if report.HasFailures() {
+		return fmt.Errorf("%d of %d rows failed", report.Failed, report.Total)
+	}
+
+	return nil
+}
+
+// runExportProducts выгружает каталог потоком тем же сервисом, что и GET /products/export
+func runExportProducts(ctx context.Context, cfg *config.Config, logger *logrus.Logger, args []string) error {
+	flags := flag.NewFlagSet("export-products", flag.ExitOnError)
+	formatFlag := flags.String("format", "csv", "output format: csv or ndjson")
+	outputFlag := flags.String("output", "-", "output file, - for stdout")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 0 {
+		return errUsage
+	}
+
+	format, err := catalog.ParseFormat(*formatFlag)
+	if err != nil {
+		return err
+	}
+
+	a, err := newApp(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	output := io.Writer(os.Stdout)
+	if *outputFlag != "-" {
+		file, err := os.Create(*outputFlag)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		output = file
+	}
+
+	encoder := catalog.NewEncoder(output, format)
+	if err := a.catalogService.ExportProducts(ctx, func(product *entities.Product) error {
+		return encoder.Encode(product)
+	}); err != nil {
+		return err
+	}
+
+	return encoder.Flush()
+}
+
+// runExpireReservations отменяет заказы, которые не подтвердили или не проверили за отведенное время,
+// и освобождает их резерв
+func runExpireReservations(ctx context.Context, cfg *config.Config, logger *logrus.Logger, args []string) error {
+	flags := flag.NewFlagSet("expire-reservations", flag.ExitOnError)
+	olderThan := flags.Duration("older-than", cfg.Orders.ReservationTTL, "cancel pending orders reserved earlier than this")
+	heldOlderThan := flags.Duration("held-older-than", cfg.Orders.HoldTTL, "cancel on-hold orders reserved earlier than this, 0 keeps them")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 0 || *olderThan <= 0 || *heldOlderThan < 0 {
+		return errUsage
+	}
+
+	now := time.Now()
+	var heldBefore time.Time
+	if *heldOlderThan > 0 {
+		heldBefore = now.Add(-*heldOlderThan)
+	}
+
+	a, err := newApp(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	// как и фоновая задача serve, команда читает из основной БД
+	expired, err := a.orderService.ExpireReservations(database.WithPrimaryReads(ctx), now.Add(-*olderThan), heldBefore)
+	logger.WithField("expired", expired).Info("Expired pending and on-hold orders")
+
+	return err
+}
+
+// runReconcileStock отдает свободный остаток ожидающим заказам и выводит, что было распределено
+func runReconcileStock(ctx context.Context, cfg *config.Config, logger *logrus.Logger) error {
+	a, err := newApp(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	receipts, err := a.productService.ReconcileStock(ctx)
+	if printErr := printJSON(receipts); printErr != nil && err == nil {
+		err = printErr
+	}
+
+	return err
+}
+
+// runConfig выполняет config print: действующие значения после чтения переменных окружения
+func runConfig(cfg *config.Config, args []string) error {
+	if len(args) != 1 || args[0] != "print" {
+		return errUsage
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, setting := range cfg.Settings() {
+		fmt.Fprintf(writer, "%s\t%s\n", setting.Key, setting.Value)
+	}
+
+	return writer.Flush()
+}
+
+func printJSON(value any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
@@ -1,68 +1,121 @@
+// Команда orders - сервис заказов и служебные команды для операторов:
+//
+//	orders serve                          запустить HTTP и gRPC API (команда по умолчанию)
+//	orders migrate up|down|status|to <N>  управлять схемой БД
+//	orders seed                           заполнить пустую базу демо-данными
+//	orders import-products [-format csv|ndjson] <file|->
+//	orders export-products [-format csv|ndjson] [-output file]
+//	orders expire-reservations [-older-than 24h] [-held-older-than 72h]
+//	orders reconcile-stock
+//	orders config print                   показать действующую конфигурацию без секретов
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/AndrivA89/orders/internal/application/services"
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainServices "github.com/AndrivA89/orders/internal/domain/services"
 	"github.com/AndrivA89/orders/internal/infrastructure/config"
-	"github.com/AndrivA89/orders/internal/infrastructure/database"
-	"github.com/AndrivA89/orders/internal/infrastructure/repositories"
-	"github.com/AndrivA89/orders/internal/infrastructure/telemetry"
-	"github.com/AndrivA89/orders/internal/transport/http/handlers"
-	"github.com/AndrivA89/orders/internal/transport/http/router"
+	"github.com/AndrivA89/orders/internal/infrastructure/notifications"
+	"github.com/AndrivA89/orders/internal/infrastructure/risk"
 
 	"github.com/sirupsen/logrus"
 )
 
+// errUsage - команда вызвана с неверными аргументами; справка уже выведена
+var errUsage = errors.New("invalid arguments")
+
 func main() {
 	cfg := config.LoadConfig()
 	logger := setupLogger(cfg)
 
-	cleanup, err := telemetry.InitTracing("orders-service")
-	if err != nil {
-		logger.Fatalf("Failed to initialize tracing: %v", err)
-	}
-	defer cleanup()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	dbConn, err := database.NewConnection(&cfg.Database)
-	if err != nil {
-		logger.Fatalf("Failed to connect to database: %v", err)
+	command, args := "serve", []string(nil)
+	if len(os.Args) > 1 {
+		command, args = os.Args[1], os.Args[2:]
 	}
 
-	defer func() {
-		if err = dbConn.Close(); err != nil {
-			logger.Errorf("Failed to close database connection: %v", err)
-		}
-	}()
-
-	// Выполняем миграции (в продакшене я бы использовал отдельные миграции, конечно)
-	if err = dbConn.AutoMigrate(); err != nil {
-		logger.Fatalf("Failed to migrate database: %v", err)
+	var err error
+	switch command {
+	case "serve":
+		err = runServe(ctx, cfg, logger)
+	case "migrate":
+		err = runMigrate(ctx, cfg, logger, args)
+	case "seed":
+		err = runSeed(ctx, cfg, logger, args)
+	case "import-products":
+		err = runImportProducts(ctx, cfg, logger, args)
+	case "export-products":
+		err = runExportProducts(ctx, cfg, logger, args)
+	case "expire-reservations":
+		err = runExpireReservations(ctx, cfg, logger, args)
+	case "reconcile-stock":
+		err = runReconcileStock(ctx, cfg, logger)
+	case "config":
+		err = runConfig(cfg, args)
+	case "help", "-h", "--help":
+		usage()
+		return
+	default:
+		err = errUsage
 	}
 
-	userRepo := repositories.NewUserRepository(dbConn.DB)
-	productRepo := repositories.NewProductRepository(dbConn.DB)
-	orderRepo := repositories.NewOrderRepository(dbConn.DB)
+	if errors.Is(err, errUsage) {
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		logger.Fatalf("%s failed: %v", command, err)
+	}
+}
 
-	userService := services.NewUserService(userRepo)
-	productService := services.NewProductService(productRepo)
-	txManager := repositories.NewTransactionManager(dbConn.DB)
-	orderService := services.NewOrderService(orderRepo, userRepo, productRepo, txManager)
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	fmt.Fprintln(os.Stderr, "  orders [serve]")
+	fmt.Fprintln(os.Stderr, "  orders migrate up|down|status|to <version>")
+	fmt.Fprintln(os.Stderr, "  orders seed [-force]")
+	fmt.Fprintln(os.Stderr, "  orders import-products [-format csv|ndjson] <file|->")
+	fmt.Fprintln(os.Stderr, "  orders export-products [-format csv|ndjson] [-output file]")
+	fmt.Fprintln(os.Stderr, "  orders expire-reservations [-older-than duration] [-held-older-than duration]")
+	fmt.Fprintln(os.Stderr, "  orders reconcile-stock")
+	fmt.Fprintln(os.Stderr, "  orders config print")
+}
 
-	userHandler := handlers.NewUserHandler(userService)
-	productHandler := handlers.NewProductHandler(productService)
-	orderHandler := handlers.NewOrderHandler(orderService)
+func orderPolicy(cfg *config.OrdersConfig) domainServices.OrderPolicy {
+	policy := domainServices.OrderPolicy{
+		Limits: entities.OrderLimits{
+			MaxQuantityPerProduct: cfg.MaxQuantityPerProduct,
+			MaxQuantityPerUser:    cfg.MaxQuantityPerUser,
+			UserWindow:            cfg.UserWindow,
+			MaxOrderTotal:         cfg.MaxOrderTotal,
+			MaxOrdersPerUser:      cfg.MaxOrdersPerUser,
+			VelocityWindow:        cfg.VelocityWindow,
+		},
+		HoldThreshold: cfg.RiskHoldThreshold,
+	}
 
-	appRouter := router.NewRouter(userHandler, productHandler, orderHandler, logger)
-	ginRouter := appRouter.SetupRoutes()
+	if cfg.RiskEnabled {
+		policy.RiskScorer = risk.NewHeuristicScorer(cfg.RiskNewAccountAge, cfg.RiskHighValueTotal)
+	}
 
-	address := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	logger.Infof("Starting server on %s", address)
+	return policy
+}
 
-	if err = ginRouter.Run(address); err != nil {
-		logger.Fatalf("Failed to start server: %v", err)
+// emailSender выбирает способ доставки писем: SMTP или файлы для локальной разработки
+func emailSender(cfg *config.EmailConfig) domainServices.EmailSender {
+	if cfg.Sender == "smtp" {
+		return notifications.NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
 	}
+
+	return notifications.NewFileSender(cfg.DropDir, cfg.From)
 }
 
 func setupLogger(cfg *config.Config) *logrus.Logger {
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/config"
+	"github.com/AndrivA89/orders/internal/infrastructure/database"
+
+	"github.com/sirupsen/logrus"
+)
+
+// runMigrate выполняет подкоманду migrate; после перехода на последнюю версию схема сверяется с моделями
+func runMigrate(ctx context.Context, cfg *config.Config, logger *logrus.Logger, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	dbConn, err := database.NewConnection(ctx, &cfg.Database, logger)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer func() {
+		if err := dbConn.Close(); err != nil {
+			logger.Errorf("Failed to close database connection: %v", err)
+		}
+	}()
+
+	migrator, err := dbConn.Migrator(logger)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(ctx); err != nil {
+			return err
+		}
+		return dbConn.CheckSchema()
+	case "down":
+		return migrator.Down(ctx)
+	case "to":
+		if len(args) != 2 {
+			return errUsage
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := migrator.To(ctx, version); err != nil {
+			return err
+		}
+		if version == migrator.Latest() {
+			return dbConn.CheckSchema()
+		}
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied() {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(writer, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return writer.Flush()
+	default:
+		return errUsage
+	}
+}
+
+// prepareSchema применяет миграции при старте, если это разрешено, и проверяет, что схема
+// соответствует моделям. Несколько реплик могут стартовать одновременно: миграции идут под блокировкой.
+func prepareSchema(ctx context.Context, dbConn *database.Connection, cfg *config.DatabaseConfig, logger *logrus.Logger) error {
+	migrator, err := dbConn.Migrator(logger)
+	if err != nil {
+		return err
+	}
+
+	if cfg.MigrateOnStart {
+		if err := migrator.Up(ctx); err != nil {
+			return err
+		}
+	} else {
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d migrations are not applied, run `orders migrate up`", len(pending))
+		}
+	}
+
+	return dbConn.CheckSchema()
+}
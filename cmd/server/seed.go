@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainServices "github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/infrastructure/config"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var demoUsers = []domainServices.CreateUserRequest{
+	{FirstName: "Алексей", LastName: "Иванов", Email: "alexey@example.com", Language: "ru", Age: 28, IsMarried: true, Password: "demo-password"},
+	{FirstName: "Мария", LastName: "Петрова", Email: "maria@example.com", Language: "ru", Age: 25, Password: "demo-password"},
+	{FirstName: "John", LastName: "Smith", Email: "john@example.com", Language: "en", Age: 41, IsMarried: true, Password: "demo-password"},
+}
+
+// demoProducts - товары на все политики остатков: из наличия, под заказ, предзаказ и с вариантами
+func demoProducts() []domainServices.CreateProductRequest {
+	releaseDate := time.Now().AddDate(0, 1, 0).Truncate(24 * time.Hour)
+
+	return []domainServices.CreateProductRequest{
+		{SKU: "DEMO-LAPTOP", Name: "Ноутбук", Description: "Ноутбук 14 дюймов, 16 ГБ", Tags: []string{"electronics", "computers"}, Quantity: 25, Price: 8_999_000},
+		{SKU: "DEMO-MOUSE", Name: "Мышь", Description: "Беспроводная мышь", Tags: []string{"electronics", "accessories"}, Quantity: 200, Price: 149_000},
+		{SKU: "DEMO-CHAIR", Name: "Кресло", Description: "Офисное кресло под заказ", Tags: []string{"furniture"}, Quantity: 2, Price: 1_990_000, StockPolicy: entities.StockPolicyBackorder},
+		{SKU: "DEMO-CONSOLE", Name: "Приставка", Description: "Игровая приставка нового поколения", Tags: []string{"electronics", "games"}, Price: 5_499_000, StockPolicy: entities.StockPolicyPreorder, ExpectedAt: &releaseDate},
+		{
+			SKU: "DEMO-TSHIRT", Name: "Футболка", Description: "Хлопковая футболка", Tags: []string{"clothes"},
+			Variants: []domainServices.CreateVariantRequest{
+				{SKU: "DEMO-TSHIRT-M", Attributes: map[string]string{"size": "M"}, Price: 99_000, Quantity: 40},
+				{SKU: "DEMO-TSHIRT-L", Attributes: map[string]string{"size": "L"}, Price: 99_000, Quantity: 30},
+			},
+		},
+	}
+}
+
+// runSeed заполняет базу демо-данными через сервисы, с теми же проверками, что и в API.
+// В базу с товарами данные не добавляются без -force: повторный запуск не должен плодить дубли.
+func runSeed(ctx context.Context, cfg *config.Config, logger *logrus.Logger, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	force := flags.Bool("force", false, "seed even if the catalog is not empty")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 0 {
+		return errUsage
+	}
+
+	a, err := newApp(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	existing, err := a.productService.GetProducts(ctx, 1, 0)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 && !*force {
+		logger.Info("Catalog is not empty, skipping seed (use -force to seed anyway)")
+		return nil
+	}
+
+	users := make([]*entities.User, 0, len(demoUsers))
+	for _, request := range demoUsers {
+		user, err := a.userService.RegisterUser(ctx, &request)
+		if err != nil {
+			return fmt.Errorf("create user %s: %w", request.Email, err)
+		}
+		users = append(users, user)
+	}
+
+	products := make(map[string]*entities.Product)
+	for _, request := range demoProducts() {
+		product, err := a.productService.CreateProduct(ctx, &request)
+		if err != nil {
+			return fmt.Errorf("create product %s: %w", request.SKU, err)
+		}
+		products[product.SKU] = product
+	}
+
+	variantID := func(product *entities.Product, sku string) *uuid.UUID {
+		for _, variant := range product.Variants {
+			if variant.SKU == sku {
+				return &variant.ID
+			}
+		}
+		return nil
+	}
+	tshirt := products["DEMO-TSHIRT"]
+
+	orders := []domainServices.OrderRequest{
+		{UserID: users[0].ID, Items: []domainServices.OrderItemRequest{
+			{ProductID: products["DEMO-LAPTOP"].ID, Quantity: 1},
+			{ProductID: products["DEMO-MOUSE"].ID, Quantity: 2},
+		}},
+		{UserID: users[1].ID, Items: []domainServices.OrderItemRequest{
+			{ProductID: tshirt.ID, VariantID: variantID(tshirt, "DEMO-TSHIRT-M"), Quantity: 3},
+			{ProductID: products["DEMO-CHAIR"].ID, Quantity: 4},
+		}},
+		{UserID: users[2].ID, Items: []domainServices.OrderItemRequest{
+			{ProductID: products["DEMO-CONSOLE"].ID, Quantity: 1},
+		}},
+	}
+
+	for i := range orders {
+		if _, err := a.orderService.CreateOrder(ctx, &orders[i]); err != nil {
+			return fmt.Errorf("create order for user %s: %w", orders[i].UserID, err)
+		}
+	}
+
+	// первый заказ подтвержден, остальные ждут подтверждения, чтобы было что показать в expire-reservations
+	userOrders, err := a.orderService.GetOrdersByUserID(ctx, users[0].ID, 1, 0)
+	if err != nil {
+		return err
+	}
+	if len(userOrders) > 0 && userOrders[0].Status == entities.OrderStatusPending {
+		if err := a.orderService.ConfirmOrder(ctx, userOrders[0].ID); err != nil {
+			return err
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"users":    len(users),
+		"products": len(products),
+		"orders":   len(orders),
+	}).Info("Demo data seeded")
+
+	return nil
+}
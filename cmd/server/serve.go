@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/config"
+	"github.com/AndrivA89/orders/internal/infrastructure/database"
+	"github.com/AndrivA89/orders/internal/infrastructure/health"
+	"github.com/AndrivA89/orders/internal/infrastructure/jobs"
+	"github.com/AndrivA89/orders/internal/infrastructure/telemetry"
+	"github.com/AndrivA89/orders/internal/transport/graphql"
+	grpcTransport "github.com/AndrivA89/orders/internal/transport/grpc"
+	"github.com/AndrivA89/orders/internal/transport/http/handlers"
+	"github.com/AndrivA89/orders/internal/transport/http/router"
+
+	"github.com/sirupsen/logrus"
+)
+
+// runServe запускает HTTP и gRPC API и фоновые задачи и работает до отмены ctx (SIGINT, SIGTERM)
+// или падения одного из серверов. Остановка идет по порядку: прием новых соединений прекращается,
+// начатые запросы дорабатывают до ShutdownTimeout, затем останавливаются фоновые задачи,
+// отправляются накопленные спаны и закрывается БД.
+func runServe(ctx context.Context, cfg *config.Config, logger *logrus.Logger) error {
+	a, err := newApp(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	// отложенные вызовы выполняются в обратном порядке: БД закрывается последней
+	defer a.Close()
+
+	shutdownTracing, err := telemetry.InitTracing(ctx, &cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("initialize tracing: %w", err)
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Errorf("Failed to flush traces: %v", err)
+		}
+	}()
+
+	if err := prepareSchema(ctx, a.db, &cfg.Database, logger); err != nil {
+		return fmt.Errorf("prepare database schema: %w", err)
+	}
+
+	// Фоновые задачи получают свой контекст: они останавливаются после HTTP и gRPC,
+	// чтобы письма и вебхуки о заказах, созданных последними запросами, не ждали следующего запуска.
+	// Читают задачи из основной БД: письмо о только что созданном заказе не должно упасть на отстающей реплике.
+	jobsCtx, stopJobs := context.WithCancel(database.WithPrimaryReads(context.Background()))
+	defer stopJobs()
+
+	var jobsDone sync.WaitGroup
+	runJob := func(run func(ctx context.Context)) {
+		jobsDone.Add(1)
+		go func() {
+			defer jobsDone.Done()
+			run(jobsCtx)
+		}()
+	}
+
+	// Запланированные цены переносятся в карточки товаров в фоне
+	priceJob := jobs.NewPriceJob(a.priceService, cfg.Pricing.JobInterval, logger)
+	runJob(priceJob.Run)
+	// Уведомления об остатках отправляются из очереди в фоне
+	stockAlertJob := jobs.NewStockAlertJob(a.stockAlertService, cfg.Stock.AlertInterval, logger)
+	runJob(stockAlertJob.Run)
+	// Письма покупателям о заказах отправляются из очереди в фоне
+	emailJob := jobs.NewEmailJob(a.emailService, cfg.Email.JobInterval, logger)
+	runJob(emailJob.Run)
+	// События заказов отправляются подписчикам вебхуков из очереди в фоне
+	webhookJob := jobs.NewWebhookJob(a.webhookService, cfg.Webhooks.JobInterval, logger)
+	runJob(webhookJob.Run)
+	// Просроченные заказы отменяются в фоне, если не выключено в пользу cron
+	var expiryJob *jobs.ExpiryJob
+	if cfg.Orders.ExpireInterval > 0 {
+		expiryJob = jobs.NewExpiryJob(a.orderService, cfg.Orders.ReservationTTL, cfg.Orders.HoldTTL, cfg.Orders.ExpireInterval, logger)
+		runJob(expiryJob.Run)
+	}
+	// Недоступные реплики выводятся из чтений и возвращаются, когда снова отвечают
+	runJob(func(ctx context.Context) { a.db.MonitorReplicas(ctx, cfg.Database.ReplicaCheckInterval) })
+
+	// Готовность: БД отвечает, а задачи, разбирающие очереди писем, вебхуков и уведомлений, не зависли
+	healthChecks := health.NewRegistry(cfg.Health.CheckTimeout, cfg.Health.CacheTTL)
+	healthChecks.Register("database", a.db.Ping, 0)
+	// без реплики сервис читает из остальных или из основной БД, поэтому она не влияет на готовность
+	for i := range a.db.Replicas {
+		healthChecks.RegisterOptional(fmt.Sprintf("database.replica_%d", i+1), func(ctx context.Context) error {
+			return a.db.PingReplica(ctx, i)
+		}, 0)
+	}
+	healthChecks.Register("job.prices", health.Heartbeat(priceJob.LastRun, cfg.Health.JobStallAfter), 0)
+	healthChecks.Register("job.stock_alerts", health.Heartbeat(stockAlertJob.LastRun, cfg.Health.JobStallAfter), 0)
+	healthChecks.Register("job.emails", health.Heartbeat(emailJob.LastRun, cfg.Health.JobStallAfter), 0)
+	healthChecks.Register("job.webhooks", health.Heartbeat(webhookJob.LastRun, cfg.Health.JobStallAfter), 0)
+	if expiryJob != nil {
+		healthChecks.Register("job.expire_orders", health.Heartbeat(expiryJob.LastRun, cfg.Health.JobStallAfter), 0)
+	}
+
+	userHandler := handlers.NewUserHandler(a.userService)
+	productHandler := handlers.NewProductHandler(a.productService)
+	orderHandler := handlers.NewOrderHandler(a.orderService)
+	if cfg.Carrier.WebhookToken == "" && !cfg.Carrier.WebhookAllowUnauthenticated {
+		logger.Warn("CARRIER_WEBHOOK_TOKEN is not set, carrier tracking webhooks will be rejected")
+	}
+	shipmentHandler := handlers.NewShipmentHandler(a.shipmentService, cfg.Carrier.WebhookToken, cfg.Carrier.WebhookAllowUnauthenticated)
+	returnHandler := handlers.NewReturnHandler(a.returnService)
+	invoiceHandler := handlers.NewInvoiceHandler(a.invoiceService)
+	reportHandler := handlers.NewReportHandler(a.reportService)
+	catalogHandler := handlers.NewCatalogHandler(a.catalogService)
+	categoryHandler := handlers.NewCategoryHandler(a.categoryService)
+	priceHandler := handlers.NewPriceHandler(a.priceService)
+	stockAlertHandler := handlers.NewStockAlertHandler(a.stockAlertService)
+	emailHandler := handlers.NewEmailHandler(a.emailService)
+	webhookHandler := handlers.NewWebhookHandler(a.webhookService)
+	graphqlHandler := graphql.NewHandler(a.orderService, a.productService, a.userService, cfg.GraphQL.MaxDepth, cfg.GraphQL.MaxComplexity, logger)
+	healthHandler := handlers.NewHealthHandler(healthChecks, cfg.Health.DetailedReport)
+
+	appRouter := router.NewRouter(userHandler, productHandler, orderHandler, shipmentHandler, returnHandler, invoiceHandler, reportHandler, catalogHandler, categoryHandler, priceHandler, stockAlertHandler, emailHandler, webhookHandler, graphqlHandler, healthHandler, a.metrics, logger)
+
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port),
+		Handler:           appRouter.SetupRoutes(),
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	// gRPC API работает на отдельном порту поверх тех же сервисов
+	if cfg.GRPC.AuthToken == "" && !cfg.GRPC.AllowUnauthenticated {
+		logger.Warn("GRPC_AUTH_TOKEN is not set, gRPC API calls will be rejected")
+	}
+	grpcServer := grpcTransport.NewServer(a.orderService, a.productService, a.userService, healthChecks, cfg.GRPC.AuthToken, cfg.GRPC.AllowUnauthenticated, logger)
+	grpcAddress := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.GRPC.Port)
+	grpcListener, err := net.Listen("tcp", grpcAddress)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", grpcAddress, err)
+	}
+
+	serveErrors := make(chan error, 2)
+	go func() {
+		logger.Infof("Starting gRPC server on %s", grpcAddress)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			serveErrors <- fmt.Errorf("gRPC server: %w", err)
+		}
+	}()
+	go func() {
+		logger.Infof("Starting server on %s", httpServer.Addr)
+		if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErrors <- fmt.Errorf("HTTP server: %w", err)
+		}
+	}()
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+		logger.Info("Shutdown signal received, draining in-flight requests")
+	case serveErr = <-serveErrors:
+		logger.Errorf("Server stopped unexpectedly, shutting down: %v", serveErr)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		logger.Errorf("HTTP server did not drain in time, closing connections: %v", err)
+		_ = httpServer.Close()
+	}
+	stopGRPC(shutdownCtx, grpcServer, logger)
+
+	// Заглушка перевозчика прекращает эмуляцию; уже начатые события дообрабатываются, пока БД открыта
+	if err := a.stubCarrier.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Stub carrier tracking did not stop in time")
+	}
+
+	stopJobs()
+	if !waitGroupWithin(shutdownCtx, &jobsDone) {
+		logger.Error("Background jobs did not stop in time")
+	}
+
+	logger.Info("Server stopped")
+	return serveErr
+}
+
+// stopGRPC переводит health-проверку в NOT_SERVING, дает начатым вызовам завершиться,
+// а по истечении ctx обрывает их
+func stopGRPC(ctx context.Context, server *grpcTransport.Server, logger *logrus.Logger) {
+	server.BeginShutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		logger.Error("gRPC server did not drain in time, closing connections")
+		server.Stop()
+	}
+}
+
+// waitGroupWithin ждет wg, но не дольше ctx; возвращает false, если время вышло
+func waitGroupWithin(ctx context.Context, wg *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
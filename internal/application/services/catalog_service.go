@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Размер пачки строк, импортируемых в одной транзакции
+	importChunkSize = 500
+	exportBatchSize = 500
+)
+
+type catalogService struct {
+	productRepo repositories.ProductRepository
+	txManager   repositories.TransactionManager
+}
+
+func NewCatalogService(
+	productRepo repositories.ProductRepository,
+	txManager repositories.TransactionManager,
+) services.CatalogService {
+	return &catalogService{
+		productRepo: productRepo,
+		txManager:   txManager,
+	}
+}
+
+func (s *catalogService) ImportProducts(
+	ctx context.Context,
+	rows []services.ProductImportRow,
+) (*entities.ProductImportReport, error) {
+	report := &entities.ProductImportReport{
+		Rows: make([]entities.ProductImportRowResult, 0, len(rows)),
+	}
+
+	valid := make([]services.ProductImportRow, 0, len(rows))
+	seen := make(map[string]struct{}, len(rows))
+
+	for _, row := range rows {
+		if err := validateImportRow(row, seen); err != nil {
+			report.AddFailure(row.Line, row.SKU, err)
+			continue
+		}
+
+		seen[row.SKU] = struct{}{}
+		valid = append(valid, row)
+	}
+
+	for start := 0; start < len(valid); start += importChunkSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		end := min(start+importChunkSize, len(valid))
+		chunk := valid[start:end]
+
+		created, err := s.importChunk(ctx, chunk)
+		if err != nil {
+			// Пачка откатывается целиком, остальные пачки продолжают импортироваться
+			for _, row := range chunk {
+				report.AddFailure(row.Line, row.SKU, err)
+			}
+			continue
+		}
+
+		for i, row := range chunk {
+			report.AddSuccess(row.Line, row.SKU, created[i])
+		}
+	}
+
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		return report.Rows[i].Line < report.Rows[j].Line
+	})
+
+	return report, nil
+}
+
+func (s *catalogService) ExportProducts(ctx context.Context, fn func(product *entities.Product) error) error {
+	return s.productRepo.ForEachBatch(ctx, exportBatchSize, func(products []*entities.Product) error {
+		for _, product := range products {
+			if err := fn(product); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// importChunk сохраняет пачку строк в одной транзакции и возвращает, какие товары были созданы
+func (s *catalogService) importChunk(ctx context.Context, chunk []services.ProductImportRow) ([]bool, error) {
+	created := make([]bool, len(chunk))
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		skus := make([]string, len(chunk))
+		for i, row := range chunk {
+			skus[i] = row.SKU
+		}
+
+		// Остаток из файла записывается как есть: без блокировки он затер бы резервы заказов,
+		// зафиксированные между чтением и записью
+		existing, err := repos.ProductRepository.GetBySKUsForUpdate(ctx, skus)
+		if err != nil {
+			return err
+		}
+
+		bySKU := make(map[string]*entities.Product, len(existing))
+		for _, product := range existing {
+			bySKU[product.SKU] = product
+		}
+
+		now := time.Now()
+		products := make([]*entities.Product, len(chunk))
+		repriced := make([]*entities.Product, 0)
+		stockBefore := make(map[uuid.UUID]int, len(existing))
+		for i, row := range chunk {
+			product, ok := bySKU[row.SKU]
+			if !ok {
+				product = &entities.Product{ID: uuid.New(), SKU: row.SKU, CreatedAt: now}
+				created[i] = true
+			} else {
+				stockBefore[product.ID] = product.Quantity
+			}
+
+			if !ok || product.Price != row.Price {
+				repriced = append(repriced, product)
+			}
+
+			product.Description = row.Description
+			product.Tags = row.Tags
+			product.Quantity = row.Quantity
+			product.Price = row.Price
+			product.UpdatedAt = now
+
+			products[i] = product
+		}
+
+		// Выросший остаток сначала достается ожидающим заказам, как при поступлении на склад,
+		// и сохраняется уже за вычетом распределенного
+		for _, product := range products {
+			before, ok := stockBefore[product.ID]
+			if !ok || product.Quantity <= before {
+				continue
+			}
+
+			allocated, err := allocateBackorders(ctx, repos.OrderRepository, product.ID, nil, product.Quantity)
+			if err != nil {
+				return err
+			}
+			if allocated > 0 {
+				if err := product.ReserveQuantity(allocated); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := repos.ProductRepository.UpsertBySKU(ctx, products); err != nil {
+			return err
+		}
+
+		// Импорт задает остаток как есть, но пороги и подписки на поступление срабатывают как обычно
+		for _, product := range products {
+			before, ok := stockBefore[product.ID]
+			if !ok {
+				continue
+			}
+			if err := recordStockChange(ctx, repos, product, product.StockChange(before)); err != nil {
+				return err
+			}
+		}
+
+		return recordImportedPrices(ctx, repos.PriceRepository, repriced, now)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// recordImportedPrices добавляет в историю цен новые товары и товары, цена которых изменилась при импорте
+func recordImportedPrices(
+	ctx context.Context,
+	priceRepo repositories.PriceRepository,
+	products []*entities.Product,
+	now time.Time,
+) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+
+	histories, err := priceRepo.GetByProductIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		history := histories[product.ID]
+
+		// Цена из импорта действует сразу; у новых товаров это начальная запись истории
+		change := initialPrice(product)
+		change.ValidFrom = now
+		change.MarkApplied(now)
+
+		if err := recordPriceChange(ctx, priceRepo, history, change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateImportRow(row services.ProductImportRow, seen map[string]struct{}) error {
+	if row.DecodeErr != nil {
+		return row.DecodeErr
+	}
+
+	if row.SKU == "" {
+		return domainErrors.ErrProductSKURequired
+	}
+
+	if _, duplicate := seen[row.SKU]; duplicate {
+		return domainErrors.ErrProductSKUDuplicate
+	}
+
+	product := &entities.Product{
+		SKU:         row.SKU,
+		Description: row.Description,
+		Tags:        row.Tags,
+		Quantity:    row.Quantity,
+		Price:       row.Price,
+	}
+
+	return product.ValidateForCreation()
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func expectCatalogTransaction(
+	mockTxManager *mocks.MockTransactionManager,
+	mockProductRepo *mocks.MockProductRepository,
+	mockPriceRepo *mocks.MockPriceRepository,
+	mockOrderRepo *mocks.MockOrderRepository,
+) {
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				ProductRepository: mockProductRepo,
+				PriceRepository:   mockPriceRepo,
+				OrderRepository:   mockOrderRepo,
+			})
+		},
+	)
+}
+
+func TestCatalogService_ImportProducts_CreatesAndUpdates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewCatalogService(mockProductRepo, mockTxManager)
+
+	existing := &entities.Product{ID: uuid.New(), SKU: "SHIRT-M", Description: "Old", Quantity: 1, Price: 1000}
+	currentPrice := &entities.PriceChange{ID: uuid.New(), ProductID: existing.ID, Price: 1000}
+
+	expectCatalogTransaction(mockTxManager, mockProductRepo, mockPriceRepo, mockOrderRepo)
+	mockProductRepo.EXPECT().GetBySKUsForUpdate(gomock.Any(), []string{"SHIRT-M", "SHIRT-L"}).
+		Return([]*entities.Product{existing}, nil)
+	// остаток вырос: прирост сначала предлагается ожидающим заказам
+	mockOrderRepo.EXPECT().GetBackorderedItemsForUpdate(gomock.Any(), existing.ID, gomock.Nil()).Return(nil, nil)
+	mockProductRepo.EXPECT().UpsertBySKU(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, products []*entities.Product) error {
+			assert.Len(t, products, 2)
+			assert.Equal(t, existing.ID, products[0].ID)
+			assert.Equal(t, "Shirt M", products[0].Description)
+			assert.Equal(t, 5, products[0].Quantity)
+			assert.Equal(t, "SHIRT-L", products[1].SKU)
+			return nil
+		})
+	// Цена существующего товара изменилась: окно прежней цены закрывается
+	mockPriceRepo.EXPECT().GetByProductIDs(gomock.Any(), gomock.Len(2)).
+		Return(map[uuid.UUID]entities.PriceHistory{existing.ID: {currentPrice}}, nil)
+	mockPriceRepo.EXPECT().Update(gomock.Any(), currentPrice).Return(nil)
+	mockPriceRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	report, err := service.ImportProducts(context.Background(), []services.ProductImportRow{
+		{Line: 2, SKU: "SHIRT-M", Description: "Shirt M", Quantity: 5, Price: 1500},
+		{Line: 3, SKU: "SHIRT-L", Description: "Shirt L", Quantity: 3, Price: 1500},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 0, report.Failed)
+	assert.Equal(t, entities.ProductImportUpdated, report.Rows[0].Action)
+	assert.Equal(t, entities.ProductImportCreated, report.Rows[1].Action)
+	assert.NotNil(t, currentPrice.ValidTo)
+}
+
+func TestCatalogService_ImportProducts_AllocatesBackorders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewCatalogService(mockProductRepo, mockTxManager)
+
+	existing := &entities.Product{ID: uuid.New(), SKU: "CONSOLE", Description: "Console", Quantity: 1, Price: 1000}
+	waiting := &entities.OrderItem{ID: uuid.New(), ProductID: existing.ID, Quantity: 3, Backordered: 3}
+
+	expectCatalogTransaction(mockTxManager, mockProductRepo, mockPriceRepo, mockOrderRepo)
+	mockProductRepo.EXPECT().GetBySKUsForUpdate(gomock.Any(), []string{"CONSOLE"}).
+		Return([]*entities.Product{existing}, nil)
+	mockOrderRepo.EXPECT().GetBackorderedItemsForUpdate(gomock.Any(), existing.ID, gomock.Nil()).
+		Return([]*entities.OrderItem{waiting}, nil)
+	mockOrderRepo.EXPECT().UpdateItem(gomock.Any(), waiting).Return(nil)
+	// сохраняется остаток за вычетом распределенного ожидающему заказу
+	mockProductRepo.EXPECT().UpsertBySKU(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, products []*entities.Product) error {
+			assert.Equal(t, 2, products[0].Quantity)
+			return nil
+		})
+
+	report, err := service.ImportProducts(context.Background(), []services.ProductImportRow{
+		{Line: 2, SKU: "CONSOLE", Description: "Console", Quantity: 5, Price: 1000},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 0, waiting.Backordered)
+}
+
+func TestCatalogService_ImportProducts_ReportsInvalidRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewCatalogService(mockProductRepo, mockTxManager)
+
+	expectCatalogTransaction(mockTxManager, mockProductRepo, mockPriceRepo, mockOrderRepo)
+	mockProductRepo.EXPECT().GetBySKUsForUpdate(gomock.Any(), []string{"A"}).Return(nil, nil)
+	mockProductRepo.EXPECT().UpsertBySKU(gomock.Any(), gomock.Len(1)).Return(nil)
+	mockPriceRepo.EXPECT().GetByProductIDs(gomock.Any(), gomock.Len(1)).Return(nil, nil)
+	mockPriceRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	report, err := service.ImportProducts(context.Background(), []services.ProductImportRow{
+		{Line: 2, SKU: "A", Description: "Valid", Quantity: 1, Price: 100},
+		{Line: 3, SKU: "", Description: "No SKU", Quantity: 1, Price: 100},
+		{Line: 4, SKU: "A", Description: "Duplicate", Quantity: 1, Price: 100},
+		{Line: 5, SKU: "B", Description: "Free", Quantity: 1, Price: 0},
+		{Line: 6, DecodeErr: errors.New(`invalid quantity "x"`)},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 4, report.Failed)
+	assert.Equal(t, "sku is required", report.Rows[1].Error)
+	assert.Equal(t, "sku appears more than once in the import", report.Rows[2].Error)
+	assert.Equal(t, "price must be greater than 0", report.Rows[3].Error)
+	assert.Equal(t, `invalid quantity "x"`, report.Rows[4].Error)
+}
+
+func TestCatalogService_ImportProducts_FailedChunk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewCatalogService(mockProductRepo, mockTxManager)
+
+	expectCatalogTransaction(mockTxManager, mockProductRepo, mockPriceRepo, mockOrderRepo)
+	mockProductRepo.EXPECT().GetBySKUsForUpdate(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockProductRepo.EXPECT().UpsertBySKU(gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+
+	report, err := service.ImportProducts(context.Background(), []services.ProductImportRow{
+		{Line: 2, SKU: "A", Description: "A", Quantity: 1, Price: 100},
+		{Line: 3, SKU: "B", Description: "B", Quantity: 1, Price: 100},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, "connection reset", report.Rows[0].Error)
+}
+
+func TestCatalogService_ExportProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	service := NewCatalogService(mockProductRepo, mocks.NewMockTransactionManager(ctrl))
+
+	batch := []*entities.Product{{SKU: "A"}, {SKU: "B"}}
+	mockProductRepo.EXPECT().ForEachBatch(gomock.Any(), exportBatchSize, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, size int, fn func([]*entities.Product) error) error {
+			return fn(batch)
+		})
+
+	var exported []string
+	err := service.ExportProducts(context.Background(), func(product *entities.Product) error {
+		exported = append(exported, product.SKU)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, exported)
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/services"
+)
+
+type categoryService struct {
+	categoryRepo repositories.CategoryRepository
+}
+
+func NewCategoryService(categoryRepo repositories.CategoryRepository) services.CategoryService {
+	return &categoryService{
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (s *categoryService) CreateCategory(ctx context.Context, req *services.CreateCategoryRequest) (*entities.Category, error) {
+	var parent *entities.Category
+	if req.ParentID != nil {
+		var err error
+		parent, err = s.categoryRepo.GetByID(ctx, *req.ParentID)
+		if err != nil {
+			return nil, domainErrors.ErrCategoryNotFound
+		}
+	}
+
+	category, err := entities.NewCategory(req.Name, req.Slug, parent, req.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.categoryRepo.GetBySlug(ctx, category.Slug); err == nil {
+		return nil, domainErrors.ErrCategorySlugTaken
+	}
+
+	if err := s.categoryRepo.Create(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (s *categoryService) GetCategoryTree(ctx context.Context) ([]*entities.Category, error) {
+	categories, err := s.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.BuildCategoryTree(categories), nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestCategoryService_CreateCategory_WithParent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
+	service := NewCategoryService(mockCategoryRepo)
+
+	parent := &entities.Category{ID: uuid.New(), Name: "Одежда", Slug: "clothes"}
+
+	mockCategoryRepo.EXPECT().GetByID(gomock.Any(), parent.ID).Return(parent, nil)
+	mockCategoryRepo.EXPECT().GetBySlug(gomock.Any(), "shirts").Return(nil, gorm.ErrRecordNotFound)
+	mockCategoryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	category, err := service.CreateCategory(context.Background(), &services.CreateCategoryRequest{
+		ParentID: &parent.ID,
+		Name:     "Футболки",
+		Slug:     "shirts",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, parent.ID, *category.ParentID)
+}
+
+func TestCategoryService_CreateCategory_ParentNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
+	service := NewCategoryService(mockCategoryRepo)
+
+	parentID := uuid.New()
+	mockCategoryRepo.EXPECT().GetByID(gomock.Any(), parentID).Return(nil, gorm.ErrRecordNotFound)
+
+	category, err := service.CreateCategory(context.Background(), &services.CreateCategoryRequest{
+		ParentID: &parentID,
+		Name:     "Футболки",
+		Slug:     "shirts",
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, category)
+	assert.Equal(t, "category not found", err.Error())
+}
+
+func TestCategoryService_CreateCategory_SlugTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
+	service := NewCategoryService(mockCategoryRepo)
+
+	mockCategoryRepo.EXPECT().GetBySlug(gomock.Any(), "clothes").Return(&entities.Category{ID: uuid.New()}, nil)
+
+	category, err := service.CreateCategory(context.Background(), &services.CreateCategoryRequest{
+		Name: "Одежда",
+		Slug: "clothes",
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, category)
+	assert.Equal(t, "category slug is already taken", err.Error())
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// dueEmailsBatchSize ограничивает число писем, отправляемых за один проход
+const dueEmailsBatchSize = 100
+
+// emailClaimLease - на сколько забранное письмо скрыто от других экземпляров сервиса;
+// с запасом покрывает отправку всей пачки
+const emailClaimLease = 10 * time.Minute
+
+type emailService struct {
+	emailRepo repositories.EmailDeliveryRepository
+	orderRepo repositories.OrderRepository
+	userRepo  repositories.UserRepository
+	renderer  services.EmailRenderer
+	sender    services.EmailSender
+}
+
+func NewEmailService(
+	emailRepo repositories.EmailDeliveryRepository,
+	orderRepo repositories.OrderRepository,
+	userRepo repositories.UserRepository,
+	renderer services.EmailRenderer,
+	sender services.EmailSender,
+) services.EmailService {
+	return &emailService{
+		emailRepo: emailRepo,
+		orderRepo: orderRepo,
+		userRepo:  userRepo,
+		renderer:  renderer,
+		sender:    sender,
+	}
+}
+
+// DispatchEmails отправляет письма по одному: ошибка одного письма не останавливает остальные,
+// а неудачное письмо повторяется с растущей задержкой. Письма сначала забираются (ClaimDue), поэтому
+// задача, запущенная на нескольких экземплярах, не отправляет одно письмо дважды.
+func (s *emailService) DispatchEmails(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.emailRepo.ClaimDue(ctx, now, emailClaimLease, dueEmailsBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, delivery := range due {
+		if err := s.send(ctx, delivery); err != nil {
+			delivery.MarkFailed(err, now)
+			errs = append(errs, err)
+		} else {
+			delivery.MarkSent(now)
+			sent++
+		}
+
+		if err := s.emailRepo.Update(ctx, delivery); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func (s *emailService) GetOrderEmails(ctx context.Context, orderID uuid.UUID) ([]*entities.EmailDelivery, error) {
+	if _, err := s.orderRepo.GetByID(ctx, orderID); err != nil {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+
+	return s.emailRepo.GetByOrderID(ctx, orderID)
+}
+
+func (s *emailService) send(ctx context.Context, delivery *entities.EmailDelivery) error {
+	order, err := s.orderRepo.GetByID(ctx, delivery.OrderID)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, delivery.UserID)
+	if err != nil {
+		return err
+	}
+
+	message, err := s.renderer.RenderOrderEmail(delivery, order, user)
+	if err != nil {
+		return err
+	}
+	delivery.Subject = message.Subject
+
+	return s.sender.Send(ctx, message)
+}
+
+// queueOrderEmail ставит в очередь письмо покупателю о событии заказа. Без email письмо не создается.
+func queueOrderEmail(
+	ctx context.Context,
+	repos repositories.TransactionalRepositories,
+	event entities.OrderEvent,
+	order *entities.Order,
+	user *entities.User,
+) error {
+	if !user.CanReceiveEmail() {
+		return nil
+	}
+
+	return repos.EmailDeliveryRepository.Create(ctx, entities.NewOrderEmail(event, order, user))
+}
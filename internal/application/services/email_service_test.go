@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// stubEmailRenderer собирает письмо без шаблонов: тема - событие заказа
+type stubEmailRenderer struct{}
+
+func (stubEmailRenderer) RenderOrderEmail(
+	delivery *entities.EmailDelivery,
+	_ *entities.Order,
+	_ *entities.User,
+) (*entities.EmailMessage, error) {
+	return &entities.EmailMessage{To: delivery.Recipient, Subject: string(delivery.Event)}, nil
+}
+
+// stubEmailSender не доставляет письма на адреса из failFor и запоминает отправленные
+type stubEmailSender struct {
+	failFor map[string]bool
+	sent    []*entities.EmailMessage
+}
+
+func (s *stubEmailSender) Send(_ context.Context, message *entities.EmailMessage) error {
+	if s.failFor[message.To] {
+		return errors.New("mailbox unavailable")
+	}
+	s.sent = append(s.sent, message)
+	return nil
+}
+
+func TestEmailService_DispatchEmails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailRepo := mocks.NewMockEmailDeliveryRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	sender := &stubEmailSender{failFor: map[string]bool{"broken@example.com": true}}
+	service := NewEmailService(mockEmailRepo, mockOrderRepo, mockUserRepo, stubEmailRenderer{}, sender)
+
+	john := &entities.User{ID: uuid.New(), Email: "john@example.com", Language: "ru"}
+	broken := &entities.User{ID: uuid.New(), Email: "broken@example.com", Language: "ru"}
+	johnOrder := entities.NewOrder(john.ID)
+	brokenOrder := entities.NewOrder(broken.ID)
+	delivered := entities.NewOrderEmail(entities.OrderEventCreated, johnOrder, john)
+	failed := entities.NewOrderEmail(entities.OrderEventConfirmed, brokenOrder, broken)
+
+	now := time.Now()
+	mockEmailRepo.EXPECT().ClaimDue(gomock.Any(), now, emailClaimLease, dueEmailsBatchSize).
+		Return([]*entities.EmailDelivery{delivered, failed}, nil)
+	mockOrderRepo.EXPECT().GetByID(gomock.Any(), johnOrder.ID).Return(johnOrder, nil)
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), john.ID).Return(john, nil)
+	mockOrderRepo.EXPECT().GetByID(gomock.Any(), brokenOrder.ID).Return(brokenOrder, nil)
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), broken.ID).Return(broken, nil)
+	mockEmailRepo.EXPECT().Update(gomock.Any(), delivered).Return(nil)
+	mockEmailRepo.EXPECT().Update(gomock.Any(), failed).Return(nil)
+
+	sent, err := service.DispatchEmails(context.Background(), now)
+
+	// Ошибка одного письма не мешает отправке остальных
+	assert.Error(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, sender.sent, 1)
+	assert.Equal(t, entities.EmailDeliveryStatusSent, delivered.Status)
+	assert.Equal(t, "order_created", delivered.Subject)
+	assert.Equal(t, entities.EmailDeliveryStatusPending, failed.Status)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, "mailbox unavailable", failed.LastError)
+	assert.True(t, failed.NextAttemptAt.After(now))
+}
+
+func TestEmailService_GetOrderEmails_OrderNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	service := NewEmailService(
+		mocks.NewMockEmailDeliveryRepository(ctrl),
+		mockOrderRepo,
+		mocks.NewMockUserRepository(ctrl),
+		stubEmailRenderer{},
+		&stubEmailSender{},
+	)
+
+	orderID := uuid.New()
+	mockOrderRepo.EXPECT().GetByID(gomock.Any(), orderID).Return(nil, errors.New("record not found"))
+
+	deliveries, err := service.GetOrderEmails(context.Background(), orderID)
+
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	assert.Nil(t, deliveries)
+}
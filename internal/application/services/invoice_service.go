@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+type invoiceService struct {
+	invoiceRepo repositories.InvoiceRepository
+	txManager   repositories.TransactionManager
+	renderer    services.InvoiceRenderer
+	sellerName  string
+}
+
+func NewInvoiceService(
+	invoiceRepo repositories.InvoiceRepository,
+	txManager repositories.TransactionManager,
+	renderer services.InvoiceRenderer,
+	sellerName string,
+) services.InvoiceService {
+	return &invoiceService{
+		invoiceRepo: invoiceRepo,
+		txManager:   txManager,
+		renderer:    renderer,
+		sellerName:  sellerName,
+	}
+}
+
+func (s *invoiceService) GetOrIssueInvoice(ctx context.Context, orderID uuid.UUID) (*entities.Invoice, error) {
+	invoice, err := s.invoiceRepo.GetByOrderID(ctx, orderID)
+	if err == nil {
+		return invoice, nil
+	}
+	if !errors.Is(err, domainErrors.ErrInvoiceNotFound) {
+		return nil, err
+	}
+
+	invoice, err = s.issueInvoice(ctx, orderID)
+	if err != nil {
+		// Параллельный запрос мог выставить счет раньше нас - тогда транзакция
+		// откатилась вместе с выданным номером, и достаточно прочитать готовый счет
+		if existing, getErr := s.invoiceRepo.GetByOrderID(ctx, orderID); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func (s *invoiceService) GetInvoicePDF(ctx context.Context, invoice *entities.Invoice) ([]byte, error) {
+	return s.invoiceRepo.GetPDF(ctx, invoice.ID)
+}
+
+func (s *invoiceService) issueInvoice(ctx context.Context, orderID uuid.UUID) (*entities.Invoice, error) {
+	var resultInvoice *entities.Invoice
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		order, err := repos.OrderRepository.GetByID(ctx, orderID)
+		if err != nil {
+			return domainErrors.ErrOrderNotFound
+		}
+
+		// Проверяем статус до выдачи номера, чтобы не блокировать счетчик зря
+		if order.Status != entities.OrderStatusCompleted {
+			return domainErrors.ErrOnlyCompletedCanInvoice
+		}
+
+		user, err := repos.UserRepository.GetByID(ctx, order.UserID)
+		if err != nil {
+			return domainErrors.ErrUserNotFound
+		}
+
+		issuedAt := time.Now().UTC()
+
+		sequence, err := repos.InvoiceRepository.NextSequence(ctx, issuedAt.Year())
+		if err != nil {
+			return err
+		}
+
+		invoice, err := entities.NewInvoice(order, user, s.sellerName, sequence, issuedAt)
+		if err != nil {
+			return err
+		}
+
+		pdf, err := s.renderer.RenderPDF(invoice)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.InvoiceRepository.Create(ctx, invoice, pdf); err != nil {
+			return err
+		}
+
+		resultInvoice = invoice
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultInvoice, nil
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeInvoiceRenderer struct{}
+
+func (r *fakeInvoiceRenderer) RenderPDF(invoice *entities.Invoice) ([]byte, error) {
+	return []byte("%PDF " + invoice.Number), nil
+}
+
+func expectInvoiceTransaction(
+	mockTxManager *mocks.MockTransactionManager,
+	mockOrderRepo *mocks.MockOrderRepository,
+	mockUserRepo *mocks.MockUserRepository,
+	mockInvoiceRepo *mocks.MockInvoiceRepository,
+) {
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			repos := repositories.TransactionalRepositories{
+				OrderRepository:   mockOrderRepo,
+				UserRepository:    mockUserRepo,
+				InvoiceRepository: mockInvoiceRepo,
+			}
+			return fn(ctx, repos)
+		},
+	)
+}
+
+func TestInvoiceService_GetOrIssueInvoice_Existing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewInvoiceService(mockInvoiceRepo, mockTxManager, &fakeInvoiceRenderer{}, "Orders Service")
+
+	orderID := uuid.New()
+	invoice := &entities.Invoice{ID: uuid.New(), OrderID: orderID, Number: "INV-2025-000001"}
+
+	mockInvoiceRepo.EXPECT().GetByOrderID(gomock.Any(), orderID).Return(invoice, nil)
+
+	result, err := service.GetOrIssueInvoice(context.Background(), orderID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, invoice, result)
+}
+
+func TestInvoiceService_GetOrIssueInvoice_Issues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewInvoiceService(mockInvoiceRepo, mockTxManager, &fakeInvoiceRenderer{}, "Orders Service")
+
+	user := &entities.User{ID: uuid.New(), FirstName: "John", LastName: "Doe"}
+	product := &entities.Product{ID: uuid.New(), Description: "Test Product", Quantity: 5, Price: 700}
+	order := entities.NewOrder(user.ID)
+	assert.NoError(t, order.AddItem(product, 2))
+	assert.NoError(t, order.Confirm())
+	assert.NoError(t, order.Complete())
+
+	year := time.Now().UTC().Year()
+
+	mockInvoiceRepo.EXPECT().GetByOrderID(gomock.Any(), order.ID).Return(nil, domainErrors.ErrInvoiceNotFound)
+	expectInvoiceTransaction(mockTxManager, mockOrderRepo, mockUserRepo, mockInvoiceRepo)
+	mockOrderRepo.EXPECT().GetByID(gomock.Any(), order.ID).Return(order, nil)
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+	mockInvoiceRepo.EXPECT().NextSequence(gomock.Any(), year).Return(7, nil)
+	mockInvoiceRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, invoice *entities.Invoice, pdf []byte) error {
+			assert.Equal(t, "%PDF "+invoice.Number, string(pdf))
+			return nil
+		})
+
+	invoice, err := service.GetOrIssueInvoice(context.Background(), order.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.FormatInvoiceNumber(year, 7), invoice.Number)
+	assert.Equal(t, int64(1400), invoice.Total)
+}
+
+func TestInvoiceService_GetOrIssueInvoice_OrderNotCompleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewInvoiceService(mockInvoiceRepo, mockTxManager, &fakeInvoiceRenderer{}, "Orders Service")
+
+	order := entities.NewOrder(uuid.New())
+
+	// Номер счета не должен выдаваться для незавершенного заказа
+	mockInvoiceRepo.EXPECT().GetByOrderID(gomock.Any(), order.ID).Return(nil, domainErrors.ErrInvoiceNotFound).Times(2)
+	expectInvoiceTransaction(mockTxManager, mockOrderRepo, mockUserRepo, mockInvoiceRepo)
+	mockOrderRepo.EXPECT().GetByID(gomock.Any(), order.ID).Return(order, nil)
+
+	invoice, err := service.GetOrIssueInvoice(context.Background(), order.ID)
+
+	assert.Error(t, err)
+	assert.Nil(t, invoice)
+	assert.Equal(t, "invoices are issued only for completed orders", err.Error())
+}
+
+func TestInvoiceService_GetOrIssueInvoice_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewInvoiceService(mockInvoiceRepo, mockTxManager, &fakeInvoiceRenderer{}, "Orders Service")
+
+	orderID := uuid.New()
+	dbErr := errors.New("connection reset")
+
+	mockInvoiceRepo.EXPECT().GetByOrderID(gomock.Any(), orderID).Return(nil, dbErr)
+
+	invoice, err := service.GetOrIssueInvoice(context.Background(), orderID)
+
+	assert.ErrorIs(t, err, dbErr)
+	assert.Nil(t, invoice)
+}
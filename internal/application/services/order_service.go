@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/AndrivA89/orders/internal/domain/entities"
 	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
@@ -11,11 +12,18 @@ import (
 	"github.com/google/uuid"
 )
 
+// riskUnavailableReason - причина отложенного заказа, если сервис оценки риска не ответил
+const riskUnavailableReason = "risk scoring unavailable"
+
+// expireBatchSize - сколько просроченных заказов загружается за один запрос
+const expireBatchSize = 100
+
 type orderService struct {
 	orderRepo   repositories.OrderRepository
 	userRepo    repositories.UserRepository
 	productRepo repositories.ProductRepository
 	txManager   repositories.TransactionManager
+	policy      services.OrderPolicy
 }
 
 func NewOrderService(
@@ -23,51 +31,69 @@ func NewOrderService(
 	userRepo repositories.UserRepository,
 	productRepo repositories.ProductRepository,
 	txManager repositories.TransactionManager,
+	policy services.OrderPolicy,
 ) services.OrderService {
 	return &orderService{
 		orderRepo:   orderRepo,
 		userRepo:    userRepo,
 		productRepo: productRepo,
 		txManager:   txManager,
+		policy:      policy,
 	}
 }
 
+// reservation - товар или вариант, остаток которого списывается после проверки правил заказа.
+// item - индекс позиции заказа, в которую записывается недостача при предзаказе.
+type reservation struct {
+	product  *entities.Product
+	variant  *entities.ProductVariant
+	item     int
+	quantity int
+}
+
+// CreateOrder резервирует товар и сохраняет заказ в одной транзакции. Оценка риска выполняется уже после
+// нее, чтобы медленный или недоступный антифрод не держал блокировки пользователя и товаров. Рискованный
+// заказ откладывается следующей короткой транзакцией, и только после этого о заказе сообщается покупателю
+// и партнерам. Если она не удалась, заказ остается неподтвержденным и снимается по истечении резерва.
 func (s *orderService) CreateOrder(ctx context.Context, request *services.OrderRequest) (*entities.Order, error) {
 	if len(request.Items) == 0 {
 		return nil, domainErrors.ErrOrderMustHaveItems
 	}
 
-	var resultOrder *entities.Order
+	var (
+		resultOrder *entities.Order
+		user        *entities.User
+		history     *entities.UserOrderHistory
+	)
 
 	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
-		// Verify user exists
-		_, err := repos.UserRepository.GetByID(ctx, request.UserID)
+		// Блокировка пользователя выстраивает его параллельные заказы в очередь,
+		// иначе лимиты на пользователя можно обойти одновременными запросами
+		var err error
+		user, err = repos.UserRepository.GetByIDForUpdate(ctx, request.UserID)
 		if err != nil {
 			return domainErrors.ErrUserNotFound
 		}
 
 		order := entities.NewOrder(request.UserID)
 
-		// Process each item with quantity reservation
-		for _, itemReq := range request.Items {
-			// Lock product row to prevent race conditions
-			product, err := repos.ProductRepository.GetByIDForUpdate(ctx, itemReq.ProductID)
-			if err != nil {
-				return err
-			}
+		// Сначала собираем заказ целиком, резервирование выполняется только после проверки правил
+		reservations, err := s.buildOrder(ctx, repos, order, request.Items)
+		if err != nil {
+			return err
+		}
 
-			// Add item to order (includes availability check)
-			if err := order.AddItem(product, itemReq.Quantity); err != nil {
-				return err
-			}
+		history, err = s.loadUserHistory(ctx, repos, user.ID, order.CreatedAt)
+		if err != nil {
+			return err
+		}
 
-			// Reserve quantity in product
-			if err := product.ReserveQuantity(itemReq.Quantity); err != nil {
-				return err
-			}
+		if err := s.policy.Limits.Check(order, history); err != nil {
+			return err
+		}
 
-			// Update product with reserved quantity
-			if err := repos.ProductRepository.Update(ctx, product); err != nil {
+		for _, r := range reservations {
+			if err := reserve(ctx, repos, &order.Items[r.item], r); err != nil {
 				return err
 			}
 		}
@@ -78,7 +104,52 @@ func (s *orderService) CreateOrder(ctx context.Context, request *services.OrderR
 		}
 
 		resultOrder = order
-		return nil
+		if s.policy.RiskScorer != nil {
+			return nil
+		}
+
+		return recordOrderEvent(ctx, repos, entities.OrderEventCreated, order, user)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if s.policy.RiskScorer == nil {
+		return resultOrder, nil
+	}
+
+	return s.screenOrder(ctx, resultOrder, user, s.assessRisk(ctx, user, resultOrder, history))
+}
+
+// screenOrder откладывает заказ на ручную проверку, если оценка риска достигла порога, и сообщает о
+// созданном заказе. Клиент узнает номер заказа только из ответа, поэтому подтвердить заказ до этого
+// никто не может, и блокировка нужна лишь против отмены по истечении резерва.
+func (s *orderService) screenOrder(
+	ctx context.Context,
+	order *entities.Order,
+	user *entities.User,
+	assessment *entities.RiskAssessment,
+) (*entities.Order, error) {
+	var resultOrder *entities.Order
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		current, err := repos.OrderRepository.GetByIDForUpdate(ctx, order.ID)
+		if err != nil {
+			return domainErrors.ErrOrderNotFound
+		}
+
+		if assessment.Score >= s.policy.HoldThreshold {
+			if err := current.Hold(assessment); err != nil {
+				return err
+			}
+			if err := repos.OrderRepository.Update(ctx, current); err != nil {
+				return err
+			}
+		}
+
+		resultOrder = current
+		return recordOrderEvent(ctx, repos, entities.OrderEventCreated, current, user)
 	})
 
 	if err != nil {
@@ -97,27 +168,342 @@ func (s *orderService) GetOrdersByUserID(ctx context.Context, userID uuid.UUID,
 }
 
 func (s *orderService) ConfirmOrder(ctx context.Context, orderID uuid.UUID) error {
-	order, err := s.orderRepo.GetByID(ctx, orderID)
-	if err != nil {
+	return s.changeOrder(ctx, orderID, entities.OrderEventConfirmed, (*entities.Order).Confirm)
+}
+
+// CancelOrder отменяет заказ по запросу и, как отмена по истечении срока, возвращает его резерв на склад
+func (s *orderService) CancelOrder(ctx context.Context, orderID uuid.UUID) error {
+	return s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		order, err := repos.OrderRepository.GetByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return domainErrors.ErrOrderNotFound
+		}
+
+		return cancelOrder(ctx, repos, order)
+	})
+}
+
+// ReleaseOrder блокирует заказ до сохранения: иначе его могла бы одновременно отменить отмена по истечении срока
+func (s *orderService) ReleaseOrder(ctx context.Context, orderID uuid.UUID) error {
+	return s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		order, err := repos.OrderRepository.GetByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return domainErrors.ErrOrderNotFound
+		}
+
+		if err := order.Release(); err != nil {
+			return err
+		}
+
+		return repos.OrderRepository.Update(ctx, order)
+	})
+}
+
+func (s *orderService) ExpireReservations(ctx context.Context, before, heldBefore time.Time) (int, error) {
+	expired, err := s.expireOrders(ctx, entities.OrderStatusPending, before)
+	if err != nil || heldBefore.IsZero() {
+		return expired, err
+	}
+
+	held, err := s.expireOrders(ctx, entities.OrderStatusOnHold, heldBefore)
+	return expired + held, err
+}
+
+// expireOrders отменяет пачками заказы в статусе status, резерв которых начат раньше before
+func (s *orderService) expireOrders(ctx context.Context, status entities.OrderStatus, before time.Time) (int, error) {
+	expired := 0
+
+	for {
+		orders, err := s.orderRepo.GetReservedBefore(ctx, status, before, expireBatchSize)
+		if err != nil {
+			return expired, err
+		}
+		if len(orders) == 0 {
+			return expired, nil
+		}
+
+		for _, order := range orders {
+			cancelled, err := s.expireOrder(ctx, order.ID, status)
+			if err != nil {
+				return expired, err
+			}
+			if cancelled {
+				expired++
+			}
+		}
+	}
+}
+
+// expireOrder отменяет просроченный заказ так же, как отмена по запросу. Заказ, статус которого за это
+// время изменился (например, его подтвердили или модератор вернул его в работу), не трогается.
+func (s *orderService) expireOrder(ctx context.Context, orderID uuid.UUID, status entities.OrderStatus) (bool, error) {
+	cancelled := false
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		order, err := repos.OrderRepository.GetByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return domainErrors.ErrOrderNotFound
+		}
+		if order.Status != status {
+			return nil
+		}
+
+		if err := cancelOrder(ctx, repos, order); err != nil {
+			return err
+		}
+
+		cancelled = true
+		return nil
+	})
+
+	return cancelled, err
+}
+
+// cancelOrder отменяет заблокированный заказ и возвращает зарезервированные единицы на склад, где их
+// в первую очередь получают ожидающие заказы, а затем ставит в очередь письмо и вебхуки об отмене
+func cancelOrder(ctx context.Context, repos repositories.TransactionalRepositories, order *entities.Order) error {
+	if err := order.Cancel(); err != nil {
 		return err
 	}
 
-	if err := order.Confirm(); err != nil {
+	for _, item := range order.Items {
+		if item.AllocatedQuantity() == 0 {
+			continue
+		}
+
+		product, err := repos.ProductRepository.GetByIDForUpdate(ctx, item.ProductID)
+		if err != nil {
+			return err
+		}
+
+		if item.VariantID == nil {
+			_, err = restockProduct(ctx, repos, product, item.AllocatedQuantity())
+		} else {
+			var variant *entities.ProductVariant
+			if variant, err = repos.ProductRepository.GetVariantByIDForUpdate(ctx, *item.VariantID); err == nil {
+				_, err = restockVariant(ctx, repos, product, variant, item.AllocatedQuantity())
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := repos.OrderRepository.Update(ctx, order); err != nil {
 		return err
 	}
 
-	return s.orderRepo.Update(ctx, order)
+	user, err := repos.UserRepository.GetByID(ctx, order.UserID)
+	if err != nil {
+		return domainErrors.ErrUserNotFound
+	}
+
+	return recordOrderEvent(ctx, repos, entities.OrderEventCancelled, order, user)
 }
 
-func (s *orderService) CancelOrder(ctx context.Context, orderID uuid.UUID) error {
-	order, err := s.orderRepo.GetByID(ctx, orderID)
+// changeOrder меняет статус заказа и ставит в очередь письмо покупателю в одной транзакции.
+// Заказ блокируется, чтобы переход проверялся по его актуальному статусу: подтверждение,
+// пришедшее одновременно с отменой по истечении срока, не должно подтвердить уже отмененный заказ.
+func (s *orderService) changeOrder(
+	ctx context.Context,
+	orderID uuid.UUID,
+	event entities.OrderEvent,
+	change func(*entities.Order) error,
+) error {
+	return s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		order, err := repos.OrderRepository.GetByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return domainErrors.ErrOrderNotFound
+		}
+
+		if err := change(order); err != nil {
+			return err
+		}
+
+		if err := repos.OrderRepository.Update(ctx, order); err != nil {
+			return err
+		}
+
+		user, err := repos.UserRepository.GetByID(ctx, order.UserID)
+		if err != nil {
+			return domainErrors.ErrUserNotFound
+		}
+
+		return recordOrderEvent(ctx, repos, event, order, user)
+	})
+}
+
+// recordOrderEvent ставит в очередь письмо покупателю и доставки вебхуков партнерам. Вызывается
+// в транзакции, изменившей заказ, поэтому о неудавшейся операции никто не узнает.
+func recordOrderEvent(
+	ctx context.Context,
+	repos repositories.TransactionalRepositories,
+	event entities.OrderEvent,
+	order *entities.Order,
+	user *entities.User,
+) error {
+	if err := queueOrderEmail(ctx, repos, event, order, user); err != nil {
+		return err
+	}
+
+	return queueWebhookDeliveries(ctx, repos, event, order)
+}
+
+// buildOrder добавляет позиции в заказ и возвращает, какие остатки нужно зарезервировать.
+// Товары и варианты загружаются один раз, чтобы повторы в запросе списывались с одного остатка.
+func (s *orderService) buildOrder(
+	ctx context.Context,
+	repos repositories.TransactionalRepositories,
+	order *entities.Order,
+	items []services.OrderItemRequest,
+) ([]reservation, error) {
+	products := make(map[uuid.UUID]*entities.Product, len(items))
+	variants := make(map[uuid.UUID]*entities.ProductVariant)
+	reservations := make([]reservation, 0, len(items))
+
+	for _, itemReq := range items {
+		product, ok := products[itemReq.ProductID]
+		if !ok {
+			// Lock product row to prevent race conditions
+			var err error
+			product, err = repos.ProductRepository.GetByIDForUpdate(ctx, itemReq.ProductID)
+			if err != nil {
+				return nil, err
+			}
+
+			// Цена берется из истории цен на момент заказа; у вариантов цена своя
+			if !product.HasVariants() {
+				if err := applyEffectivePrice(ctx, repos, product, order.CreatedAt); err != nil {
+					return nil, err
+				}
+			}
+			products[product.ID] = product
+		}
+
+		if itemReq.VariantID == nil {
+			// Add item to order (includes availability check)
+			if err := order.AddItem(product, itemReq.Quantity); err != nil {
+				return nil, err
+			}
+			reservations = append(reservations, reservation{
+				product:  product,
+				item:     len(order.Items) - 1,
+				quantity: itemReq.Quantity,
+			})
+			continue
+		}
+
+		variant, ok := variants[*itemReq.VariantID]
+		if !ok {
+			// Остаток варианта хранится в отдельной строке - блокируем именно ее
+			var err error
+			variant, err = repos.ProductRepository.GetVariantByIDForUpdate(ctx, *itemReq.VariantID)
+			if err != nil {
+				return nil, domainErrors.ErrVariantNotFound
+			}
+			variants[variant.ID] = variant
+		}
+
+		if err := order.AddVariantItem(product, variant, itemReq.Quantity); err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation{
+			product:  product,
+			variant:  variant,
+			item:     len(order.Items) - 1,
+			quantity: itemReq.Quantity,
+		})
+	}
+
+	return reservations, nil
+}
+
+// loadUserHistory загружает недавние покупки пользователя, только если их требуют настроенные правила
+// или оценка риска: повторная покупка тех же товаров за UserWindow - один из ее признаков
+func (s *orderService) loadUserHistory(
+	ctx context.Context,
+	repos repositories.TransactionalRepositories,
+	userID uuid.UUID,
+	now time.Time,
+) (*entities.UserOrderHistory, error) {
+	history := &entities.UserOrderHistory{}
+	limits := s.policy.Limits
+
+	if limits.TracksVelocity() {
+		count, err := repos.OrderRepository.CountByUserSince(ctx, userID, now.Add(-limits.VelocityWindow))
+		if err != nil {
+			return nil, err
+		}
+		history.RecentOrders = count
+	}
+
+	if limits.TracksUserQuantities() || (s.policy.RiskScorer != nil && limits.UserWindow > 0) {
+		quantities, err := repos.OrderRepository.QuantitiesByUserSince(ctx, userID, now.Add(-limits.UserWindow))
+		if err != nil {
+			return nil, err
+		}
+		history.RecentQuantities = quantities
+	}
+
+	return history, nil
+}
+
+// assessRisk оценивает риск нового заказа. Если оценить риск не удалось, заказ откладывается, а не отклоняется.
+func (s *orderService) assessRisk(
+	ctx context.Context,
+	user *entities.User,
+	order *entities.Order,
+	history *entities.UserOrderHistory,
+) *entities.RiskAssessment {
+	assessment, err := s.policy.RiskScorer.Score(ctx, &services.RiskInput{
+		User:    user,
+		Order:   order,
+		History: history,
+	})
 	if err != nil {
+		return &entities.RiskAssessment{Score: 1, Reasons: []string{riskUnavailableReason}}
+	}
+
+	return assessment
+}
+
+// reserve списывает зарезервированное количество с остатка товара или варианта.
+// Если товар принимает предзаказы, списывается только имеющийся остаток, а недостача записывается в позицию.
+func reserve(ctx context.Context, repos repositories.TransactionalRepositories, item *entities.OrderItem, r reservation) error {
+	available := r.product.Quantity
+	if r.variant != nil {
+		available = r.variant.Quantity
+	}
+
+	quantity := r.quantity
+	if r.product.AcceptsBackorders() {
+		quantity = min(quantity, available)
+		item.Backorder(r.quantity-quantity, r.product.ExpectedAt)
+	}
+
+	if quantity == 0 {
+		return nil
+	}
+
+	if r.variant != nil {
+		if err := r.variant.ReserveQuantity(quantity); err != nil {
+			return err
+		}
+		if err := repos.ProductRepository.UpdateVariant(ctx, r.variant); err != nil {
+			return err
+		}
+		return recordStockChange(ctx, repos, r.product, r.variant.StockChange(available))
+	}
+
+	// Reserve quantity in product
+	if err := r.product.ReserveQuantity(quantity); err != nil {
 		return err
 	}
 
-	if err := order.Cancel(); err != nil {
+	// Update product with reserved quantity
+	if err := repos.ProductRepository.Update(ctx, r.product); err != nil {
 		return err
 	}
 
-	return s.orderRepo.Update(ctx, order)
+	return recordStockChange(ctx, repos, r.product, r.product.StockChange(available))
 }
@@ -7,12 +7,14 @@ import (
 	"time"
 
 	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
 	"github.com/AndrivA89/orders/internal/domain/repositories"
 	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
 	"github.com/AndrivA89/orders/internal/domain/services"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 )
@@ -23,10 +25,12 @@ func TestOrderService_CreateOrder_Success(t *testing.T) {
 
 	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
 	mockTxManager := mocks.NewMockTransactionManager(ctrl)
 
-	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
 
 	userID := uuid.New()
 	productID := uuid.New()
@@ -61,15 +65,19 @@ func TestOrderService_CreateOrder_Success(t *testing.T) {
 			repos := repositories.TransactionalRepositories{
 				OrderRepository:   mockOrderRepo,
 				ProductRepository: mockProductRepo,
+				PriceRepository:   mockPriceRepo,
 				UserRepository:    mockUserRepo,
+				WebhookRepository: mockWebhookRepo,
 			}
 			return fn(ctx, repos)
 		},
 	)
-	mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID).Return(user, nil)
 	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), productID).Return(product, nil)
+	mockPriceRepo.EXPECT().GetEffective(gomock.Any(), productID, gomock.Any()).Return(nil, domainErrors.ErrPriceNotFound)
 	mockProductRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 	mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
 
 	order, err := service.CreateOrder(context.Background(), request)
 
@@ -86,9 +94,10 @@ func TestOrderService_CreateOrder_UserNotFound(t *testing.T) {
 
 	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTxManager := mocks.NewMockTransactionManager(ctrl)
-	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
 
 	userID := uuid.New()
 	productID := uuid.New()
@@ -108,12 +117,13 @@ func TestOrderService_CreateOrder_UserNotFound(t *testing.T) {
 			repos := repositories.TransactionalRepositories{
 				OrderRepository:   mockOrderRepo,
 				ProductRepository: mockProductRepo,
+				PriceRepository:   mockPriceRepo,
 				UserRepository:    mockUserRepo,
 			}
 			return fn(ctx, repos)
 		},
 	)
-	mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(nil, gorm.ErrRecordNotFound)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID).Return(nil, gorm.ErrRecordNotFound)
 
 	order, err := service.CreateOrder(context.Background(), request)
 
@@ -128,9 +138,10 @@ func TestOrderService_CreateOrder_InsufficientQuantity(t *testing.T) {
 
 	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTxManager := mocks.NewMockTransactionManager(ctrl)
-	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
 
 	userID := uuid.New()
 	productID := uuid.New()
@@ -157,13 +168,15 @@ func TestOrderService_CreateOrder_InsufficientQuantity(t *testing.T) {
 			repos := repositories.TransactionalRepositories{
 				OrderRepository:   mockOrderRepo,
 				ProductRepository: mockProductRepo,
+				PriceRepository:   mockPriceRepo,
 				UserRepository:    mockUserRepo,
 			}
 			return fn(ctx, repos)
 		},
 	)
-	mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(user, nil)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID).Return(user, nil)
 	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), productID).Return(product, nil)
+	mockPriceRepo.EXPECT().GetEffective(gomock.Any(), productID, gomock.Any()).Return(nil, domainErrors.ErrPriceNotFound)
 
 	order, err := service.CreateOrder(context.Background(), request)
 
@@ -180,7 +193,7 @@ func TestOrderService_CreateOrder_EmptyItems(t *testing.T) {
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTxManager := mocks.NewMockTransactionManager(ctrl)
-	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
 
 	userID := uuid.New()
 	request := &services.OrderRequest{
@@ -202,12 +215,16 @@ func TestOrderService_ConfirmOrder_Success(t *testing.T) {
 	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockEmailRepo := mocks.NewMockEmailDeliveryRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
 	mockTxManager := mocks.NewMockTransactionManager(ctrl)
-	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
 
+	user := &entities.User{ID: uuid.New(), Email: "ivan@example.com", Language: "en"}
 	orderID := uuid.New()
 	order := &entities.Order{
 		ID:     orderID,
+		UserID: user.ID,
 		Status: entities.OrderStatusPending,
 		Items: []entities.OrderItem{
 			{
@@ -217,12 +234,376 @@ func TestOrderService_ConfirmOrder_Success(t *testing.T) {
 		},
 	}
 
-	mockOrderRepo.EXPECT().GetByID(gomock.Any(), orderID).Return(order, nil)
+	var queued *entities.EmailDelivery
+	expectOrderEventTransaction(mockTxManager, mockOrderRepo, mockUserRepo, mockEmailRepo, mockWebhookRepo)
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), orderID).Return(order, nil)
 	mockOrderRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+	mockEmailRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, delivery *entities.EmailDelivery) error {
+			queued = delivery
+			return nil
+		},
+	)
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
 
 	err := service.ConfirmOrder(context.Background(), orderID)
 
 	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderEventConfirmed, queued.Event)
+	assert.Equal(t, "ivan@example.com", queued.Recipient)
+	assert.Equal(t, "en", queued.Language)
+	assert.Equal(t, entities.EmailDeliveryStatusPending, queued.Status)
+}
+
+func TestOrderService_CancelOrder_WithoutEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockEmailRepo := mocks.NewMockEmailDeliveryRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mocks.NewMockProductRepository(ctrl), mockTxManager, services.OrderPolicy{})
+
+	user := &entities.User{ID: uuid.New()}
+	order := entities.NewOrder(user.ID)
+
+	// Пользователь без email письма не получает, заказ отменяется как обычно
+	expectOrderEventTransaction(mockTxManager, mockOrderRepo, mockUserRepo, mockEmailRepo, mockWebhookRepo)
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), order.ID).Return(order, nil)
+	mockOrderRepo.EXPECT().Update(gomock.Any(), order).Return(nil)
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
+
+	err := service.CancelOrder(context.Background(), order.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusCancelled, order.Status)
+}
+
+func TestOrderService_CancelOrder_ReleasesStockToBackorders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockStockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
+
+	user := &entities.User{ID: uuid.New()}
+	product := &entities.Product{ID: uuid.New(), Description: "Console", StockPolicy: entities.StockPolicyBackorder}
+	order := entities.NewOrder(user.ID)
+	order.Status = entities.OrderStatusConfirmed
+	order.Items = []entities.OrderItem{{ID: uuid.New(), ProductID: product.ID, Quantity: 3}}
+	// другой заказ ждет две единицы этого товара
+	waiting := &entities.OrderItem{ID: uuid.New(), ProductID: product.ID, Quantity: 2, Backordered: 2}
+
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				OrderRepository:      mockOrderRepo,
+				UserRepository:       mockUserRepo,
+				ProductRepository:    mockProductRepo,
+				StockAlertRepository: mockStockAlertRepo,
+				WebhookRepository:    mockWebhookRepo,
+			})
+		},
+	)
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), order.ID).Return(order, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockOrderRepo.EXPECT().GetBackorderedItemsForUpdate(gomock.Any(), product.ID, gomock.Nil()).Return([]*entities.OrderItem{waiting}, nil)
+	mockOrderRepo.EXPECT().UpdateItem(gomock.Any(), waiting).Return(nil)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+	mockStockAlertRepo.EXPECT().GetSubscribersForUpdate(gomock.Any(), product.ID, gomock.Nil()).Return(nil, nil).AnyTimes()
+	mockOrderRepo.EXPECT().Update(gomock.Any(), order).Return(nil)
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
+
+	err := service.CancelOrder(context.Background(), order.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusCancelled, order.Status)
+	assert.Equal(t, 0, waiting.Backordered)
+	assert.Equal(t, 1, product.Quantity)
+}
+
+func TestOrderService_CancelOrder_AlreadyCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mocks.NewMockProductRepository(ctrl), mockTxManager, services.OrderPolicy{})
+
+	order := entities.NewOrder(uuid.New())
+	order.Status = entities.OrderStatusCancelled
+
+	// ни резерв, ни письмо с вебхуком повторно не появляются
+	expectOrderEventTransaction(mockTxManager, mockOrderRepo, mockUserRepo, nil, nil)
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), order.ID).Return(order, nil)
+
+	err := service.CancelOrder(context.Background(), order.ID)
+
+	assert.ErrorIs(t, err, domainErrors.ErrOrderAlreadyCancelled)
+}
+
+func TestOrderService_ExpireReservations_ReleasesStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockStockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
+
+	user := &entities.User{ID: uuid.New()}
+	product := &entities.Product{ID: uuid.New(), Description: "Console"}
+	stale := entities.NewOrder(user.ID)
+	// из трех единиц зарезервированы две, третья ждала поступления
+	stale.Items = []entities.OrderItem{{ID: uuid.New(), ProductID: product.ID, Quantity: 3, Backordered: 1}}
+	confirmed := entities.NewOrder(user.ID)
+	before := time.Now()
+
+	mockOrderRepo.EXPECT().GetReservedBefore(gomock.Any(), entities.OrderStatusPending, before, expireBatchSize).
+		Return([]*entities.Order{stale, confirmed}, nil)
+	mockOrderRepo.EXPECT().GetReservedBefore(gomock.Any(), entities.OrderStatusPending, before, expireBatchSize).Return(nil, nil)
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				OrderRepository:      mockOrderRepo,
+				UserRepository:       mockUserRepo,
+				ProductRepository:    mockProductRepo,
+				StockAlertRepository: mockStockAlertRepo,
+				WebhookRepository:    mockWebhookRepo,
+			})
+		},
+	)
+
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), stale.ID).Return(stale, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockOrderRepo.EXPECT().GetBackorderedItemsForUpdate(gomock.Any(), product.ID, gomock.Nil()).Return(nil, nil)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+	mockStockAlertRepo.EXPECT().GetSubscribersForUpdate(gomock.Any(), product.ID, gomock.Nil()).Return(nil, nil)
+	mockOrderRepo.EXPECT().Update(gomock.Any(), stale).Return(nil)
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
+
+	// заказ подтвердили, пока шла выборка: его резерв остается на месте
+	confirmedNow := *confirmed
+	confirmedNow.Status = entities.OrderStatusConfirmed
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), confirmed.ID).Return(&confirmedNow, nil)
+
+	expired, err := service.ExpireReservations(context.Background(), before, time.Time{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, entities.OrderStatusCancelled, stale.Status)
+	assert.Equal(t, 2, product.Quantity)
+}
+
+func TestOrderService_ExpireReservations_CancelsUnreviewedHeldOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockStockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
+
+	user := &entities.User{ID: uuid.New()}
+	product := &entities.Product{ID: uuid.New(), Description: "Console"}
+	held := entities.NewOrder(user.ID)
+	held.Items = []entities.OrderItem{{ID: uuid.New(), ProductID: product.ID, Quantity: 2}}
+	require.NoError(t, held.Hold(&entities.RiskAssessment{Score: 0.9, Reasons: []string{"new account"}}))
+	before, heldBefore := time.Now(), time.Now().Add(-48*time.Hour)
+
+	mockOrderRepo.EXPECT().GetReservedBefore(gomock.Any(), entities.OrderStatusPending, before, expireBatchSize).Return(nil, nil)
+	mockOrderRepo.EXPECT().GetReservedBefore(gomock.Any(), entities.OrderStatusOnHold, heldBefore, expireBatchSize).
+		Return([]*entities.Order{held}, nil)
+	mockOrderRepo.EXPECT().GetReservedBefore(gomock.Any(), entities.OrderStatusOnHold, heldBefore, expireBatchSize).Return(nil, nil)
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				OrderRepository:      mockOrderRepo,
+				UserRepository:       mockUserRepo,
+				ProductRepository:    mockProductRepo,
+				StockAlertRepository: mockStockAlertRepo,
+				WebhookRepository:    mockWebhookRepo,
+			})
+		},
+	)
+
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), held.ID).Return(held, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockOrderRepo.EXPECT().GetBackorderedItemsForUpdate(gomock.Any(), product.ID, gomock.Nil()).Return(nil, nil)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+	mockStockAlertRepo.EXPECT().GetSubscribersForUpdate(gomock.Any(), product.ID, gomock.Nil()).Return(nil, nil)
+	mockOrderRepo.EXPECT().Update(gomock.Any(), held).Return(nil)
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
+
+	expired, err := service.ExpireReservations(context.Background(), before, heldBefore)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, entities.OrderStatusCancelled, held.Status)
+	assert.Equal(t, 2, product.Quantity)
+}
+
+// rowLock имитирует блокировку строки заказа: GetByIDForUpdate ждет ее, а снимается она
+// только по завершении транзакции, которая ее взяла
+type rowLock struct {
+	mu sync.Mutex
+}
+
+type rowLockHolder struct {
+	locked bool
+}
+
+type rowLockKey struct{}
+
+func (l *rowLock) lock(ctx context.Context) {
+	l.mu.Lock()
+	ctx.Value(rowLockKey{}).(*rowLockHolder).locked = true
+}
+
+func (l *rowLock) transaction(repos repositories.TransactionalRepositories) func(context.Context, func(context.Context, repositories.TransactionalRepositories) error) error {
+	return func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+		holder := &rowLockHolder{}
+		err := fn(context.WithValue(ctx, rowLockKey{}, holder), repos)
+		if holder.locked {
+			l.mu.Unlock()
+		}
+		return err
+	}
+}
+
+func TestOrderService_ConfirmOrder_RacesWithExpiry(t *testing.T) {
+	for range 20 {
+		ctrl := gomock.NewController(t)
+
+		mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+		mockProductRepo := mocks.NewMockProductRepository(ctrl)
+		mockStockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+		mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+		mockTxManager := mocks.NewMockTransactionManager(ctrl)
+		service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
+
+		user := &entities.User{ID: uuid.New()}
+		product := &entities.Product{ID: uuid.New(), Description: "Console"}
+		stored := entities.NewOrder(user.ID)
+		stored.Items = []entities.OrderItem{{ID: uuid.New(), ProductID: product.ID, Quantity: 2}}
+		orderID := stored.ID
+
+		// каждая транзакция получает свою копию строки, сохраненное состояние видят только следующие
+		var (
+			row  rowLock
+			data sync.Mutex
+		)
+		load := func() *entities.Order {
+			data.Lock()
+			defer data.Unlock()
+			order := *stored
+			order.Items = append([]entities.OrderItem(nil), stored.Items...)
+			return &order
+		}
+
+		mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(row.transaction(
+			repositories.TransactionalRepositories{
+				OrderRepository:      mockOrderRepo,
+				UserRepository:       mockUserRepo,
+				ProductRepository:    mockProductRepo,
+				StockAlertRepository: mockStockAlertRepo,
+				WebhookRepository:    mockWebhookRepo,
+			},
+		))
+		mockOrderRepo.EXPECT().GetReservedBefore(gomock.Any(), entities.OrderStatusPending, gomock.Any(), expireBatchSize).
+			DoAndReturn(func(context.Context, entities.OrderStatus, time.Time, int) ([]*entities.Order, error) {
+				return []*entities.Order{load()}, nil
+			})
+		mockOrderRepo.EXPECT().GetReservedBefore(gomock.Any(), entities.OrderStatusPending, gomock.Any(), expireBatchSize).Return(nil, nil)
+		mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), orderID).Times(2).DoAndReturn(
+			func(ctx context.Context, _ uuid.UUID) (*entities.Order, error) {
+				row.lock(ctx)
+				return load(), nil
+			},
+		)
+		mockOrderRepo.EXPECT().Update(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(_ context.Context, order *entities.Order) error {
+				data.Lock()
+				defer data.Unlock()
+				stored = order
+				return nil
+			},
+		)
+		mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).AnyTimes().Return(product, nil)
+		mockOrderRepo.EXPECT().GetBackorderedItemsForUpdate(gomock.Any(), product.ID, gomock.Nil()).AnyTimes().Return(nil, nil)
+		mockProductRepo.EXPECT().Update(gomock.Any(), product).AnyTimes().Return(nil)
+		mockStockAlertRepo.EXPECT().GetSubscribersForUpdate(gomock.Any(), product.ID, gomock.Nil()).AnyTimes().Return(nil, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), user.ID).AnyTimes().Return(user, nil)
+		mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).AnyTimes().Return(nil, nil)
+
+		var (
+			wg         sync.WaitGroup
+			confirmErr error
+		)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			confirmErr = service.ConfirmOrder(context.Background(), orderID)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := service.ExpireReservations(context.Background(), time.Now(), time.Time{})
+			assert.NoError(t, err)
+		}()
+		wg.Wait()
+
+		// побеждает одна из операций: подтвержденный заказ сохраняет резерв, отмененный - возвращает его
+		final := load()
+		if final.Status == entities.OrderStatusConfirmed {
+			assert.NoError(t, confirmErr)
+			assert.Equal(t, 0, product.Quantity)
+		} else {
+			assert.Equal(t, entities.OrderStatusCancelled, final.Status)
+			assert.ErrorIs(t, confirmErr, domainErrors.ErrOnlyPendingCanConfirm)
+			assert.Equal(t, 2, product.Quantity)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func expectOrderEventTransaction(
+	mockTxManager *mocks.MockTransactionManager,
+	mockOrderRepo *mocks.MockOrderRepository,
+	mockUserRepo *mocks.MockUserRepository,
+	mockEmailRepo *mocks.MockEmailDeliveryRepository,
+	mockWebhookRepo *mocks.MockWebhookRepository,
+) {
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				OrderRepository:         mockOrderRepo,
+				UserRepository:          mockUserRepo,
+				EmailDeliveryRepository: mockEmailRepo,
+				WebhookRepository:       mockWebhookRepo,
+			})
+		},
+	)
 }
 
 // TestOrderService_CreateOrder_RaceCondition проверяет корректность работы при конкурентном доступе
@@ -232,10 +613,12 @@ func TestOrderService_CreateOrder_RaceCondition(t *testing.T) {
 
 	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
 	mockTxManager := mocks.NewMockTransactionManager(ctrl)
 
-	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
 
 	userID1 := uuid.New()
 	userID2 := uuid.New()
@@ -272,14 +655,19 @@ func TestOrderService_CreateOrder_RaceCondition(t *testing.T) {
 			repos := repositories.TransactionalRepositories{
 				OrderRepository:   mockOrderRepo,
 				ProductRepository: mockProductRepo,
+				PriceRepository:   mockPriceRepo,
 				UserRepository:    mockUserRepo,
+				WebhookRepository: mockWebhookRepo,
 			}
 			return fn(ctx, repos)
 		},
 	).Times(2)
 
+	mockPriceRepo.EXPECT().GetEffective(gomock.Any(), productID, gomock.Any()).
+		Return(nil, domainErrors.ErrPriceNotFound).Times(2)
+
 	// Настраиваем моки для первого успешного запроса
-	mockUserRepo.EXPECT().GetByID(gomock.Any(), userID1).Return(user1, nil)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID1).Return(user1, nil)
 	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), productID).Return(product, nil)
 	mockProductRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
 		func(ctx context.Context, p *entities.Product) error {
@@ -288,9 +676,10 @@ func TestOrderService_CreateOrder_RaceCondition(t *testing.T) {
 			return nil
 		})
 	mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
 
 	// Настраиваем моки для второго неуспешного запроса
-	mockUserRepo.EXPECT().GetByID(gomock.Any(), userID2).Return(user2, nil)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID2).Return(user2, nil)
 	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), productID).DoAndReturn(
 		func(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
 			// Возвращаем товар с нулевым остатком (уже купил первый пользователь)
@@ -329,3 +718,477 @@ func TestOrderService_CreateOrder_RaceCondition(t *testing.T) {
 	assert.Equal(t, 1, successful, "Должен пройти только один заказ")
 	assert.Equal(t, 1, failed, "Один заказ должен завершиться ошибкой")
 }
+
+func TestOrderService_CreateOrder_ReservesVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
+
+	userID := uuid.New()
+	product := &entities.Product{ID: uuid.New(), Name: "Shirt", Description: "Cotton shirt"}
+	variant := &entities.ProductVariant{
+		ID:         uuid.New(),
+		ProductID:  product.ID,
+		SKU:        "SHIRT-M",
+		Attributes: map[string]string{"size": "M"},
+		Price:      1500,
+		Quantity:   5,
+	}
+	product.Variants = []entities.ProductVariant{*variant}
+
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			repos := repositories.TransactionalRepositories{
+				OrderRepository:   mockOrderRepo,
+				ProductRepository: mockProductRepo,
+				PriceRepository:   mockPriceRepo,
+				UserRepository:    mockUserRepo,
+				WebhookRepository: mockWebhookRepo,
+			}
+			return fn(ctx, repos)
+		},
+	)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID).Return(&entities.User{ID: userID}, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockProductRepo.EXPECT().GetVariantByIDForUpdate(gomock.Any(), variant.ID).Return(variant, nil)
+	mockProductRepo.EXPECT().UpdateVariant(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, v *entities.ProductVariant) error {
+			assert.Equal(t, 3, v.Quantity)
+			return nil
+		})
+	mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
+
+	order, err := service.CreateOrder(context.Background(), &services.OrderRequest{
+		UserID: userID,
+		Items:  []services.OrderItemRequest{{ProductID: product.ID, VariantID: &variant.ID, Quantity: 2}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3000), order.Total)
+	assert.Equal(t, "SHIRT-M", order.Items[0].ProductSnapshot.SKU)
+}
+
+func TestOrderService_CreateOrder_UsesEffectivePrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
+
+	userID := uuid.New()
+	// Распродажа уже началась, но фоновая задача еще не обновила карточку товара
+	product := &entities.Product{ID: uuid.New(), Description: "Shirt", Quantity: 10, Price: 1000}
+	sale := &entities.PriceChange{ID: uuid.New(), ProductID: product.ID, Price: 800, ValidFrom: time.Now().Add(-time.Minute)}
+
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			repos := repositories.TransactionalRepositories{
+				OrderRepository:   mockOrderRepo,
+				ProductRepository: mockProductRepo,
+				PriceRepository:   mockPriceRepo,
+				UserRepository:    mockUserRepo,
+				WebhookRepository: mockWebhookRepo,
+			}
+			return fn(ctx, repos)
+		},
+	)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID).Return(&entities.User{ID: userID}, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockPriceRepo.EXPECT().GetEffective(gomock.Any(), product.ID, gomock.Any()).Return(sale, nil)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+	mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
+
+	order, err := service.CreateOrder(context.Background(), &services.OrderRequest{
+		UserID: userID,
+		Items:  []services.OrderItemRequest{{ProductID: product.ID, Quantity: 2}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(800), order.Items[0].PricePerItem)
+	assert.Equal(t, int64(800), order.Items[0].ProductSnapshot.Price)
+	assert.Equal(t, int64(1600), order.Total)
+}
+
+type stubRiskScorer struct {
+	assessment *entities.RiskAssessment
+	err        error
+	input      *services.RiskInput
+}
+
+func (s *stubRiskScorer) Score(ctx context.Context, input *services.RiskInput) (*entities.RiskAssessment, error) {
+	s.input = input
+	return s.assessment, s.err
+}
+
+// expectOrderTransaction прокидывает моки репозиториев в транзакцию создания заказа
+func expectOrderTransaction(
+	mockTxManager *mocks.MockTransactionManager,
+	mockOrderRepo *mocks.MockOrderRepository,
+	mockProductRepo *mocks.MockProductRepository,
+	mockPriceRepo *mocks.MockPriceRepository,
+	mockUserRepo *mocks.MockUserRepository,
+	mockWebhookRepo *mocks.MockWebhookRepository,
+) *gomock.Call {
+	return mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				OrderRepository:   mockOrderRepo,
+				ProductRepository: mockProductRepo,
+				PriceRepository:   mockPriceRepo,
+				UserRepository:    mockUserRepo,
+				WebhookRepository: mockWebhookRepo,
+			})
+		},
+	)
+}
+
+func TestOrderService_CreateOrder_UserQuantityLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{
+		Limits: entities.OrderLimits{MaxQuantityPerUser: 5, UserWindow: 24 * time.Hour},
+	})
+
+	userID := uuid.New()
+	product := &entities.Product{ID: uuid.New(), Description: "Console", Quantity: 10, Price: 1000}
+
+	expectOrderTransaction(mockTxManager, mockOrderRepo, mockProductRepo, mockPriceRepo, mockUserRepo, mockWebhookRepo)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID).Return(&entities.User{ID: userID}, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockPriceRepo.EXPECT().GetEffective(gomock.Any(), product.ID, gomock.Any()).Return(nil, domainErrors.ErrPriceNotFound)
+	mockOrderRepo.EXPECT().QuantitiesByUserSince(gomock.Any(), userID, gomock.Any()).Return(map[uuid.UUID]int{product.ID: 4}, nil)
+
+	order, err := service.CreateOrder(context.Background(), &services.OrderRequest{
+		UserID: userID,
+		Items:  []services.OrderItemRequest{{ProductID: product.ID, Quantity: 2}},
+	})
+
+	// Остаток не списывается, если заказ не прошел проверку лимитов
+	assert.ErrorIs(t, err, domainErrors.ErrUserProductQuantityLimit)
+	assert.Nil(t, order)
+	assert.Equal(t, 10, product.Quantity)
+}
+
+func TestOrderService_CreateOrder_VelocityLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{
+		Limits: entities.OrderLimits{MaxOrdersPerUser: 3, VelocityWindow: time.Hour},
+	})
+
+	userID := uuid.New()
+	product := &entities.Product{ID: uuid.New(), Description: "Console", Quantity: 10, Price: 1000}
+
+	expectOrderTransaction(mockTxManager, mockOrderRepo, mockProductRepo, mockPriceRepo, mockUserRepo, mockWebhookRepo)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID).Return(&entities.User{ID: userID}, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockPriceRepo.EXPECT().GetEffective(gomock.Any(), product.ID, gomock.Any()).Return(nil, domainErrors.ErrPriceNotFound)
+	mockOrderRepo.EXPECT().CountByUserSince(gomock.Any(), userID, gomock.Any()).Return(3, nil)
+
+	order, err := service.CreateOrder(context.Background(), &services.OrderRequest{
+		UserID: userID,
+		Items:  []services.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+	})
+
+	assert.ErrorIs(t, err, domainErrors.ErrOrderVelocityLimit)
+	assert.Nil(t, order)
+}
+
+func TestOrderService_CreateOrder_HoldsRiskyOrder(t *testing.T) {
+	tests := []struct {
+		name           string
+		scorer         *stubRiskScorer
+		expectedStatus entities.OrderStatus
+		expectedReason string
+	}{
+		{
+			name:           "score below threshold",
+			scorer:         &stubRiskScorer{assessment: &entities.RiskAssessment{Score: 0.3, Reasons: []string{"new account"}}},
+			expectedStatus: entities.OrderStatusPending,
+		},
+		{
+			name:           "score above threshold",
+			scorer:         &stubRiskScorer{assessment: &entities.RiskAssessment{Score: 0.8, Reasons: []string{"new account", "high order total"}}},
+			expectedStatus: entities.OrderStatusOnHold,
+			expectedReason: "new account; high order total",
+		},
+		{
+			name:           "scorer failure",
+			scorer:         &stubRiskScorer{err: assert.AnError},
+			expectedStatus: entities.OrderStatusOnHold,
+			expectedReason: "risk scoring unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+			mockProductRepo := mocks.NewMockProductRepository(ctrl)
+			mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+			mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+			service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{
+				RiskScorer:    tt.scorer,
+				HoldThreshold: 0.7,
+			})
+
+			userID := uuid.New()
+			product := &entities.Product{ID: uuid.New(), Description: "Console", Quantity: 10, Price: 1000}
+
+			// заказ создается в одной транзакции, а откладывается и попадает в очереди уведомлений в следующей
+			var created *entities.Order
+			expectOrderTransaction(mockTxManager, mockOrderRepo, mockProductRepo, mockPriceRepo, mockUserRepo, mockWebhookRepo).Times(2)
+			mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID).Return(&entities.User{ID: userID}, nil)
+			mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+			mockPriceRepo.EXPECT().GetEffective(gomock.Any(), product.ID, gomock.Any()).Return(nil, domainErrors.ErrPriceNotFound)
+			mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+			mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *entities.Order) error {
+				created = order
+				return nil
+			})
+			mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID) (*entities.Order, error) {
+				// оценка риска выполняется вне транзакции создания заказа
+				assert.Equal(t, entities.OrderStatusPending, created.Status)
+				return created, nil
+			})
+			if tt.expectedStatus == entities.OrderStatusOnHold {
+				mockOrderRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			}
+			mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
+
+			order, err := service.CreateOrder(context.Background(), &services.OrderRequest{
+				UserID: userID,
+				Items:  []services.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+			})
+
+			// Отложенный заказ держит резерв товара до решения модератора
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, order.Status)
+			assert.Equal(t, tt.expectedReason, order.HoldReason)
+			assert.Equal(t, 9, product.Quantity)
+		})
+	}
+}
+
+func TestOrderService_CreateOrder_ScorerSeesRecentPurchasesWithoutUserLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	// лимит на пользователя не задан, но окно для признака повторной покупки есть
+	scorer := &stubRiskScorer{assessment: &entities.RiskAssessment{}}
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{
+		Limits:        entities.OrderLimits{UserWindow: 24 * time.Hour},
+		RiskScorer:    scorer,
+		HoldThreshold: 0.7,
+	})
+
+	userID := uuid.New()
+	product := &entities.Product{ID: uuid.New(), Description: "Console", Quantity: 10, Price: 1000}
+
+	var created *entities.Order
+	expectOrderTransaction(mockTxManager, mockOrderRepo, mockProductRepo, mockPriceRepo, mockUserRepo, mockWebhookRepo).Times(2)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID).Return(&entities.User{ID: userID}, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockPriceRepo.EXPECT().GetEffective(gomock.Any(), product.ID, gomock.Any()).Return(nil, domainErrors.ErrPriceNotFound)
+	mockOrderRepo.EXPECT().QuantitiesByUserSince(gomock.Any(), userID, gomock.Any()).Return(map[uuid.UUID]int{product.ID: 1}, nil)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+	mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *entities.Order) error {
+		created = order
+		return nil
+	})
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID) (*entities.Order, error) {
+		return created, nil
+	})
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
+
+	order, err := service.CreateOrder(context.Background(), &services.OrderRequest{
+		UserID: userID,
+		Items:  []services.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusPending, order.Status)
+	require.NotNil(t, scorer.input)
+	assert.Equal(t, 1, scorer.input.History.RecentQuantities[product.ID])
+}
+
+func TestOrderService_ReleaseOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewOrderService(
+		mocks.NewMockOrderRepository(ctrl),
+		mocks.NewMockUserRepository(ctrl),
+		mocks.NewMockProductRepository(ctrl),
+		mockTxManager,
+		services.OrderPolicy{},
+	)
+
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{OrderRepository: mockOrderRepo})
+		},
+	)
+
+	held := entities.NewOrder(uuid.New())
+	held.Status = entities.OrderStatusOnHold
+	pending := entities.NewOrder(uuid.New())
+
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), held.ID).Return(held, nil)
+	mockOrderRepo.EXPECT().Update(gomock.Any(), held).Return(nil)
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), pending.ID).Return(pending, nil)
+
+	assert.NoError(t, service.ReleaseOrder(context.Background(), held.ID))
+	assert.Equal(t, entities.OrderStatusPending, held.Status)
+
+	err := service.ReleaseOrder(context.Background(), pending.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrOnlyOnHoldCanRelease)
+}
+
+func TestOrderService_CreateOrder_Backorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
+
+	userID := uuid.New()
+	expectedAt := time.Now().AddDate(0, 1, 0)
+	product := &entities.Product{
+		ID:          uuid.New(),
+		Description: "Console",
+		Quantity:    1,
+		Price:       1000,
+		StockPolicy: entities.StockPolicyPreorder,
+		ExpectedAt:  &expectedAt,
+	}
+
+	expectOrderTransaction(mockTxManager, mockOrderRepo, mockProductRepo, mockPriceRepo, mockUserRepo, mockWebhookRepo)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), userID).Return(&entities.User{ID: userID}, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockPriceRepo.EXPECT().GetEffective(gomock.Any(), product.ID, gomock.Any()).Return(nil, domainErrors.ErrPriceNotFound)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+	mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
+
+	// Вторая позиция с тем же товаром целиком уходит в ожидание
+	order, err := service.CreateOrder(context.Background(), &services.OrderRequest{
+		UserID: userID,
+		Items: []services.OrderItemRequest{
+			{ProductID: product.ID, Quantity: 3},
+			{ProductID: product.ID, Quantity: 2},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, product.Quantity)
+	assert.Equal(t, 2, order.Items[0].Backordered)
+	assert.Equal(t, &expectedAt, order.Items[0].ExpectedAt)
+	assert.Equal(t, 2, order.Items[1].Backordered)
+	assert.Equal(t, int64(5000), order.Total)
+}
+
+func TestOrderService_CreateOrder_QueuesLowStockAlert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockStockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewOrderService(mockOrderRepo, mockUserRepo, mockProductRepo, mockTxManager, services.OrderPolicy{})
+
+	user := &entities.User{ID: uuid.New()}
+	product := &entities.Product{ID: uuid.New(), Description: "Console", Quantity: 5, Price: 1000, LowStockThreshold: 3}
+
+	var queued []*entities.StockNotification
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				OrderRepository:      mockOrderRepo,
+				ProductRepository:    mockProductRepo,
+				PriceRepository:      mockPriceRepo,
+				UserRepository:       mockUserRepo,
+				StockAlertRepository: mockStockAlertRepo,
+				WebhookRepository:    mockWebhookRepo,
+			})
+		},
+	)
+	mockUserRepo.EXPECT().GetByIDForUpdate(gomock.Any(), user.ID).Return(user, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockPriceRepo.EXPECT().GetEffective(gomock.Any(), product.ID, gomock.Any()).Return(nil, domainErrors.ErrPriceNotFound)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+	mockStockAlertRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, notifications []*entities.StockNotification) error {
+			queued = notifications
+			return nil
+		},
+	)
+	mockOrderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return(nil, nil)
+
+	_, err := service.CreateOrder(context.Background(), &services.OrderRequest{
+		UserID: user.ID,
+		Items:  []services.OrderItemRequest{{ProductID: product.ID, Quantity: 3}},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, queued, 1)
+	assert.Equal(t, entities.StockNotificationLowStock, queued[0].Kind)
+	assert.Equal(t, 2, queued[0].Quantity)
+}
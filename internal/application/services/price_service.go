@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// duePricesBatchSize - сколько наступивших цен применяется за один запуск фоновой задачи
+const duePricesBatchSize = 100
+
+type priceService struct {
+	priceRepo   repositories.PriceRepository
+	productRepo repositories.ProductRepository
+	txManager   repositories.TransactionManager
+}
+
+func NewPriceService(
+	priceRepo repositories.PriceRepository,
+	productRepo repositories.ProductRepository,
+	txManager repositories.TransactionManager,
+) services.PriceService {
+	return &priceService{
+		priceRepo:   priceRepo,
+		productRepo: productRepo,
+		txManager:   txManager,
+	}
+}
+
+func (s *priceService) SchedulePrice(
+	ctx context.Context,
+	productID uuid.UUID,
+	req *services.SchedulePriceRequest,
+) (*entities.PriceChange, error) {
+	now := time.Now()
+	validFrom := now
+	if req.EffectiveFrom != nil {
+		if !req.EffectiveFrom.After(now) {
+			return nil, domainErrors.ErrPriceStartInPast
+		}
+		validFrom = *req.EffectiveFrom
+	}
+
+	change, err := entities.NewPriceChange(productID, req.Price, validFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		// Блокировка товара упорядочивает параллельные изменения его цены
+		product, err := repos.ProductRepository.GetByIDForUpdate(ctx, productID)
+		if err != nil {
+			return domainErrors.ErrProductNotFound
+		}
+
+		if product.HasVariants() {
+			return domainErrors.ErrProductPricedByVariants
+		}
+
+		history, err := repos.PriceRepository.GetByProductID(ctx, product.ID)
+		if err != nil {
+			return err
+		}
+
+		// Товары, созданные до появления истории цен, получают начальную запись с текущей ценой
+		if len(history) == 0 {
+			baseline := initialPrice(product)
+			if err := recordPriceChange(ctx, repos.PriceRepository, history, baseline); err != nil {
+				return err
+			}
+			history = entities.PriceHistory{baseline}
+		}
+
+		if req.EffectiveFrom == nil {
+			change.MarkApplied(now)
+			product.ApplyPrice(change)
+
+			if err := repos.ProductRepository.Update(ctx, product); err != nil {
+				return err
+			}
+		}
+
+		return recordPriceChange(ctx, repos.PriceRepository, history, change)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+func (s *priceService) GetPriceHistory(ctx context.Context, productID uuid.UUID) (entities.PriceHistory, error) {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, domainErrors.ErrProductNotFound
+	}
+
+	return s.priceRepo.GetByProductID(ctx, productID)
+}
+
+func (s *priceService) ApplyScheduledPrices(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.priceRepo.GetDue(ctx, now, duePricesBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Ошибка по одному товару не должна задерживать цены остальных товаров
+	var (
+		applied int
+		errs    []error
+	)
+	for _, change := range due {
+		if err := s.applyPrice(ctx, change, now); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		applied++
+	}
+
+	return applied, errors.Join(errs...)
+}
+
+func (s *priceService) applyPrice(ctx context.Context, change *entities.PriceChange, now time.Time) error {
+	return s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		product, err := repos.ProductRepository.GetByIDForUpdate(ctx, change.ProductID)
+		if err != nil {
+			return err
+		}
+
+		// Если с прошлого запуска наступило несколько цен, в карточку попадает только действующая
+		if change.IsEffectiveAt(now) {
+			product.ApplyPrice(change)
+			if err := repos.ProductRepository.Update(ctx, product); err != nil {
+				return err
+			}
+		}
+
+		change.MarkApplied(now)
+		return repos.PriceRepository.Update(ctx, change)
+	})
+}
+
+// recordPriceChange встраивает изменение в историю цен и сохраняет его вместе с закрытым окном предыдущей цены
+func recordPriceChange(
+	ctx context.Context,
+	priceRepo repositories.PriceRepository,
+	history entities.PriceHistory,
+	change *entities.PriceChange,
+) error {
+	previous, err := history.Insert(change)
+	if err != nil {
+		return err
+	}
+
+	if previous != nil {
+		if err := priceRepo.Update(ctx, previous); err != nil {
+			return err
+		}
+	}
+
+	return priceRepo.Create(ctx, change)
+}
+
+// applyEffectivePrice подставляет в товар цену, действующую в момент at: фоновая задача
+// может еще не успеть перенести наступившую цену в карточку товара
+func applyEffectivePrice(
+	ctx context.Context,
+	repos repositories.TransactionalRepositories,
+	product *entities.Product,
+	at time.Time,
+) error {
+	change, err := repos.PriceRepository.GetEffective(ctx, product.ID, at)
+	if errors.Is(err, domainErrors.ErrPriceNotFound) {
+		// У товаров, созданных до появления истории цен, действует цена из карточки
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	product.ApplyPrice(change)
+	return nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func expectPriceTransaction(
+	mockTxManager *mocks.MockTransactionManager,
+	mockProductRepo *mocks.MockProductRepository,
+	mockPriceRepo *mocks.MockPriceRepository,
+) {
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			repos := repositories.TransactionalRepositories{
+				ProductRepository: mockProductRepo,
+				PriceRepository:   mockPriceRepo,
+			}
+			return fn(ctx, repos)
+		},
+	)
+}
+
+func TestPriceService_SchedulePrice_Future(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewPriceService(mockPriceRepo, mockProductRepo, mockTxManager)
+
+	createdAt := time.Now().AddDate(0, -1, 0)
+	product := &entities.Product{ID: uuid.New(), Description: "Shirt", Price: 1000, CreatedAt: createdAt}
+	current := &entities.PriceChange{ID: uuid.New(), ProductID: product.ID, Price: 1000, ValidFrom: createdAt, AppliedAt: &createdAt}
+	saleStart := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	expectPriceTransaction(mockTxManager, mockProductRepo, mockPriceRepo)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockPriceRepo.EXPECT().GetByProductID(gomock.Any(), product.ID).Return(entities.PriceHistory{current}, nil)
+	mockPriceRepo.EXPECT().Update(gomock.Any(), current).Return(nil)
+	mockPriceRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	change, err := service.SchedulePrice(context.Background(), product.ID, &services.SchedulePriceRequest{
+		Price:         800,
+		EffectiveFrom: &saleStart,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, change.IsApplied())
+	assert.Equal(t, saleStart, *current.ValidTo)
+	// Карточка товара меняется только когда цена наступит
+	assert.Equal(t, int64(1000), product.Price)
+}
+
+func TestPriceService_SchedulePrice_ImmediateCreatesBaseline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewPriceService(mockPriceRepo, mockProductRepo, mockTxManager)
+
+	product := &entities.Product{ID: uuid.New(), Description: "Shirt", Price: 1000, CreatedAt: time.Now().AddDate(0, -1, 0)}
+
+	var created []*entities.PriceChange
+	expectPriceTransaction(mockTxManager, mockProductRepo, mockPriceRepo)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockPriceRepo.EXPECT().GetByProductID(gomock.Any(), product.ID).Return(entities.PriceHistory{}, nil)
+	mockPriceRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, change *entities.PriceChange) error {
+			created = append(created, change)
+			return nil
+		}).Times(2)
+	mockPriceRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+
+	change, err := service.SchedulePrice(context.Background(), product.ID, &services.SchedulePriceRequest{Price: 1200})
+
+	assert.NoError(t, err)
+	assert.True(t, change.IsApplied())
+	assert.Equal(t, int64(1200), product.Price)
+
+	assert.Len(t, created, 2)
+	assert.Equal(t, int64(1000), created[0].Price)
+	assert.Equal(t, change.ValidFrom, *created[0].ValidTo)
+}
+
+func TestPriceService_SchedulePrice_InPast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewPriceService(
+		mocks.NewMockPriceRepository(ctrl),
+		mocks.NewMockProductRepository(ctrl),
+		mocks.NewMockTransactionManager(ctrl),
+	)
+
+	yesterday := time.Now().Add(-24 * time.Hour)
+	change, err := service.SchedulePrice(context.Background(), uuid.New(), &services.SchedulePriceRequest{
+		Price:         800,
+		EffectiveFrom: &yesterday,
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, change)
+	assert.Equal(t, "scheduled price must start in the future", err.Error())
+}
+
+func TestPriceService_SchedulePrice_ProductWithVariants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewPriceService(mockPriceRepo, mockProductRepo, mockTxManager)
+
+	product := &entities.Product{
+		ID:       uuid.New(),
+		Variants: []entities.ProductVariant{{ID: uuid.New(), SKU: "SHIRT-M", Price: 1500}},
+	}
+
+	expectPriceTransaction(mockTxManager, mockProductRepo, mockPriceRepo)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+
+	change, err := service.SchedulePrice(context.Background(), product.ID, &services.SchedulePriceRequest{Price: 1200})
+
+	assert.Error(t, err)
+	assert.Nil(t, change)
+	assert.Equal(t, "product with variants is priced per variant", err.Error())
+}
+
+func TestPriceService_ApplyScheduledPrices_SkipsSuperseded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewPriceService(mockPriceRepo, mockProductRepo, mockTxManager)
+
+	now := time.Now()
+	product := &entities.Product{ID: uuid.New(), Description: "Shirt", Price: 1000}
+
+	// Задача не запускалась двое суток: первая цена уже сменилась второй
+	secondStart := now.Add(-24 * time.Hour)
+	superseded := &entities.PriceChange{ProductID: product.ID, Price: 900, ValidFrom: now.Add(-48 * time.Hour), ValidTo: &secondStart}
+	effective := &entities.PriceChange{ProductID: product.ID, Price: 800, ValidFrom: secondStart}
+
+	mockPriceRepo.EXPECT().GetDue(gomock.Any(), now, gomock.Any()).Return([]*entities.PriceChange{superseded, effective}, nil)
+	for range 2 {
+		expectPriceTransaction(mockTxManager, mockProductRepo, mockPriceRepo)
+	}
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil).Times(2)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil).Times(1)
+	mockPriceRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	applied, err := service.ApplyScheduledPrices(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	assert.Equal(t, int64(800), product.Price)
+	assert.True(t, superseded.IsApplied())
+	assert.True(t, effective.IsApplied())
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
 	"github.com/AndrivA89/orders/internal/domain/repositories"
 	"github.com/AndrivA89/orders/internal/domain/services"
 
@@ -12,31 +13,68 @@ import (
 )
 
 type productService struct {
-	productRepo repositories.ProductRepository
+	productRepo  repositories.ProductRepository
+	categoryRepo repositories.CategoryRepository
+	txManager    repositories.TransactionManager
 }
 
-func NewProductService(productRepo repositories.ProductRepository) services.ProductService {
+func NewProductService(
+	productRepo repositories.ProductRepository,
+	categoryRepo repositories.CategoryRepository,
+	txManager repositories.TransactionManager,
+) services.ProductService {
 	return &productService{
-		productRepo: productRepo,
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+		txManager:    txManager,
 	}
 }
 
 func (s *productService) CreateProduct(ctx context.Context, req *services.CreateProductRequest) (*entities.Product, error) {
 	product := &entities.Product{
 		ID:          uuid.New(),
+		SKU:         req.SKU,
+		Name:        req.Name,
 		Description: req.Description,
 		Tags:        req.Tags,
+		Attributes:  req.Attributes,
 		Quantity:    req.Quantity,
 		Price:       req.Price,
+		StockPolicy: req.StockPolicy,
+		ExpectedAt:  req.ExpectedAt,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
+	for _, variantReq := range req.Variants {
+		variant := newVariant(product.ID, &variantReq)
+		product.Variants = append(product.Variants, *variant)
+	}
+
 	if err := product.ValidateForCreation(); err != nil {
 		return nil, err
 	}
 
-	if err := s.productRepo.Create(ctx, product); err != nil {
+	categories, err := s.resolveCategories(ctx, req.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	product.Categories = categories
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		if err := repos.ProductRepository.Create(ctx, product); err != nil {
+			return err
+		}
+
+		// У товаров с вариантами цена ведется по вариантам, история цен товара не нужна
+		if product.HasVariants() {
+			return nil
+		}
+
+		return recordPriceChange(ctx, repos.PriceRepository, nil, initialPrice(product))
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
@@ -47,6 +85,389 @@ func (s *productService) GetProductByID(ctx context.Context, id uuid.UUID) (*ent
 	return s.productRepo.GetByID(ctx, id)
 }
 
+func (s *productService) GetProductsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.Product, error) {
+	products, err := s.productRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID]*entities.Product, len(products))
+	for _, product := range products {
+		result[product.ID] = product
+	}
+
+	return result, nil
+}
+
 func (s *productService) GetProducts(ctx context.Context, limit, offset int) ([]*entities.Product, error) {
 	return s.productRepo.GetAll(ctx, limit, offset)
 }
+
+func (s *productService) AddVariant(
+	ctx context.Context,
+	productID uuid.UUID,
+	req *services.CreateVariantRequest,
+) (*entities.ProductVariant, error) {
+	var variant *entities.ProductVariant
+
+	// Блокировка товара не дает двум запросам одновременно добавить варианты с одинаковыми атрибутами
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		product, err := repos.ProductRepository.GetByIDForUpdate(ctx, productID)
+		if err != nil {
+			return domainErrors.ErrProductNotFound
+		}
+
+		variant = newVariant(product.ID, req)
+		if err := product.AddVariant(variant); err != nil {
+			return err
+		}
+
+		return repos.ProductRepository.CreateVariant(ctx, variant)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+func (s *productService) ReceiveStock(
+	ctx context.Context,
+	productID uuid.UUID,
+	req *services.ReceiveStockRequest,
+) (*entities.StockReceipt, error) {
+	if req.Quantity <= 0 {
+		return nil, domainErrors.ErrQuantityInvalid
+	}
+
+	var receipt *entities.StockReceipt
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		// Блокировка товара выстраивает поступления и новые заказы в очередь
+		product, err := repos.ProductRepository.GetByIDForUpdate(ctx, productID)
+		if err != nil {
+			return domainErrors.ErrProductNotFound
+		}
+
+		receipt = &entities.StockReceipt{ProductID: product.ID, VariantID: req.VariantID, Received: req.Quantity}
+
+		if req.VariantID == nil {
+			if product.HasVariants() {
+				return domainErrors.ErrVariantRequired
+			}
+
+			receipt.Allocated, err = restockProduct(ctx, repos, product, req.Quantity)
+			receipt.Available = product.Quantity
+			return err
+		}
+
+		variant, err := repos.ProductRepository.GetVariantByIDForUpdate(ctx, *req.VariantID)
+		if err != nil || variant.ProductID != product.ID {
+			return domainErrors.ErrVariantNotFound
+		}
+
+		receipt.Allocated, err = restockVariant(ctx, repos, product, variant, req.Quantity)
+		receipt.Available = variant.Quantity
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// ReconcileStock отдает свободный остаток ожидающим заказам там, где он появился в обход поступления
+// (например, после правки остатка прямо в БД). Каждый товар обрабатывается в своей транзакции.
+func (s *productService) ReconcileStock(ctx context.Context) ([]*entities.StockReceipt, error) {
+	productIDs, err := s.productRepo.GetBackorderedIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var receipts []*entities.StockReceipt
+	for _, productID := range productIDs {
+		err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+			product, err := repos.ProductRepository.GetByIDForUpdate(ctx, productID)
+			if err != nil {
+				return err
+			}
+
+			if !product.HasVariants() {
+				allocated, err := allocateProductStock(ctx, repos, product, product.Quantity)
+				if allocated > 0 {
+					receipts = append(receipts, &entities.StockReceipt{
+						ProductID: product.ID,
+						Allocated: allocated,
+						Available: product.Quantity,
+					})
+				}
+				return err
+			}
+
+			for i := range product.Variants {
+				variant, err := repos.ProductRepository.GetVariantByIDForUpdate(ctx, product.Variants[i].ID)
+				if err != nil {
+					return err
+				}
+
+				allocated, err := allocateVariantStock(ctx, repos, product, variant, variant.Quantity)
+				if err != nil {
+					return err
+				}
+				if allocated > 0 {
+					receipts = append(receipts, &entities.StockReceipt{
+						ProductID: product.ID,
+						VariantID: &variant.ID,
+						Allocated: allocated,
+						Available: variant.Quantity,
+					})
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return receipts, err
+		}
+	}
+
+	return receipts, nil
+}
+
+func (s *productService) GetProductsByCategory(
+	ctx context.Context,
+	categorySlug string,
+	limit, offset int,
+) ([]*entities.Product, error) {
+	category, err := s.categoryRepo.GetBySlug(ctx, categorySlug)
+	if err != nil {
+		return nil, domainErrors.ErrCategoryNotFound
+	}
+
+	return s.productRepo.GetByCategory(ctx, category.ID, limit, offset)
+}
+
+func (s *productService) SetProductCategories(
+	ctx context.Context,
+	productID uuid.UUID,
+	categoryIDs []uuid.UUID,
+) (*entities.Product, error) {
+	var product *entities.Product
+
+	// Связи заменяются удалением и вставкой: блокировка товара не дает двум запросам перемешать наборы
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		var err error
+		product, err = repos.ProductRepository.GetByIDForUpdate(ctx, productID)
+		if err != nil {
+			return domainErrors.ErrProductNotFound
+		}
+
+		categories, err := s.resolveCategories(ctx, categoryIDs)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(categories))
+		for i, category := range categories {
+			ids[i] = category.ID
+		}
+
+		if err := repos.ProductRepository.SetCategories(ctx, product.ID, ids); err != nil {
+			return err
+		}
+
+		product.Categories = categories
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// resolveCategories проверяет, что все категории существуют, и убирает повторы
+func (s *productService) resolveCategories(ctx context.Context, categoryIDs []uuid.UUID) ([]entities.CategoryRef, error) {
+	if len(categoryIDs) == 0 {
+		return nil, nil
+	}
+
+	unique := make([]uuid.UUID, 0, len(categoryIDs))
+	seen := make(map[uuid.UUID]struct{}, len(categoryIDs))
+	for _, id := range categoryIDs {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			unique = append(unique, id)
+		}
+	}
+
+	categories, err := s.categoryRepo.GetByIDs(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) != len(unique) {
+		return nil, domainErrors.ErrCategoryNotFound
+	}
+
+	refs := make([]entities.CategoryRef, len(categories))
+	for i, category := range categories {
+		refs[i] = category.Ref()
+	}
+
+	return refs, nil
+}
+
+// restockProduct возвращает единицы на склад и отдает их ожидающим заказам; вызывается под блокировкой товара
+func restockProduct(
+	ctx context.Context,
+	repos repositories.TransactionalRepositories,
+	product *entities.Product,
+	quantity int,
+) (int, error) {
+	before := product.Quantity
+	if err := product.Restock(quantity); err != nil {
+		return 0, err
+	}
+
+	return allocateProductStock(ctx, repos, product, before)
+}
+
+// restockVariant - то же, что restockProduct, для остатка варианта
+func restockVariant(
+	ctx context.Context,
+	repos repositories.TransactionalRepositories,
+	product *entities.Product,
+	variant *entities.ProductVariant,
+	quantity int,
+) (int, error) {
+	before := variant.Quantity
+	if err := variant.Restock(quantity); err != nil {
+		return 0, err
+	}
+
+	return allocateVariantStock(ctx, repos, product, variant, before)
+}
+
+// allocateProductStock отдает свободный остаток товара ожидающим заказам и сохраняет товар.
+// before - остаток до изменения, по нему ставятся в очередь уведомления об остатках.
+func allocateProductStock(
+	ctx context.Context,
+	repos repositories.TransactionalRepositories,
+	product *entities.Product,
+	before int,
+) (int, error) {
+	allocated, err := allocateBackorders(ctx, repos.OrderRepository, product.ID, nil, product.Quantity)
+	if err != nil {
+		return 0, err
+	}
+
+	if allocated > 0 {
+		if err := product.ReserveQuantity(allocated); err != nil {
+			return 0, err
+		}
+	}
+
+	// сверка остатков без распределения ничего не меняет
+	if allocated == 0 && product.Quantity == before {
+		return 0, nil
+	}
+
+	if err := repos.ProductRepository.Update(ctx, product); err != nil {
+		return 0, err
+	}
+
+	return allocated, recordStockChange(ctx, repos, product, product.StockChange(before))
+}
+
+// allocateVariantStock - то же, что allocateProductStock, для остатка варианта
+func allocateVariantStock(
+	ctx context.Context,
+	repos repositories.TransactionalRepositories,
+	product *entities.Product,
+	variant *entities.ProductVariant,
+	before int,
+) (int, error) {
+	allocated, err := allocateBackorders(ctx, repos.OrderRepository, variant.ProductID, &variant.ID, variant.Quantity)
+	if err != nil {
+		return 0, err
+	}
+
+	if allocated > 0 {
+		if err := variant.ReserveQuantity(allocated); err != nil {
+			return 0, err
+		}
+	}
+
+	// сверка остатков без распределения ничего не меняет
+	if allocated == 0 && variant.Quantity == before {
+		return 0, nil
+	}
+
+	if err := repos.ProductRepository.UpdateVariant(ctx, variant); err != nil {
+		return 0, err
+	}
+
+	return allocated, recordStockChange(ctx, repos, product, variant.StockChange(before))
+}
+
+// allocateBackorders распределяет свободный остаток по ожидающим позициям в порядке создания заказов
+// и возвращает, сколько единиц было распределено
+func allocateBackorders(
+	ctx context.Context,
+	orderRepo repositories.OrderRepository,
+	productID uuid.UUID,
+	variantID *uuid.UUID,
+	available int,
+) (int, error) {
+	if available <= 0 {
+		return 0, nil
+	}
+
+	items, err := orderRepo.GetBackorderedItemsForUpdate(ctx, productID, variantID)
+	if err != nil {
+		return 0, err
+	}
+
+	allocated := 0
+	for _, item := range items {
+		if allocated == available {
+			break
+		}
+
+		allocated += item.Allocate(available - allocated)
+		if err := orderRepo.UpdateItem(ctx, item); err != nil {
+			return 0, err
+		}
+	}
+
+	return allocated, nil
+}
+
+// initialPrice - первая запись истории цен, действующая с момента создания товара
+func initialPrice(product *entities.Product) *entities.PriceChange {
+	change := &entities.PriceChange{
+		ID:        uuid.New(),
+		ProductID: product.ID,
+		Price:     product.Price,
+		ValidFrom: product.CreatedAt,
+		CreatedAt: product.CreatedAt,
+	}
+	change.MarkApplied(product.CreatedAt)
+
+	return change
+}
+
+func newVariant(productID uuid.UUID, req *services.CreateVariantRequest) *entities.ProductVariant {
+	return &entities.ProductVariant{
+		ID:         uuid.New(),
+		ProductID:  productID,
+		SKU:        req.SKU,
+		Attributes: req.Attributes,
+		Price:      req.Price,
+		Quantity:   req.Quantity,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
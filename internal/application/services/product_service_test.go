@@ -6,20 +6,33 @@ import (
 	"time"
 
 	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
 	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
 	"github.com/AndrivA89/orders/internal/domain/services"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
+func expectProductTransaction(mockTxManager *mocks.MockTransactionManager, mockProductRepo *mocks.MockProductRepository) {
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{ProductRepository: mockProductRepo})
+		},
+	)
+}
+
 func TestProductService_CreateProduct_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
-	service := NewProductService(mockProductRepo)
+	mockPriceRepo := mocks.NewMockPriceRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewProductService(mockProductRepo, mocks.NewMockCategoryRepository(ctrl), mockTxManager)
 
 	request := &services.CreateProductRequest{
 		Description: "Test Product",
@@ -27,6 +40,7 @@ func TestProductService_CreateProduct_Success(t *testing.T) {
 		Quantity:    10,
 	}
 
+	expectPriceTransaction(mockTxManager, mockProductRepo, mockPriceRepo)
 	mockProductRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
 		func(ctx context.Context, product *entities.Product) error {
 			product.ID = uuid.New()
@@ -34,6 +48,13 @@ func TestProductService_CreateProduct_Success(t *testing.T) {
 			product.UpdatedAt = time.Now()
 			return nil
 		})
+	mockPriceRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, change *entities.PriceChange) error {
+			assert.Equal(t, int64(1000), change.Price)
+			assert.True(t, change.IsApplied())
+			assert.Nil(t, change.ValidTo)
+			return nil
+		})
 
 	product, err := service.CreateProduct(context.Background(), request)
 
@@ -49,7 +70,7 @@ func TestProductService_CreateProduct_ValidationError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
-	service := NewProductService(mockProductRepo)
+	service := NewProductService(mockProductRepo, mocks.NewMockCategoryRepository(ctrl), mocks.NewMockTransactionManager(ctrl))
 
 	request := &services.CreateProductRequest{
 		Description: "", // Empty description
@@ -69,7 +90,7 @@ func TestProductService_GetProductByID_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
-	service := NewProductService(mockProductRepo)
+	service := NewProductService(mockProductRepo, mocks.NewMockCategoryRepository(ctrl), mocks.NewMockTransactionManager(ctrl))
 
 	productID := uuid.New()
 	expectedProduct := &entities.Product{
@@ -89,12 +110,38 @@ func TestProductService_GetProductByID_Success(t *testing.T) {
 	assert.Equal(t, "Test Product", product.Description)
 }
 
+func TestProductService_GetProductsByIDs_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	service := NewProductService(mockProductRepo, mocks.NewMockCategoryRepository(ctrl), mocks.NewMockTransactionManager(ctrl))
+
+	firstID := uuid.New()
+	secondID := uuid.New()
+	missingID := uuid.New()
+	ids := []uuid.UUID{firstID, secondID, missingID}
+
+	mockProductRepo.EXPECT().GetByIDs(gomock.Any(), ids).Return([]*entities.Product{
+		{ID: secondID, Description: "Product 2"},
+		{ID: firstID, Description: "Product 1"},
+	}, nil)
+
+	products, err := service.GetProductsByIDs(context.Background(), ids)
+
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, "Product 1", products[firstID].Description)
+	assert.Equal(t, "Product 2", products[secondID].Description)
+	assert.NotContains(t, products, missingID)
+}
+
 func TestProductService_GetProducts_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
-	service := NewProductService(mockProductRepo)
+	service := NewProductService(mockProductRepo, mocks.NewMockCategoryRepository(ctrl), mocks.NewMockTransactionManager(ctrl))
 
 	expectedProducts := []*entities.Product{
 		{
@@ -121,3 +168,270 @@ func TestProductService_GetProducts_Success(t *testing.T) {
 	assert.Equal(t, "Product 1", products[0].Description)
 	assert.Equal(t, "Product 2", products[1].Description)
 }
+
+func TestProductService_AddVariant_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewProductService(mocks.NewMockProductRepository(ctrl), mocks.NewMockCategoryRepository(ctrl), mockTxManager)
+
+	product := &entities.Product{ID: uuid.New(), Description: "Shirt"}
+
+	expectProductTransaction(mockTxManager, mockProductRepo)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockProductRepo.EXPECT().CreateVariant(gomock.Any(), gomock.Any()).Return(nil)
+
+	variant, err := service.AddVariant(context.Background(), product.ID, &services.CreateVariantRequest{
+		SKU:        "SHIRT-M",
+		Attributes: map[string]string{"size": "M"},
+		Price:      1500,
+		Quantity:   4,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, product.ID, variant.ProductID)
+	assert.Equal(t, "SHIRT-M", variant.SKU)
+}
+
+func TestProductService_AddVariant_ProductNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewProductService(mocks.NewMockProductRepository(ctrl), mocks.NewMockCategoryRepository(ctrl), mockTxManager)
+
+	productID := uuid.New()
+	expectProductTransaction(mockTxManager, mockProductRepo)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), productID).Return(nil, gorm.ErrRecordNotFound)
+
+	variant, err := service.AddVariant(context.Background(), productID, &services.CreateVariantRequest{
+		SKU:        "SHIRT-M",
+		Attributes: map[string]string{"size": "M"},
+		Price:      1500,
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, variant)
+	assert.Equal(t, "product not found", err.Error())
+}
+
+func TestProductService_SetProductCategories_DeduplicatesIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewProductService(mocks.NewMockProductRepository(ctrl), mockCategoryRepo, mockTxManager)
+
+	product := &entities.Product{ID: uuid.New(), Description: "Shirt"}
+	expectProductTransaction(mockTxManager, mockProductRepo)
+	category := &entities.Category{ID: uuid.New(), Name: "Футболки", Slug: "shirts"}
+
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockCategoryRepo.EXPECT().GetByIDs(gomock.Any(), []uuid.UUID{category.ID}).Return([]*entities.Category{category}, nil)
+	mockProductRepo.EXPECT().SetCategories(gomock.Any(), product.ID, []uuid.UUID{category.ID}).Return(nil)
+
+	result, err := service.SetProductCategories(context.Background(), product.ID, []uuid.UUID{category.ID, category.ID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.CategoryRef{category.Ref()}, result.Categories)
+}
+
+func TestProductService_SetProductCategories_UnknownCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewProductService(mocks.NewMockProductRepository(ctrl), mockCategoryRepo, mockTxManager)
+
+	product := &entities.Product{ID: uuid.New(), Description: "Shirt"}
+	expectProductTransaction(mockTxManager, mockProductRepo)
+	missingID := uuid.New()
+
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockCategoryRepo.EXPECT().GetByIDs(gomock.Any(), []uuid.UUID{missingID}).Return([]*entities.Category{}, nil)
+
+	result, err := service.SetProductCategories(context.Background(), product.ID, []uuid.UUID{missingID})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "category not found", err.Error())
+}
+
+func TestProductService_GetProductsByCategory_UnknownSlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
+	service := NewProductService(mocks.NewMockProductRepository(ctrl), mockCategoryRepo, mocks.NewMockTransactionManager(ctrl))
+
+	mockCategoryRepo.EXPECT().GetBySlug(gomock.Any(), "missing").Return(nil, gorm.ErrRecordNotFound)
+
+	products, err := service.GetProductsByCategory(context.Background(), "missing", 10, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, products)
+	assert.Equal(t, "category not found", err.Error())
+}
+
+func TestProductService_ReceiveStock_AllocatesInOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewProductService(mockProductRepo, mocks.NewMockCategoryRepository(ctrl), mockTxManager)
+
+	product := &entities.Product{ID: uuid.New(), Description: "Console", Price: 1000, StockPolicy: entities.StockPolicyBackorder}
+	first := &entities.OrderItem{ID: uuid.New(), ProductID: product.ID, Quantity: 3, Backordered: 3}
+	second := &entities.OrderItem{ID: uuid.New(), ProductID: product.ID, Quantity: 2, Backordered: 2}
+
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				OrderRepository:   mockOrderRepo,
+				ProductRepository: mockProductRepo,
+			})
+		},
+	)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockOrderRepo.EXPECT().GetBackorderedItemsForUpdate(gomock.Any(), product.ID, gomock.Nil()).
+		Return([]*entities.OrderItem{first, second}, nil)
+	mockOrderRepo.EXPECT().UpdateItem(gomock.Any(), first).Return(nil)
+	mockOrderRepo.EXPECT().UpdateItem(gomock.Any(), second).Return(nil)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+
+	receipt, err := service.ReceiveStock(context.Background(), product.ID, &services.ReceiveStockRequest{Quantity: 4})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, receipt.Allocated)
+	assert.Equal(t, 0, receipt.Available)
+	assert.Equal(t, 0, first.Backordered)
+	assert.Equal(t, 1, second.Backordered)
+	assert.Equal(t, 0, product.Quantity)
+}
+
+func TestProductService_ReceiveStock_VariantRequired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewProductService(mockProductRepo, mocks.NewMockCategoryRepository(ctrl), mockTxManager)
+
+	product := newShirtWithVariant()
+
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{ProductRepository: mockProductRepo})
+		},
+	)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+
+	receipt, err := service.ReceiveStock(context.Background(), product.ID, &services.ReceiveStockRequest{Quantity: 4})
+
+	assert.ErrorIs(t, err, domainErrors.ErrVariantRequired)
+	assert.Nil(t, receipt)
+}
+
+func TestProductService_ReceiveStock_NotifiesSubscribers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockStockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewProductService(mockProductRepo, mocks.NewMockCategoryRepository(ctrl), mockTxManager)
+
+	product := &entities.Product{ID: uuid.New(), Description: "Console", Price: 1000}
+	first := entities.NewStockSubscription(uuid.New(), product.ID, nil)
+	second := entities.NewStockSubscription(uuid.New(), product.ID, nil)
+
+	var queued []*entities.StockNotification
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				OrderRepository:      mockOrderRepo,
+				ProductRepository:    mockProductRepo,
+				StockAlertRepository: mockStockAlertRepo,
+			})
+		},
+	)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), product.ID).Return(product, nil)
+	mockOrderRepo.EXPECT().GetBackorderedItemsForUpdate(gomock.Any(), product.ID, gomock.Nil()).Return(nil, nil)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+	mockStockAlertRepo.EXPECT().GetSubscribersForUpdate(gomock.Any(), product.ID, gomock.Nil()).
+		Return([]*entities.StockSubscription{first, second}, nil)
+	mockStockAlertRepo.EXPECT().MarkSubscriptionsNotified(gomock.Any(), []uuid.UUID{first.ID, second.ID}, gomock.Any()).Return(nil)
+	mockStockAlertRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, notifications []*entities.StockNotification) error {
+			queued = notifications
+			return nil
+		},
+	)
+
+	_, err := service.ReceiveStock(context.Background(), product.ID, &services.ReceiveStockRequest{Quantity: 5})
+
+	assert.NoError(t, err)
+	assert.Len(t, queued, 2)
+	assert.Equal(t, first.UserID, *queued[0].UserID)
+	assert.Equal(t, second.UserID, *queued[1].UserID)
+	assert.Equal(t, 5, queued[0].Quantity)
+}
+
+func TestProductService_ReconcileStock_AllocatesFreeStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewProductService(mockProductRepo, mocks.NewMockCategoryRepository(ctrl), mockTxManager)
+
+	// остаток появился после импорта каталога, поступление через ReceiveStock не проводилось
+	imported := &entities.Product{ID: uuid.New(), Description: "Console", Quantity: 3, StockPolicy: entities.StockPolicyPreorder}
+	waiting := &entities.OrderItem{ID: uuid.New(), ProductID: imported.ID, Quantity: 5, Backordered: 5}
+	empty := &entities.Product{ID: uuid.New(), Description: "Camera", StockPolicy: entities.StockPolicyPreorder}
+
+	mockProductRepo.EXPECT().GetBackorderedIDs(gomock.Any()).Return([]uuid.UUID{imported.ID, empty.ID}, nil)
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				OrderRepository:   mockOrderRepo,
+				ProductRepository: mockProductRepo,
+			})
+		},
+	)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), imported.ID).Return(imported, nil)
+	mockOrderRepo.EXPECT().GetBackorderedItemsForUpdate(gomock.Any(), imported.ID, gomock.Nil()).
+		Return([]*entities.OrderItem{waiting}, nil)
+	mockOrderRepo.EXPECT().UpdateItem(gomock.Any(), waiting).Return(nil)
+	mockProductRepo.EXPECT().Update(gomock.Any(), imported).Return(nil)
+	// у товара без свободного остатка ничего не сохраняется
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), empty.ID).Return(empty, nil)
+
+	receipts, err := service.ReconcileStock(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, receipts, 1)
+	assert.Equal(t, imported.ID, receipts[0].ProductID)
+	assert.Equal(t, 3, receipts[0].Allocated)
+	assert.Equal(t, 2, waiting.Backordered)
+	assert.Equal(t, 0, imported.Quantity)
+}
+
+func newShirtWithVariant() *entities.Product {
+	product := &entities.Product{ID: uuid.New(), Description: "Shirt"}
+	product.Variants = []entities.ProductVariant{
+		{ID: uuid.New(), ProductID: product.ID, SKU: "SHIRT-M", Attributes: map[string]string{"size": "M"}, Price: 1500},
+	}
+	return product
+}
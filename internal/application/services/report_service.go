@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/services"
+)
+
+const (
+	defaultReportLimit = 10
+	maxReportLimit     = 100
+
+	// Ограничение на длину интервала защищает БД от тяжелых запросов за всю историю
+	maxReportRange = 366 * 24 * time.Hour
+)
+
+type reportService struct {
+	reportRepo repositories.ReportRepository
+}
+
+func NewReportService(reportRepo repositories.ReportRepository) services.ReportService {
+	return &reportService{
+		reportRepo: reportRepo,
+	}
+}
+
+func (s *reportService) GetSalesByPeriod(ctx context.Context, req *services.SalesReportRequest) ([]entities.SalesPoint, error) {
+	if err := validateReportRange(req); err != nil {
+		return nil, err
+	}
+
+	if req.GroupBy == "" {
+		req.GroupBy = entities.ReportPeriodDay
+	}
+	if !req.GroupBy.IsValid() {
+		return nil, domainErrors.ErrReportPeriodInvalid
+	}
+
+	return s.reportRepo.SalesByPeriod(ctx, req.From, req.To, req.GroupBy)
+}
+
+func (s *reportService) GetSalesSummary(ctx context.Context, req *services.SalesReportRequest) (*entities.SalesSummary, error) {
+	if err := validateReportRange(req); err != nil {
+		return nil, err
+	}
+
+	return s.reportRepo.SalesSummary(ctx, req.From, req.To)
+}
+
+func (s *reportService) GetTopProducts(ctx context.Context, req *services.SalesReportRequest) ([]entities.ProductSales, error) {
+	if err := validateReportRange(req); err != nil {
+		return nil, err
+	}
+
+	return s.reportRepo.TopProducts(ctx, req.From, req.To, normalizeReportLimit(req.Limit))
+}
+
+func (s *reportService) GetSalesByTag(ctx context.Context, req *services.SalesReportRequest) ([]entities.TagSales, error) {
+	if err := validateReportRange(req); err != nil {
+		return nil, err
+	}
+
+	return s.reportRepo.SalesByTag(ctx, req.From, req.To)
+}
+
+func (s *reportService) GetSalesByCategory(
+	ctx context.Context,
+	req *services.SalesReportRequest,
+) ([]entities.CategorySales, error) {
+	if err := validateReportRange(req); err != nil {
+		return nil, err
+	}
+
+	return s.reportRepo.SalesByCategory(ctx, req.From, req.To)
+}
+
+func (s *reportService) GetLowStockProducts(ctx context.Context, threshold, limit int) ([]entities.LowStockProduct, error) {
+	if threshold < 0 {
+		return nil, domainErrors.ErrProductQuantityNegative
+	}
+
+	return s.reportRepo.LowStockProducts(ctx, threshold, normalizeReportLimit(limit))
+}
+
+func validateReportRange(req *services.SalesReportRequest) error {
+	if !req.From.Before(req.To) {
+		return domainErrors.ErrReportRangeInvalid
+	}
+	if req.To.Sub(req.From) > maxReportRange {
+		return domainErrors.ErrReportRangeTooLarge
+	}
+	return nil
+}
+
+func normalizeReportLimit(limit int) int {
+	if limit <= 0 {
+		return defaultReportLimit
+	}
+	if limit > maxReportLimit {
+		return maxReportLimit
+	}
+	return limit
+}
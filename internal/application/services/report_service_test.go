@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReportService_GetSalesByPeriod_DefaultsToDay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepo := mocks.NewMockReportRepository(ctrl)
+	service := NewReportService(mockReportRepo)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	points := []entities.SalesPoint{{Period: from, Orders: 3, ItemsSold: 5, Revenue: 15000}}
+
+	mockReportRepo.EXPECT().SalesByPeriod(gomock.Any(), from, to, entities.ReportPeriodDay).Return(points, nil)
+
+	result, err := service.GetSalesByPeriod(context.Background(), &services.SalesReportRequest{From: from, To: to})
+
+	assert.NoError(t, err)
+	assert.Equal(t, points, result)
+}
+
+func TestReportService_GetSalesByPeriod_InvalidPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewReportService(mocks.NewMockReportRepository(ctrl))
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	result, err := service.GetSalesByPeriod(context.Background(), &services.SalesReportRequest{
+		From:    from,
+		To:      from.AddDate(0, 0, 7),
+		GroupBy: "year",
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "report grouping must be day, week or month", err.Error())
+}
+
+func TestReportService_GetSalesSummary_InvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewReportService(mocks.NewMockReportRepository(ctrl))
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.GetSalesSummary(context.Background(), &services.SalesReportRequest{From: from, To: from})
+	assert.Equal(t, "report range start must be before its end", err.Error())
+
+	_, err = service.GetSalesSummary(context.Background(), &services.SalesReportRequest{From: from, To: from.AddDate(2, 0, 0)})
+	assert.Equal(t, "report range must not exceed 366 days", err.Error())
+}
+
+func TestReportService_GetTopProducts_ClampsLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportRepo := mocks.NewMockReportRepository(ctrl)
+	service := NewReportService(mockReportRepo)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	mockReportRepo.EXPECT().TopProducts(gomock.Any(), from, to, 10).Return([]entities.ProductSales{}, nil)
+	mockReportRepo.EXPECT().TopProducts(gomock.Any(), from, to, 100).Return([]entities.ProductSales{}, nil)
+
+	_, err := service.GetTopProducts(context.Background(), &services.SalesReportRequest{From: from, To: to})
+	assert.NoError(t, err)
+
+	_, err = service.GetTopProducts(context.Background(), &services.SalesReportRequest{From: from, To: to, Limit: 1000})
+	assert.NoError(t, err)
+}
+
+func TestReportService_GetLowStockProducts_NegativeThreshold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewReportService(mocks.NewMockReportRepository(ctrl))
+
+	result, err := service.GetLowStockProducts(context.Background(), -1, 10)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
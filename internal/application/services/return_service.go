@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+type returnService struct {
+	returnRepo repositories.ReturnRepository
+	orderRepo  repositories.OrderRepository
+	txManager  repositories.TransactionManager
+}
+
+func NewReturnService(
+	returnRepo repositories.ReturnRepository,
+	orderRepo repositories.OrderRepository,
+	txManager repositories.TransactionManager,
+) services.ReturnService {
+	return &returnService{
+		returnRepo: returnRepo,
+		orderRepo:  orderRepo,
+		txManager:  txManager,
+	}
+}
+
+func (s *returnService) OpenReturn(ctx context.Context, req *services.OpenReturnRequest) (*entities.ReturnRequest, error) {
+	var resultRequest *entities.ReturnRequest
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		// Блокировка заказа не дает двум параллельным заявкам вернуть одну и ту же позицию сверх купленного
+		order, err := repos.OrderRepository.GetByIDForUpdate(ctx, req.OrderID)
+		if err != nil {
+			return domainErrors.ErrOrderNotFound
+		}
+
+		existing, err := repos.ReturnRepository.GetByOrderID(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		items := make([]entities.ReturnItem, 0, len(req.Items))
+		for _, item := range req.Items {
+			items = append(items, entities.ReturnItem{
+				OrderItemID: item.OrderItemID,
+				Quantity:    item.Quantity,
+				Reason:      entities.ReturnReason(item.Reason),
+				Comment:     item.Comment,
+			})
+		}
+
+		request, err := entities.NewReturnRequest(order, req.UserID, items, existing)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.ReturnRepository.Create(ctx, request); err != nil {
+			return err
+		}
+
+		resultRequest = request
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultRequest, nil
+}
+
+func (s *returnService) GetReturnByID(ctx context.Context, id uuid.UUID) (*entities.ReturnRequest, error) {
+	request, err := s.returnRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, domainErrors.ErrReturnNotFound
+	}
+
+	return request, nil
+}
+
+func (s *returnService) GetReturnsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.ReturnRequest, error) {
+	if _, err := s.orderRepo.GetByID(ctx, orderID); err != nil {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+
+	return s.returnRepo.GetByOrderID(ctx, orderID)
+}
+
+func (s *returnService) ApproveReturn(ctx context.Context, id uuid.UUID, comment string) (*entities.ReturnRequest, error) {
+	return s.decideReturn(ctx, id, func(request *entities.ReturnRequest) error {
+		return request.Approve(comment)
+	})
+}
+
+func (s *returnService) RejectReturn(ctx context.Context, id uuid.UUID, comment string) (*entities.ReturnRequest, error) {
+	return s.decideReturn(ctx, id, func(request *entities.ReturnRequest) error {
+		return request.Reject(comment)
+	})
+}
+
+// decideReturn применяет решение по заявке под блокировкой, чтобы одновременные одобрение
+// и отклонение не перезаписали друг друга
+func (s *returnService) decideReturn(
+	ctx context.Context,
+	id uuid.UUID,
+	decide func(request *entities.ReturnRequest) error,
+) (*entities.ReturnRequest, error) {
+	var resultRequest *entities.ReturnRequest
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		request, err := repos.ReturnRepository.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return domainErrors.ErrReturnNotFound
+		}
+
+		if err := decide(request); err != nil {
+			return err
+		}
+
+		if err := repos.ReturnRepository.Update(ctx, request); err != nil {
+			return err
+		}
+
+		resultRequest = request
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultRequest, nil
+}
+
+func (s *returnService) ReceiveReturn(ctx context.Context, id uuid.UUID) (*entities.ReturnRequest, error) {
+	var resultRequest *entities.ReturnRequest
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		// Блокируем заявку, чтобы товар не вернулся на склад дважды
+		request, err := repos.ReturnRepository.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return domainErrors.ErrReturnNotFound
+		}
+
+		if err := request.MarkReceived(); err != nil {
+			return err
+		}
+
+		// Возвращенный товар в первую очередь достается заказам, ожидающим поступления
+		for _, item := range request.Items {
+			// Товар блокируется и для вариантов: в том же порядке, что и при создании заказа
+			product, err := repos.ProductRepository.GetByIDForUpdate(ctx, item.ProductID)
+			if err != nil {
+				return err
+			}
+
+			if item.VariantID == nil {
+				if _, err := restockProduct(ctx, repos, product, item.Quantity); err != nil {
+					return err
+				}
+				continue
+			}
+
+			variant, err := repos.ProductRepository.GetVariantByIDForUpdate(ctx, *item.VariantID)
+			if err != nil {
+				return err
+			}
+
+			if _, err := restockVariant(ctx, repos, product, variant, item.Quantity); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.ReturnRepository.Update(ctx, request); err != nil {
+			return err
+		}
+
+		resultRequest = request
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultRequest, nil
+}
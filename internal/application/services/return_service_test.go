@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func expectReturnTransaction(
+	mockTxManager *mocks.MockTransactionManager,
+	mockOrderRepo *mocks.MockOrderRepository,
+	mockProductRepo *mocks.MockProductRepository,
+	mockReturnRepo *mocks.MockReturnRepository,
+) {
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			repos := repositories.TransactionalRepositories{
+				OrderRepository:   mockOrderRepo,
+				ProductRepository: mockProductRepo,
+				ReturnRepository:  mockReturnRepo,
+			}
+			return fn(ctx, repos)
+		},
+	)
+}
+
+func newCompletedOrder(t *testing.T, product *entities.Product, quantity int) *entities.Order {
+	order := entities.NewOrder(uuid.New())
+
+	assert.NoError(t, order.AddItem(product, quantity))
+	assert.NoError(t, order.Confirm())
+	assert.NoError(t, order.Complete())
+
+	return order
+}
+
+func TestReturnService_OpenReturn_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReturnRepo := mocks.NewMockReturnRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewReturnService(mockReturnRepo, mockOrderRepo, mockTxManager)
+
+	product := &entities.Product{ID: uuid.New(), Quantity: 10, Price: 2500}
+	order := newCompletedOrder(t, product, 2)
+
+	// Цена товара изменилась после заказа - возврат считается по цене из заказа
+	product.Price = 9999
+
+	expectReturnTransaction(mockTxManager, mockOrderRepo, mockProductRepo, mockReturnRepo)
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), order.ID).Return(order, nil)
+	mockReturnRepo.EXPECT().GetByOrderID(gomock.Any(), order.ID).Return([]*entities.ReturnRequest{}, nil)
+	mockReturnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	request, err := service.OpenReturn(context.Background(), &services.OpenReturnRequest{
+		OrderID: order.ID,
+		UserID:  order.UserID,
+		Items: []services.ReturnItemRequest{
+			{OrderItemID: order.Items[0].ID, Quantity: 2, Reason: "damaged"},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ReturnStatusRequested, request.Status)
+	assert.Equal(t, int64(5000), request.RefundAmount)
+}
+
+func TestReturnService_OpenReturn_OrderNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReturnRepo := mocks.NewMockReturnRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewReturnService(mockReturnRepo, mockOrderRepo, mockTxManager)
+
+	orderID := uuid.New()
+
+	expectReturnTransaction(mockTxManager, mockOrderRepo, mockProductRepo, mockReturnRepo)
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), orderID).Return(nil, gorm.ErrRecordNotFound)
+
+	request, err := service.OpenReturn(context.Background(), &services.OpenReturnRequest{
+		OrderID: orderID,
+		UserID:  uuid.New(),
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, request)
+	assert.Equal(t, "order not found", err.Error())
+}
+
+func TestReturnService_ReceiveReturn_Restocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReturnRepo := mocks.NewMockReturnRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewReturnService(mockReturnRepo, mockOrderRepo, mockTxManager)
+
+	productID := uuid.New()
+	returnID := uuid.New()
+	request := &entities.ReturnRequest{
+		ID:     returnID,
+		Status: entities.ReturnStatusApproved,
+		Items: []entities.ReturnItem{
+			{ProductID: productID, Quantity: 2},
+		},
+	}
+	product := &entities.Product{ID: productID, Quantity: 1, Price: 1000}
+
+	expectReturnTransaction(mockTxManager, mockOrderRepo, mockProductRepo, mockReturnRepo)
+	mockReturnRepo.EXPECT().GetByIDForUpdate(gomock.Any(), returnID).Return(request, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), productID).Return(product, nil)
+	mockOrderRepo.EXPECT().GetBackorderedItemsForUpdate(gomock.Any(), productID, gomock.Nil()).Return(nil, nil)
+	mockProductRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, p *entities.Product) error {
+			assert.Equal(t, 3, p.Quantity)
+			return nil
+		})
+	mockReturnRepo.EXPECT().Update(gomock.Any(), request).Return(nil)
+
+	result, err := service.ReceiveReturn(context.Background(), returnID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ReturnStatusReceived, result.Status)
+}
+
+func TestReturnService_ReceiveReturn_FillsBackorders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReturnRepo := mocks.NewMockReturnRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockStockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewReturnService(mockReturnRepo, mockOrderRepo, mockTxManager)
+
+	productID := uuid.New()
+	returnID := uuid.New()
+	request := &entities.ReturnRequest{
+		ID:     returnID,
+		Status: entities.ReturnStatusApproved,
+		Items:  []entities.ReturnItem{{ProductID: productID, Quantity: 2}},
+	}
+	product := &entities.Product{ID: productID, Price: 1000, StockPolicy: entities.StockPolicyBackorder}
+	waiting := &entities.OrderItem{ID: uuid.New(), ProductID: productID, Quantity: 3, Backordered: 1}
+
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				OrderRepository:      mockOrderRepo,
+				ProductRepository:    mockProductRepo,
+				ReturnRepository:     mockReturnRepo,
+				StockAlertRepository: mockStockAlertRepo,
+			})
+		},
+	)
+	mockReturnRepo.EXPECT().GetByIDForUpdate(gomock.Any(), returnID).Return(request, nil)
+	mockProductRepo.EXPECT().GetByIDForUpdate(gomock.Any(), productID).Return(product, nil)
+	mockOrderRepo.EXPECT().GetBackorderedItemsForUpdate(gomock.Any(), productID, gomock.Nil()).
+		Return([]*entities.OrderItem{waiting}, nil)
+	mockOrderRepo.EXPECT().UpdateItem(gomock.Any(), waiting).Return(nil)
+	mockProductRepo.EXPECT().Update(gomock.Any(), product).Return(nil)
+	// Остаток снова больше нуля - подписчики получают уведомление о поступлении
+	mockStockAlertRepo.EXPECT().GetSubscribersForUpdate(gomock.Any(), productID, gomock.Nil()).Return(nil, nil)
+	mockReturnRepo.EXPECT().Update(gomock.Any(), request).Return(nil)
+
+	_, err := service.ReceiveReturn(context.Background(), returnID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, waiting.Backordered)
+	assert.Equal(t, 1, product.Quantity)
+}
+
+func TestReturnService_ReceiveReturn_NotApproved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReturnRepo := mocks.NewMockReturnRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewReturnService(mockReturnRepo, mockOrderRepo, mockTxManager)
+
+	returnID := uuid.New()
+	request := &entities.ReturnRequest{ID: returnID, Status: entities.ReturnStatusRequested}
+
+	expectReturnTransaction(mockTxManager, mockOrderRepo, mockProductRepo, mockReturnRepo)
+	mockReturnRepo.EXPECT().GetByIDForUpdate(gomock.Any(), returnID).Return(request, nil)
+
+	result, err := service.ReceiveReturn(context.Background(), returnID)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "only approved returns can be received", err.Error())
+}
+
+func TestReturnService_RejectReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReturnRepo := mocks.NewMockReturnRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewReturnService(mockReturnRepo, mockOrderRepo, mockTxManager)
+
+	returnID := uuid.New()
+	request := &entities.ReturnRequest{ID: returnID, Status: entities.ReturnStatusRequested}
+
+	expectReturnTransaction(mockTxManager, mockOrderRepo, nil, mockReturnRepo)
+	mockReturnRepo.EXPECT().GetByIDForUpdate(gomock.Any(), returnID).Return(request, nil)
+	mockReturnRepo.EXPECT().Update(gomock.Any(), request).Return(nil)
+
+	result, err := service.RejectReturn(context.Background(), returnID, "item was used")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ReturnStatusRejected, result.Status)
+	assert.Equal(t, "item was used", result.ReviewComment)
+}
+
+func TestReturnService_ApproveReturn_AlreadyRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReturnRepo := mocks.NewMockReturnRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewReturnService(mockReturnRepo, mockOrderRepo, mockTxManager)
+
+	returnID := uuid.New()
+	// заявку успели отклонить: решение читается под блокировкой и не перезаписывается
+	request := &entities.ReturnRequest{ID: returnID, Status: entities.ReturnStatusRejected}
+
+	expectReturnTransaction(mockTxManager, mockOrderRepo, nil, mockReturnRepo)
+	mockReturnRepo.EXPECT().GetByIDForUpdate(gomock.Any(), returnID).Return(request, nil)
+
+	result, err := service.ApproveReturn(context.Background(), returnID, "")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, entities.ReturnStatusRejected, request.Status)
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+type shipmentService struct {
+	shipmentRepo repositories.ShipmentRepository
+	orderRepo    repositories.OrderRepository
+	txManager    repositories.TransactionManager
+	carriers     map[string]services.CarrierClient
+}
+
+func NewShipmentService(
+	shipmentRepo repositories.ShipmentRepository,
+	orderRepo repositories.OrderRepository,
+	txManager repositories.TransactionManager,
+	carriers []services.CarrierClient,
+) services.ShipmentService {
+	carrierByName := make(map[string]services.CarrierClient, len(carriers))
+	for _, carrier := range carriers {
+		carrierByName[carrier.Name()] = carrier
+	}
+
+	return &shipmentService{
+		shipmentRepo: shipmentRepo,
+		orderRepo:    orderRepo,
+		txManager:    txManager,
+		carriers:     carrierByName,
+	}
+}
+
+func (s *shipmentService) CreateShipment(ctx context.Context, req *services.CreateShipmentRequest) (*entities.Shipment, error) {
+	carrier, ok := s.carriers[req.Carrier]
+	if !ok {
+		return nil, domainErrors.ErrCarrierNotSupported
+	}
+
+	var resultShipment *entities.Shipment
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		// Блокировка заказа не дает двум параллельным отправкам разделить одну и ту же неотгруженную позицию
+		order, err := repos.OrderRepository.GetByIDForUpdate(ctx, req.OrderID)
+		if err != nil {
+			return domainErrors.ErrOrderNotFound
+		}
+
+		existing, err := repos.ShipmentRepository.GetByOrderID(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		items := make([]entities.ShipmentItem, 0, len(req.Items))
+		for _, item := range req.Items {
+			items = append(items, entities.ShipmentItem{
+				OrderItemID: item.OrderItemID,
+				Quantity:    item.Quantity,
+			})
+		}
+
+		shipment, err := entities.NewShipment(order, carrier.Name(), items, existing)
+		if err != nil {
+			return err
+		}
+
+		// Регистрируем отправку у перевозчика внутри транзакции,
+		// чтобы при ошибке не осталось отправки без трек-номера
+		trackingNumber, err := carrier.RegisterShipment(ctx, shipment)
+		if err != nil {
+			return err
+		}
+		shipment.AssignTrackingNumber(trackingNumber)
+
+		if err := repos.ShipmentRepository.Create(ctx, shipment); err != nil {
+			return err
+		}
+
+		resultShipment = shipment
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if tracker, ok := carrier.(services.TrackingCarrier); ok {
+		tracker.StartTracking(resultShipment)
+	}
+
+	return resultShipment, nil
+}
+
+func (s *shipmentService) GetShipmentsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Shipment, error) {
+	if _, err := s.orderRepo.GetByID(ctx, orderID); err != nil {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+
+	return s.shipmentRepo.GetByOrderID(ctx, orderID)
+}
+
+func (s *shipmentService) ProcessTrackingEvent(ctx context.Context, event *services.TrackingEvent) (*entities.Shipment, error) {
+	var resultShipment *entities.Shipment
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		shipment, err := repos.ShipmentRepository.GetByTrackingNumberForUpdate(ctx, event.Carrier, event.TrackingNumber)
+		if err != nil {
+			return domainErrors.ErrShipmentNotFound
+		}
+
+		applied, err := shipment.ApplyEvent(event.Status, event.Location, event.Description, event.OccurredAt)
+		if err != nil {
+			return err
+		}
+
+		resultShipment = shipment
+
+		// Повторная доставка того же события - ничего не меняем
+		if !applied {
+			return nil
+		}
+
+		if err := repos.ShipmentRepository.Update(ctx, shipment); err != nil {
+			return err
+		}
+
+		if !shipment.IsDelivered() {
+			return nil
+		}
+
+		return s.completeOrderIfDelivered(ctx, repos, shipment.OrderID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultShipment, nil
+}
+
+// completeOrderIfDelivered переводит заказ в completed, когда доставлены все его позиции.
+// Заказ блокируется до чтения отправок: иначе две отправки, доставленные одновременно, видят
+// друг друга недоставленными, и заказ навсегда остается confirmed.
+func (s *shipmentService) completeOrderIfDelivered(ctx context.Context, repos repositories.TransactionalRepositories, orderID uuid.UUID) error {
+	order, err := repos.OrderRepository.GetByIDForUpdate(ctx, orderID)
+	if err != nil {
+		return domainErrors.ErrOrderNotFound
+	}
+
+	if order.Status != entities.OrderStatusConfirmed {
+		return nil
+	}
+
+	shipments, err := repos.ShipmentRepository.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if !order.IsFullyDelivered(shipments) {
+		return nil
+	}
+
+	if err := order.Complete(); err != nil {
+		return err
+	}
+
+	if err := repos.OrderRepository.Update(ctx, order); err != nil {
+		return err
+	}
+
+	return queueWebhookDeliveries(ctx, repos, entities.OrderEventCompleted, order)
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type fakeCarrier struct {
+	trackingNumber string
+	tracked        []*entities.Shipment
+}
+
+func (c *fakeCarrier) Name() string {
+	return "fake"
+}
+
+func (c *fakeCarrier) RegisterShipment(_ context.Context, _ *entities.Shipment) (string, error) {
+	return c.trackingNumber, nil
+}
+
+func (c *fakeCarrier) StartTracking(shipment *entities.Shipment) {
+	c.tracked = append(c.tracked, shipment)
+}
+
+func expectShipmentTransaction(
+	mockTxManager *mocks.MockTransactionManager,
+	mockOrderRepo *mocks.MockOrderRepository,
+	mockShipmentRepo *mocks.MockShipmentRepository,
+) {
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			repos := repositories.TransactionalRepositories{
+				OrderRepository:    mockOrderRepo,
+				ShipmentRepository: mockShipmentRepo,
+			}
+			return fn(ctx, repos)
+		},
+	)
+}
+
+func newConfirmedOrder(t *testing.T) *entities.Order {
+	order := entities.NewOrder(uuid.New())
+	product := &entities.Product{
+		ID:       uuid.New(),
+		Quantity: 10,
+		Price:    1000,
+	}
+
+	assert.NoError(t, order.AddItem(product, 2))
+	assert.NoError(t, order.Confirm())
+
+	return order
+}
+
+func TestShipmentService_CreateShipment_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockShipmentRepo := mocks.NewMockShipmentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	carrier := &fakeCarrier{trackingNumber: "TRACK-1"}
+
+	service := NewShipmentService(mockShipmentRepo, mockOrderRepo, mockTxManager, []services.CarrierClient{carrier})
+
+	order := newConfirmedOrder(t)
+
+	expectShipmentTransaction(mockTxManager, mockOrderRepo, mockShipmentRepo)
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), order.ID).Return(order, nil)
+	mockShipmentRepo.EXPECT().GetByOrderID(gomock.Any(), order.ID).Return([]*entities.Shipment{}, nil)
+	mockShipmentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	shipment, err := service.CreateShipment(context.Background(), &services.CreateShipmentRequest{
+		OrderID: order.ID,
+		Carrier: "fake",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "TRACK-1", shipment.TrackingNumber)
+	assert.Equal(t, "fake", shipment.Carrier)
+	assert.Len(t, shipment.Items, 1)
+	assert.Equal(t, []*entities.Shipment{shipment}, carrier.tracked)
+}
+
+func TestShipmentService_CreateShipment_RollbackDoesNotStartTracking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockShipmentRepo := mocks.NewMockShipmentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	carrier := &fakeCarrier{trackingNumber: "TRACK-1"}
+
+	service := NewShipmentService(mockShipmentRepo, mockOrderRepo, mockTxManager, []services.CarrierClient{carrier})
+
+	order := newConfirmedOrder(t)
+
+	expectShipmentTransaction(mockTxManager, mockOrderRepo, mockShipmentRepo)
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), order.ID).Return(order, nil)
+	mockShipmentRepo.EXPECT().GetByOrderID(gomock.Any(), order.ID).Return([]*entities.Shipment{}, nil)
+	mockShipmentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+
+	shipment, err := service.CreateShipment(context.Background(), &services.CreateShipmentRequest{
+		OrderID: order.ID,
+		Carrier: "fake",
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, shipment)
+	// отправка не сохранилась, и события по ее трек-номеру не эмулируются
+	assert.Empty(t, carrier.tracked)
+}
+
+func TestShipmentService_CreateShipment_UnknownCarrier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockShipmentRepo := mocks.NewMockShipmentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewShipmentService(mockShipmentRepo, mockOrderRepo, mockTxManager, nil)
+
+	shipment, err := service.CreateShipment(context.Background(), &services.CreateShipmentRequest{
+		OrderID: uuid.New(),
+		Carrier: "unknown",
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, shipment)
+	assert.Equal(t, "carrier is not supported", err.Error())
+}
+
+func TestShipmentService_ProcessTrackingEvent_DeliveredCompletesOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockShipmentRepo := mocks.NewMockShipmentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewShipmentService(mockShipmentRepo, mockOrderRepo, mockTxManager, nil)
+
+	order := newConfirmedOrder(t)
+	shipment, err := entities.NewShipment(order, "fake", nil, nil)
+	assert.NoError(t, err)
+	shipment.AssignTrackingNumber("TRACK-1")
+
+	partner := &entities.WebhookSubscription{ID: uuid.New(), Events: []entities.OrderEvent{entities.OrderEventCompleted}, Active: true}
+	other := &entities.WebhookSubscription{ID: uuid.New(), Events: []entities.OrderEvent{entities.OrderEventCreated}, Active: true}
+
+	var queued []*entities.WebhookDelivery
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{
+				OrderRepository:    mockOrderRepo,
+				ShipmentRepository: mockShipmentRepo,
+				WebhookRepository:  mockWebhookRepo,
+			})
+		},
+	)
+	mockShipmentRepo.EXPECT().GetByTrackingNumberForUpdate(gomock.Any(), "fake", "TRACK-1").Return(shipment, nil)
+	mockShipmentRepo.EXPECT().Update(gomock.Any(), shipment).Return(nil)
+	mockOrderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), order.ID).Return(order, nil)
+	mockShipmentRepo.EXPECT().GetByOrderID(gomock.Any(), order.ID).Return([]*entities.Shipment{shipment}, nil)
+	mockOrderRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, o *entities.Order) error {
+			assert.Equal(t, entities.OrderStatusCompleted, o.Status)
+			return nil
+		})
+	mockWebhookRepo.EXPECT().GetActiveSubscriptions(gomock.Any()).Return([]*entities.WebhookSubscription{partner, other}, nil)
+	mockWebhookRepo.EXPECT().CreateDeliveries(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+			queued = deliveries
+			return nil
+		})
+
+	result, err := service.ProcessTrackingEvent(context.Background(), &services.TrackingEvent{
+		Carrier:        "fake",
+		TrackingNumber: "TRACK-1",
+		Status:         entities.ShipmentStatusDelivered,
+		OccurredAt:     time.Now(),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsDelivered())
+	// Завершение заказа уходит только подписчикам на order_completed
+	assert.Len(t, queued, 1)
+	assert.Equal(t, partner.ID, queued[0].SubscriptionID)
+	assert.Equal(t, entities.OrderEventCompleted, queued[0].Event)
+}
+
+func TestShipmentService_ProcessTrackingEvent_InTransitKeepsOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockShipmentRepo := mocks.NewMockShipmentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewShipmentService(mockShipmentRepo, mockOrderRepo, mockTxManager, nil)
+
+	order := newConfirmedOrder(t)
+	shipment, err := entities.NewShipment(order, "fake", nil, nil)
+	assert.NoError(t, err)
+	shipment.AssignTrackingNumber("TRACK-1")
+
+	expectShipmentTransaction(mockTxManager, mockOrderRepo, mockShipmentRepo)
+	mockShipmentRepo.EXPECT().GetByTrackingNumberForUpdate(gomock.Any(), "fake", "TRACK-1").Return(shipment, nil)
+	mockShipmentRepo.EXPECT().Update(gomock.Any(), shipment).Return(nil)
+
+	result, err := service.ProcessTrackingEvent(context.Background(), &services.TrackingEvent{
+		Carrier:        "fake",
+		TrackingNumber: "TRACK-1",
+		Status:         entities.ShipmentStatusInTransit,
+		OccurredAt:     time.Now(),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ShipmentStatusInTransit, result.Status)
+}
+
+func TestShipmentService_ProcessTrackingEvent_UnknownShipment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockShipmentRepo := mocks.NewMockShipmentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+
+	service := NewShipmentService(mockShipmentRepo, mockOrderRepo, mockTxManager, nil)
+
+	expectShipmentTransaction(mockTxManager, mockOrderRepo, mockShipmentRepo)
+	mockShipmentRepo.EXPECT().GetByTrackingNumberForUpdate(gomock.Any(), "fake", "TRACK-404").Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := service.ProcessTrackingEvent(context.Background(), &services.TrackingEvent{
+		Carrier:        "fake",
+		TrackingNumber: "TRACK-404",
+		Status:         entities.ShipmentStatusInTransit,
+		OccurredAt:     time.Now(),
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "shipment not found", err.Error())
+}
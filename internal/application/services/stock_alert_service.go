@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// pendingNotificationsBatchSize ограничивает число уведомлений, отправляемых за один проход
+const pendingNotificationsBatchSize = 100
+
+type stockAlertService struct {
+	stockAlertRepo repositories.StockAlertRepository
+	productRepo    repositories.ProductRepository
+	userRepo       repositories.UserRepository
+	txManager      repositories.TransactionManager
+	notifier       services.Notifier
+}
+
+func NewStockAlertService(
+	stockAlertRepo repositories.StockAlertRepository,
+	productRepo repositories.ProductRepository,
+	userRepo repositories.UserRepository,
+	txManager repositories.TransactionManager,
+	notifier services.Notifier,
+) services.StockAlertService {
+	return &stockAlertService{
+		stockAlertRepo: stockAlertRepo,
+		productRepo:    productRepo,
+		userRepo:       userRepo,
+		txManager:      txManager,
+		notifier:       notifier,
+	}
+}
+
+func (s *stockAlertService) Subscribe(
+	ctx context.Context,
+	req *services.StockSubscriptionRequest,
+) (*entities.StockSubscription, error) {
+	if _, err := s.userRepo.GetByID(ctx, req.UserID); err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	product, err := s.productRepo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, domainErrors.ErrProductNotFound
+	}
+
+	available, err := product.AvailableQuantity(req.VariantID)
+	if err != nil {
+		return nil, err
+	}
+	if available > 0 {
+		return nil, domainErrors.ErrProductInStock
+	}
+
+	existing, err := s.stockAlertRepo.GetActiveSubscription(ctx, req.UserID, product.ID, req.VariantID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, domainErrors.ErrStockSubscriptionNotFound) {
+		return nil, err
+	}
+
+	subscription := entities.NewStockSubscription(req.UserID, product.ID, req.VariantID)
+	if err := s.stockAlertRepo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (s *stockAlertService) Unsubscribe(ctx context.Context, subscriptionID uuid.UUID) error {
+	return s.stockAlertRepo.DeleteSubscription(ctx, subscriptionID)
+}
+
+func (s *stockAlertService) SetLowStockThreshold(
+	ctx context.Context,
+	productID uuid.UUID,
+	threshold int,
+) (*entities.Product, error) {
+	var product *entities.Product
+
+	// Update сохраняет товар целиком, включая остаток: без блокировки он затер бы резерв,
+	// сделанный заказом между чтением и записью
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		var err error
+		product, err = repos.ProductRepository.GetByIDForUpdate(ctx, productID)
+		if err != nil {
+			return domainErrors.ErrProductNotFound
+		}
+
+		if err := product.SetLowStockThreshold(threshold); err != nil {
+			return err
+		}
+
+		return repos.ProductRepository.Update(ctx, product)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// DispatchNotifications отправляет уведомления по одному: ошибка отправки не останавливает
+// остальные, а неудачное уведомление повторяется при следующем запуске. Уведомления блокируются
+// и отмечаются отправленными в одной транзакции, поэтому задача, запущенная на нескольких
+// экземплярах, не отправляет одно уведомление дважды.
+func (s *stockAlertService) DispatchNotifications(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	var errs []error
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context, repos repositories.TransactionalRepositories) error {
+		pending, err := repos.StockAlertRepository.GetPendingNotificationsForUpdate(ctx, pendingNotificationsBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, notification := range pending {
+			if err := s.notifier.Notify(ctx, notification); err != nil {
+				notification.MarkFailed(err)
+				errs = append(errs, err)
+			} else {
+				notification.MarkSent(now)
+				sent++
+			}
+
+			if err := repos.StockAlertRepository.UpdateNotification(ctx, notification); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// recordStockChange ставит в очередь уведомления, которые вызвало изменение остатка: подписчикам -
+// о поступлении, сотрудникам - о достижении порога. Вызывается в транзакции, изменившей остаток.
+func recordStockChange(
+	ctx context.Context,
+	repos repositories.TransactionalRepositories,
+	product *entities.Product,
+	change entities.StockChange,
+) error {
+	var notifications []*entities.StockNotification
+
+	if change.CrossedThreshold(product.LowStockThreshold) {
+		notifications = append(notifications, entities.NewLowStockNotification(product, change))
+	}
+
+	if change.IsBackInStock() {
+		subscriptions, err := repos.StockAlertRepository.GetSubscribersForUpdate(ctx, change.ProductID, change.VariantID)
+		if err != nil {
+			return err
+		}
+
+		if len(subscriptions) > 0 {
+			ids := make([]uuid.UUID, len(subscriptions))
+			for i, subscription := range subscriptions {
+				ids[i] = subscription.ID
+				notifications = append(notifications, entities.NewBackInStockNotification(product, change, subscription))
+			}
+
+			if err := repos.StockAlertRepository.MarkSubscriptionsNotified(ctx, ids, time.Now()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return repos.StockAlertRepository.CreateNotifications(ctx, notifications)
+}
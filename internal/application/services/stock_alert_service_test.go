@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// stubNotifier возвращает ошибку для уведомлений из failFor и запоминает отправленные
+type stubNotifier struct {
+	failFor map[uuid.UUID]bool
+	sent    []*entities.StockNotification
+}
+
+func (n *stubNotifier) Notify(_ context.Context, notification *entities.StockNotification) error {
+	if n.failFor[notification.ID] {
+		return errors.New("notifier unavailable")
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestStockAlertService_Subscribe_InStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	service := NewStockAlertService(mockStockAlertRepo, mockProductRepo, mockUserRepo, mocks.NewMockTransactionManager(ctrl), &stubNotifier{})
+
+	user := &entities.User{ID: uuid.New()}
+	product := &entities.Product{ID: uuid.New(), Description: "Shirt", Quantity: 2}
+
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+	mockProductRepo.EXPECT().GetByID(gomock.Any(), product.ID).Return(product, nil)
+
+	subscription, err := service.Subscribe(context.Background(), &services.StockSubscriptionRequest{
+		UserID:    user.ID,
+		ProductID: product.ID,
+	})
+
+	assert.ErrorIs(t, err, domainErrors.ErrProductInStock)
+	assert.Nil(t, subscription)
+}
+
+func TestStockAlertService_Subscribe_ReturnsOpenSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	service := NewStockAlertService(mockStockAlertRepo, mockProductRepo, mockUserRepo, mocks.NewMockTransactionManager(ctrl), &stubNotifier{})
+
+	user := &entities.User{ID: uuid.New()}
+	product := newShirtWithVariant()
+	variantID := product.Variants[0].ID
+	product.Variants[0].Quantity = 0
+	existing := entities.NewStockSubscription(user.ID, product.ID, &variantID)
+
+	mockUserRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+	mockProductRepo.EXPECT().GetByID(gomock.Any(), product.ID).Return(product, nil)
+	mockStockAlertRepo.EXPECT().GetActiveSubscription(gomock.Any(), user.ID, product.ID, &variantID).Return(existing, nil)
+
+	subscription, err := service.Subscribe(context.Background(), &services.StockSubscriptionRequest{
+		UserID:    user.ID,
+		ProductID: product.ID,
+		VariantID: &variantID,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, existing, subscription)
+}
+
+func TestStockAlertService_DispatchNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+	product := &entities.Product{ID: uuid.New(), Description: "Shirt", LowStockThreshold: 3}
+	delivered := entities.NewLowStockNotification(product, entities.StockChange{ProductID: product.ID, Before: 5, After: 2})
+	failed := entities.NewLowStockNotification(product, entities.StockChange{ProductID: product.ID, Before: 4, After: 1})
+	notifier := &stubNotifier{failFor: map[uuid.UUID]bool{failed.ID: true}}
+	mockTxManager := mocks.NewMockTransactionManager(ctrl)
+	service := NewStockAlertService(mockStockAlertRepo, mocks.NewMockProductRepository(ctrl), mocks.NewMockUserRepository(ctrl), mockTxManager, notifier)
+
+	now := time.Now()
+	mockTxManager.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repositories.TransactionalRepositories) error) error {
+			return fn(ctx, repositories.TransactionalRepositories{StockAlertRepository: mockStockAlertRepo})
+		},
+	)
+	mockStockAlertRepo.EXPECT().GetPendingNotificationsForUpdate(gomock.Any(), pendingNotificationsBatchSize).
+		Return([]*entities.StockNotification{delivered, failed}, nil)
+	mockStockAlertRepo.EXPECT().UpdateNotification(gomock.Any(), delivered).Return(nil)
+	mockStockAlertRepo.EXPECT().UpdateNotification(gomock.Any(), failed).Return(nil)
+
+	sent, err := service.DispatchNotifications(context.Background(), now)
+
+	// Ошибка одного уведомления не мешает отправке остальных
+	assert.Error(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, now, *delivered.SentAt)
+	assert.Nil(t, failed.SentAt)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, "notifier unavailable", failed.LastError)
+}
@@ -26,6 +26,8 @@ func (s *userService) RegisterUser(ctx context.Context, req *services.CreateUser
 		ID:        uuid.New(),
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
+		Email:     req.Email,
+		Language:  req.Language,
 		Age:       req.Age,
 		IsMarried: req.IsMarried,
 		CreatedAt: time.Now(),
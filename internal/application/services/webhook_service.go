@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// dueWebhookDeliveriesBatchSize ограничивает число доставок, отправляемых за один проход
+const dueWebhookDeliveriesBatchSize = 100
+
+// webhookClaimLease - на сколько забранная доставка скрыта от других экземпляров сервиса;
+// с запасом покрывает отправку всей пачки
+const webhookClaimLease = 10 * time.Minute
+
+// errWebhookSubscriptionDisabled - причина, по которой доставка отключенной подписке уходит в dead
+var errWebhookSubscriptionDisabled = errors.New("webhook subscription is disabled")
+
+type webhookService struct {
+	webhookRepo repositories.WebhookRepository
+	sender      services.WebhookSender
+}
+
+func NewWebhookService(webhookRepo repositories.WebhookRepository, sender services.WebhookSender) services.WebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+	}
+}
+
+func (s *webhookService) CreateSubscription(
+	ctx context.Context,
+	req *services.WebhookSubscriptionRequest,
+) (*entities.WebhookSubscription, error) {
+	subscription, err := entities.NewWebhookSubscription(req.URL, req.Events, req.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	if err := s.webhookRepo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (s *webhookService) GetSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	return s.webhookRepo.GetSubscriptions(ctx)
+}
+
+func (s *webhookService) GetSubscription(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	return s.webhookRepo.GetSubscriptionByID(ctx, id)
+}
+
+func (s *webhookService) UpdateSubscription(
+	ctx context.Context,
+	id uuid.UUID,
+	req *services.WebhookSubscriptionRequest,
+) (*entities.WebhookSubscription, error) {
+	subscription, err := s.webhookRepo.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	if err := subscription.Update(req.URL, req.Events, req.Secret, active); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (s *webhookService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return s.webhookRepo.DeleteSubscription(ctx, id)
+}
+
+func (s *webhookService) GetDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	filter *services.WebhookDeliveryFilter,
+) ([]*entities.WebhookDelivery, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, domainErrors.ErrWebhookDeliveryStatusInvalid
+	}
+
+	if _, err := s.webhookRepo.GetSubscriptionByID(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	return s.webhookRepo.GetDeliveries(ctx, subscriptionID, filter.Status, filter.Limit, filter.Offset)
+}
+
+func (s *webhookService) ReplayDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*entities.WebhookDelivery, error) {
+	delivery, err := s.webhookRepo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.SubscriptionID != subscriptionID {
+		return nil, domainErrors.ErrWebhookDeliveryNotFound
+	}
+
+	replay := delivery.Replay()
+	if err := s.webhookRepo.CreateDeliveries(ctx, []*entities.WebhookDelivery{replay}); err != nil {
+		return nil, err
+	}
+
+	return replay, nil
+}
+
+// DispatchDeliveries отправляет доставки по одной: ошибка одной доставки не останавливает остальные,
+// а неудачная доставка повторяется с растущей задержкой. Доставки сначала забираются (ClaimDueDeliveries),
+// поэтому задача, запущенная на нескольких экземплярах, не отправляет одну доставку дважды.
+func (s *webhookService) DispatchDeliveries(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.webhookRepo.ClaimDueDeliveries(ctx, now, webhookClaimLease, dueWebhookDeliveriesBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	subscriptions := make(map[uuid.UUID]*entities.WebhookSubscription)
+	delivered := 0
+	var errs []error
+	for _, delivery := range due {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = s.webhookRepo.GetSubscriptionByID(ctx, delivery.SubscriptionID)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		// Отключенной подписке не отправляем, но доставку можно повторить через replay после включения
+		if !subscription.Active {
+			delivery.Status = entities.WebhookDeliveryStatusDead
+			delivery.LastError = errWebhookSubscriptionDisabled.Error()
+		} else if status, err := s.sender.Send(ctx, subscription, delivery); err != nil {
+			delivery.MarkFailed(status, err, now)
+			errs = append(errs, err)
+		} else {
+			delivery.MarkDelivered(status, now)
+			delivered++
+		}
+
+		if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return delivered, errors.Join(errs...)
+}
+
+// queueWebhookDeliveries ставит в очередь доставку события заказа всем подписчикам на это событие.
+// Вызывается в транзакции, изменившей заказ: тело фиксирует заказ на момент события.
+func queueWebhookDeliveries(
+	ctx context.Context,
+	repos repositories.TransactionalRepositories,
+	event entities.OrderEvent,
+	order *entities.Order,
+) error {
+	subscriptions, err := repos.WebhookRepository.GetActiveSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []*entities.WebhookDelivery
+	var payload []byte
+	for _, subscription := range subscriptions {
+		if !subscription.Subscribes(event) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = entities.NewOrderEventPayload(event, order, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		deliveries = append(deliveries, entities.NewWebhookDelivery(subscription, event, order.ID, payload))
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return repos.WebhookRepository.CreateDeliveries(ctx, deliveries)
+}
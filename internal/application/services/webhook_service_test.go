@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories/mocks"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// stubWebhookSender отвечает 503 подпискам из failFor и 200 остальным
+type stubWebhookSender struct {
+	failFor map[uuid.UUID]bool
+	sent    []*entities.WebhookDelivery
+}
+
+func (s *stubWebhookSender) Send(
+	_ context.Context,
+	subscription *entities.WebhookSubscription,
+	delivery *entities.WebhookDelivery,
+) (int, error) {
+	if s.failFor[subscription.ID] {
+		return http.StatusServiceUnavailable, errors.New("webhook endpoint responded with status 503")
+	}
+	s.sent = append(s.sent, delivery)
+	return http.StatusOK, nil
+}
+
+func TestWebhookService_DispatchDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	healthy := &entities.WebhookSubscription{ID: uuid.New(), Active: true}
+	broken := &entities.WebhookSubscription{ID: uuid.New(), Active: true}
+	disabled := &entities.WebhookSubscription{ID: uuid.New()}
+	sender := &stubWebhookSender{failFor: map[uuid.UUID]bool{broken.ID: true}}
+	service := NewWebhookService(mockWebhookRepo, sender)
+
+	orderID := uuid.New()
+	first := entities.NewWebhookDelivery(healthy, entities.OrderEventCreated, orderID, []byte(`{}`))
+	second := entities.NewWebhookDelivery(healthy, entities.OrderEventConfirmed, orderID, []byte(`{}`))
+	failed := entities.NewWebhookDelivery(broken, entities.OrderEventCreated, orderID, []byte(`{}`))
+	skipped := entities.NewWebhookDelivery(disabled, entities.OrderEventCreated, orderID, []byte(`{}`))
+
+	now := time.Now()
+	mockWebhookRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), now, webhookClaimLease, dueWebhookDeliveriesBatchSize).
+		Return([]*entities.WebhookDelivery{first, failed, second, skipped}, nil)
+	// Подписка загружается один раз на проход, даже если у нее несколько доставок
+	mockWebhookRepo.EXPECT().GetSubscriptionByID(gomock.Any(), healthy.ID).Return(healthy, nil)
+	mockWebhookRepo.EXPECT().GetSubscriptionByID(gomock.Any(), broken.ID).Return(broken, nil)
+	mockWebhookRepo.EXPECT().GetSubscriptionByID(gomock.Any(), disabled.ID).Return(disabled, nil)
+	mockWebhookRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+
+	delivered, err := service.DispatchDeliveries(context.Background(), now)
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, delivered)
+	assert.Len(t, sender.sent, 2)
+	assert.Equal(t, entities.WebhookDeliveryStatusDelivered, first.Status)
+	assert.Equal(t, http.StatusOK, first.ResponseStatus)
+	assert.Equal(t, entities.WebhookDeliveryStatusPending, failed.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, failed.ResponseStatus)
+	assert.True(t, failed.NextAttemptAt.After(now))
+	assert.Equal(t, entities.WebhookDeliveryStatusDead, skipped.Status)
+	assert.Equal(t, errWebhookSubscriptionDisabled.Error(), skipped.LastError)
+}
+
+func TestWebhookService_ReplayDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	service := NewWebhookService(mockWebhookRepo, &stubWebhookSender{})
+
+	subscription := &entities.WebhookSubscription{ID: uuid.New(), Active: true}
+	dead := entities.NewWebhookDelivery(subscription, entities.OrderEventCreated, uuid.New(), []byte(`{"event":"order_created"}`))
+	dead.Status = entities.WebhookDeliveryStatusDead
+
+	mockWebhookRepo.EXPECT().GetDeliveryByID(gomock.Any(), dead.ID).Return(dead, nil).Times(2)
+	mockWebhookRepo.EXPECT().CreateDeliveries(gomock.Any(), gomock.Len(1)).Return(nil)
+
+	replay, err := service.ReplayDelivery(context.Background(), subscription.ID, dead.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, dead.ID, *replay.ReplayOf)
+	assert.Equal(t, entities.WebhookDeliveryStatusPending, replay.Status)
+	assert.Equal(t, dead.Payload, replay.Payload)
+
+	// Доставку чужой подписки повторить нельзя
+	replay, err = service.ReplayDelivery(context.Background(), uuid.New(), dead.ID)
+
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookDeliveryNotFound)
+	assert.Nil(t, replay)
+}
+
+func TestWebhookService_GetDeliveries_InvalidStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewWebhookService(mocks.NewMockWebhookRepository(ctrl), &stubWebhookSender{})
+
+	deliveries, err := service.GetDeliveries(context.Background(), uuid.New(), &services.WebhookDeliveryFilter{Status: "failed"})
+
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookDeliveryStatusInvalid)
+	assert.Nil(t, deliveries)
+}
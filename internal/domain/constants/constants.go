@@ -6,3 +6,12 @@ const (
 	MinPasswordLength = 8
 	BcryptCost        = 12
 )
+
+// Notification constants
+const (
+	// DefaultLanguage - язык писем для пользователей, не выбравших язык
+	DefaultLanguage = "ru"
+)
+
+// SupportedLanguages - языки, для которых есть шаблоны писем
+var SupportedLanguages = []string{"ru", "en"}
@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	"github.com/google/uuid"
+)
+
+var categorySlugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+const maxCategorySlugLength = 100
+
+type Category struct {
+	ID        uuid.UUID   `json:"id"`
+	ParentID  *uuid.UUID  `json:"parent_id,omitempty"`
+	Name      string      `json:"name"`
+	Slug      string      `json:"slug"`
+	Position  int         `json:"position"`
+	Children  []*Category `json:"children,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// CategoryRef - краткие сведения о категории, которые хранятся в товаре и в снимке позиции заказа
+type CategoryRef struct {
+	ID   uuid.UUID `json:"id"`
+	Slug string    `json:"slug"`
+	Name string    `json:"name"`
+}
+
+// NewCategory создает категорию; parent = nil означает корневую категорию
+func NewCategory(name, slug string, parent *Category, position int) (*Category, error) {
+	if name == "" {
+		return nil, domainErrors.ErrCategoryNameRequired
+	}
+
+	if len(slug) > maxCategorySlugLength || !categorySlugPattern.MatchString(slug) {
+		return nil, domainErrors.ErrCategorySlugInvalid
+	}
+
+	category := &Category{
+		ID:        uuid.New(),
+		Name:      name,
+		Slug:      slug,
+		Position:  position,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if parent != nil {
+		parentID := parent.ID
+		category.ParentID = &parentID
+	}
+
+	return category, nil
+}
+
+func (c *Category) Ref() CategoryRef {
+	return CategoryRef{ID: c.ID, Slug: c.Slug, Name: c.Name}
+}
+
+// BuildCategoryTree собирает плоский список категорий в дерево.
+// Дети упорядочены по Position, затем по имени; категории с отсутствующим родителем становятся корнями.
+func BuildCategoryTree(categories []*Category) []*Category {
+	byID := make(map[uuid.UUID]*Category, len(categories))
+	for _, category := range categories {
+		category.Children = nil
+		byID[category.ID] = category
+	}
+
+	roots := make([]*Category, 0)
+	for _, category := range categories {
+		if category.ParentID != nil {
+			if parent, ok := byID[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, category)
+				continue
+			}
+		}
+		roots = append(roots, category)
+	}
+
+	sortCategories(roots)
+	return roots
+}
+
+func sortCategories(categories []*Category) {
+	sort.SliceStable(categories, func(i, j int) bool {
+		if categories[i].Position != categories[j].Position {
+			return categories[i].Position < categories[j].Position
+		}
+		return categories[i].Name < categories[j].Name
+	})
+
+	for _, category := range categories {
+		sortCategories(category.Children)
+	}
+}
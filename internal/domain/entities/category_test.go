@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCategory(t *testing.T) {
+	parent, err := NewCategory("Одежда", "clothes", nil, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, parent.ParentID)
+
+	tests := []struct {
+		name        string
+		title       string
+		slug        string
+		expectedErr string
+	}{
+		{name: "valid slug", title: "Футболки", slug: "t-shirts"},
+		{name: "missing name", slug: "t-shirts", expectedErr: "category name is required"},
+		{name: "uppercase slug", title: "Футболки", slug: "T-Shirts", expectedErr: "category slug must contain only lowercase letters, digits and hyphens"},
+		{name: "trailing hyphen", title: "Футболки", slug: "shirts-", expectedErr: "category slug must contain only lowercase letters, digits and hyphens"},
+		{name: "empty slug", title: "Футболки", expectedErr: "category slug must contain only lowercase letters, digits and hyphens"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, err := NewCategory(tt.title, tt.slug, parent, 1)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, parent.ID, *category.ParentID)
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedErr, err.Error())
+		})
+	}
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	clothes, _ := NewCategory("Одежда", "clothes", nil, 1)
+	books, _ := NewCategory("Книги", "books", nil, 0)
+	shirts, _ := NewCategory("Футболки", "shirts", clothes, 0)
+	hoodies, _ := NewCategory("Худи", "hoodies", clothes, 0)
+	jackets, _ := NewCategory("Куртки", "jackets", clothes, -1)
+
+	tree := BuildCategoryTree([]*Category{shirts, clothes, hoodies, books, jackets})
+
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "books", tree[0].Slug)
+	assert.Equal(t, "clothes", tree[1].Slug)
+
+	children := tree[1].Children
+	assert.Len(t, children, 3)
+	assert.Equal(t, "jackets", children[0].Slug)
+	assert.Equal(t, "shirts", children[1].Slug)
+	assert.Equal(t, "hoodies", children[2].Slug)
+}
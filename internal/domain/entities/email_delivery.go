@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxEmailAttempts - после стольких неудачных отправок письмо переходит в статус failed
+const MaxEmailAttempts = 5
+
+// emailRetryBaseDelay - задержка перед первым повтором, каждый следующий повтор ждет вдвое дольше
+const emailRetryBaseDelay = time.Minute
+
+type EmailDeliveryStatus string
+
+const (
+	EmailDeliveryStatusPending EmailDeliveryStatus = "pending"
+	EmailDeliveryStatusSent    EmailDeliveryStatus = "sent"
+	EmailDeliveryStatusFailed  EmailDeliveryStatus = "failed"
+)
+
+// EmailDelivery - запись журнала доставки письма о событии заказа. Создается в той же транзакции,
+// что и изменение заказа; текст письма собирается по шаблону на языке Language при отправке.
+// NextAttemptAt - когда письмо можно отправить (повторы идут с экспоненциальной задержкой).
+type EmailDelivery struct {
+	ID            uuid.UUID           `json:"id"`
+	Event         OrderEvent          `json:"event"`
+	OrderID       uuid.UUID           `json:"order_id"`
+	UserID        uuid.UUID           `json:"user_id"`
+	Recipient     string              `json:"recipient"`
+	Language      string              `json:"language"`
+	Subject       string              `json:"subject,omitempty"`
+	Status        EmailDeliveryStatus `json:"status"`
+	Attempts      int                 `json:"attempts"`
+	LastError     string              `json:"last_error,omitempty"`
+	NextAttemptAt time.Time           `json:"next_attempt_at"`
+	CreatedAt     time.Time           `json:"created_at"`
+	SentAt        *time.Time          `json:"sent_at,omitempty"`
+}
+
+// NewOrderEmail ставит в очередь письмо о событии заказа на адрес и языке пользователя
+func NewOrderEmail(event OrderEvent, order *Order, user *User) *EmailDelivery {
+	now := time.Now()
+
+	return &EmailDelivery{
+		ID:            uuid.New(),
+		Event:         event,
+		OrderID:       order.ID,
+		UserID:        user.ID,
+		Recipient:     user.Email,
+		Language:      user.Language,
+		Status:        EmailDeliveryStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+func (d *EmailDelivery) MarkSent(at time.Time) {
+	d.Attempts++
+	d.Status = EmailDeliveryStatusSent
+	d.LastError = ""
+	d.SentAt = &at
+}
+
+// MarkFailed фиксирует неудачную попытку и назначает следующую; после MaxEmailAttempts попыток письмо больше не отправляется
+func (d *EmailDelivery) MarkFailed(err error, at time.Time) {
+	d.Attempts++
+	d.LastError = err.Error()
+
+	if d.Attempts >= MaxEmailAttempts {
+		d.Status = EmailDeliveryStatusFailed
+		return
+	}
+
+	d.NextAttemptAt = at.Add(emailRetryBaseDelay << (d.Attempts - 1))
+}
+
+// EmailMessage - письмо, готовое к отправке через EmailSender
+type EmailMessage struct {
+	To       string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOrderEmail(t *testing.T) {
+	user := &User{ID: uuid.New(), Email: "john@example.com", Language: "en"}
+	order := NewOrder(user.ID)
+
+	delivery := NewOrderEmail(OrderEventCreated, order, user)
+
+	assert.Equal(t, OrderEventCreated, delivery.Event)
+	assert.Equal(t, order.ID, delivery.OrderID)
+	assert.Equal(t, "john@example.com", delivery.Recipient)
+	assert.Equal(t, "en", delivery.Language)
+	assert.Equal(t, EmailDeliveryStatusPending, delivery.Status)
+	assert.False(t, delivery.NextAttemptAt.After(time.Now()))
+}
+
+func TestEmailDelivery_MarkFailed_Backoff(t *testing.T) {
+	user := &User{ID: uuid.New(), Email: "john@example.com", Language: "ru"}
+	delivery := NewOrderEmail(OrderEventConfirmed, NewOrder(user.ID), user)
+	now := time.Now()
+
+	// Задержка удваивается с каждой попыткой
+	expectedDelays := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute}
+	for _, delay := range expectedDelays {
+		delivery.MarkFailed(errors.New("connection refused"), now)
+		assert.Equal(t, EmailDeliveryStatusPending, delivery.Status)
+		assert.Equal(t, now.Add(delay), delivery.NextAttemptAt)
+	}
+
+	delivery.MarkFailed(errors.New("connection refused"), now)
+	assert.Equal(t, MaxEmailAttempts, delivery.Attempts)
+	assert.Equal(t, EmailDeliveryStatusFailed, delivery.Status)
+	assert.Equal(t, "connection refused", delivery.LastError)
+}
+
+func TestEmailDelivery_MarkSent(t *testing.T) {
+	user := &User{ID: uuid.New(), Email: "john@example.com", Language: "ru"}
+	delivery := NewOrderEmail(OrderEventCancelled, NewOrder(user.ID), user)
+	now := time.Now()
+
+	delivery.MarkFailed(errors.New("timeout"), now)
+	delivery.MarkSent(now.Add(time.Minute))
+
+	assert.Equal(t, EmailDeliveryStatusSent, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Empty(t, delivery.LastError)
+	assert.Equal(t, now.Add(time.Minute), *delivery.SentAt)
+}
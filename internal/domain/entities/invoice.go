@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceCurrency - валюта счетов, суммы хранятся в копейках
+const InvoiceCurrency = "RUB"
+
+type Invoice struct {
+	ID         uuid.UUID     `json:"id"`
+	OrderID    uuid.UUID     `json:"order_id"`
+	Number     string        `json:"number"`
+	Year       int           `json:"year"`
+	Sequence   int           `json:"sequence"`
+	SellerName string        `json:"seller_name"`
+	Customer   InvoiceParty  `json:"customer"`
+	Lines      []InvoiceLine `json:"lines"`
+	Total      int64         `json:"total"`
+	Currency   string        `json:"currency"`
+	IssuedAt   time.Time     `json:"issued_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type InvoiceParty struct {
+	ID       uuid.UUID `json:"id"`
+	FullName string    `json:"full_name"`
+}
+
+type InvoiceLine struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	Description string    `json:"description"`
+	Quantity    int       `json:"quantity"`
+	UnitPrice   int64     `json:"unit_price"`
+	Total       int64     `json:"total"`
+}
+
+// NewInvoice формирует счет по завершенному заказу.
+// Позиции берутся из ProductSnapshot, чтобы счет совпадал с ценами на момент заказа.
+func NewInvoice(order *Order, user *User, sellerName string, sequence int, issuedAt time.Time) (*Invoice, error) {
+	if order.Status != OrderStatusCompleted {
+		return nil, domainErrors.ErrOnlyCompletedCanInvoice
+	}
+
+	if sequence <= 0 {
+		return nil, domainErrors.ErrInvoiceSequenceInvalid
+	}
+
+	invoice := &Invoice{
+		ID:         uuid.New(),
+		OrderID:    order.ID,
+		Number:     FormatInvoiceNumber(issuedAt.Year(), sequence),
+		Year:       issuedAt.Year(),
+		Sequence:   sequence,
+		SellerName: sellerName,
+		Customer: InvoiceParty{
+			ID:       user.ID,
+			FullName: user.GetFullName(),
+		},
+		Lines:     make([]InvoiceLine, 0, len(order.Items)),
+		Currency:  InvoiceCurrency,
+		IssuedAt:  issuedAt,
+		CreatedAt: time.Now(),
+	}
+
+	for _, item := range order.Items {
+		invoice.Lines = append(invoice.Lines, InvoiceLine{
+			ProductID:   item.ProductSnapshot.ID,
+			Description: item.ProductSnapshot.DisplayName(),
+			Quantity:    item.Quantity,
+			UnitPrice:   item.PricePerItem,
+			Total:       item.Total,
+		})
+		invoice.Total += item.Total
+	}
+
+	return invoice, nil
+}
+
+// FormatInvoiceNumber возвращает номер вида INV-2025-000042
+func FormatInvoiceNumber(year, sequence int) string {
+	return fmt.Sprintf("INV-%d-%06d", year, sequence)
+}
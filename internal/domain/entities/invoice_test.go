@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInvoice(t *testing.T) {
+	user := &User{ID: uuid.New(), FirstName: "John", LastName: "Doe"}
+	order := NewOrder(user.ID)
+	product := &Product{
+		ID:          uuid.New(),
+		Description: "Test Product",
+		Quantity:    10,
+		Price:       1000,
+	}
+
+	assert.NoError(t, order.AddItem(product, 3))
+	assert.NoError(t, order.Confirm())
+	assert.NoError(t, order.Complete())
+
+	// Цена после заказа не должна попасть в счет
+	product.Price = 5000
+	product.Description = "Renamed Product"
+
+	issuedAt := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	invoice, err := NewInvoice(order, user, "Orders Service", 42, issuedAt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "INV-2025-000042", invoice.Number)
+	assert.Equal(t, 2025, invoice.Year)
+	assert.Equal(t, "John Doe", invoice.Customer.FullName)
+	assert.Equal(t, InvoiceCurrency, invoice.Currency)
+	assert.Len(t, invoice.Lines, 1)
+	assert.Equal(t, "Test Product", invoice.Lines[0].Description)
+	assert.Equal(t, int64(1000), invoice.Lines[0].UnitPrice)
+	assert.Equal(t, int64(3000), invoice.Total)
+}
+
+func TestNewInvoice_OrderNotCompleted(t *testing.T) {
+	user := &User{ID: uuid.New()}
+	order := NewOrder(user.ID)
+
+	_, err := NewInvoice(order, user, "Orders Service", 1, time.Now())
+
+	assert.Error(t, err)
+	assert.Equal(t, "invoices are issued only for completed orders", err.Error())
+}
+
+func TestFormatInvoiceNumber(t *testing.T) {
+	assert.Equal(t, "INV-2024-000001", FormatInvoiceNumber(2024, 1))
+	assert.Equal(t, "INV-2024-1234567", FormatInvoiceNumber(2024, 1234567))
+}
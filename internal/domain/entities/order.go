@@ -1,6 +1,9 @@
 package entities
 
 import (
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
@@ -12,47 +15,129 @@ type OrderStatus string
 
 const (
 	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusOnHold    OrderStatus = "on_hold"
 	OrderStatusConfirmed OrderStatus = "confirmed"
 	OrderStatusCancelled OrderStatus = "cancelled"
 	OrderStatusCompleted OrderStatus = "completed"
 )
 
+// OrderEvent - событие заказа, о котором сообщается покупателю (письмом) и партнерам (вебхуком).
+// О завершении заказа сообщается только партнерам.
+type OrderEvent string
+
+const (
+	OrderEventCreated   OrderEvent = "order_created"
+	OrderEventConfirmed OrderEvent = "order_confirmed"
+	OrderEventCancelled OrderEvent = "order_cancelled"
+	OrderEventCompleted OrderEvent = "order_completed"
+)
+
+// Order - заказ пользователя. RiskScore и HoldReason заполняются,
+// если заказ отложен на ручную проверку (статус on_hold). ReservedAt - начало резерва:
+// от него отсчитывается срок неподтвержденного заказа.
 type Order struct {
-	ID        uuid.UUID   `json:"id"`
-	UserID    uuid.UUID   `json:"user_id"`
-	Status    OrderStatus `json:"status"`
-	Total     int64       `json:"total"`
-	Items     []OrderItem `json:"items"`
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt time.Time   `json:"updated_at"`
+	ID         uuid.UUID   `json:"id"`
+	UserID     uuid.UUID   `json:"user_id"`
+	Status     OrderStatus `json:"status"`
+	Total      int64       `json:"total"`
+	Items      []OrderItem `json:"items"`
+	RiskScore  float64     `json:"risk_score,omitempty"`
+	HoldReason string      `json:"hold_reason,omitempty"`
+	ReservedAt time.Time   `json:"reserved_at"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
 }
 
+// OrderItem - позиция заказа. Backordered - сколько единиц позиции еще ждут
+// поступления на склад; ExpectedAt - ожидаемая дата поступления на момент заказа.
 type OrderItem struct {
 	ID              uuid.UUID       `json:"id"`
 	OrderID         uuid.UUID       `json:"order_id"`
 	ProductID       uuid.UUID       `json:"product_id"`
+	VariantID       *uuid.UUID      `json:"variant_id,omitempty"`
 	ProductSnapshot ProductSnapshot `json:"product_snapshot"`
 	Quantity        int             `json:"quantity"`
+	Backordered     int             `json:"backordered,omitempty"`
+	ExpectedAt      *time.Time      `json:"expected_at,omitempty"`
 	PricePerItem    int64           `json:"price_per_item"`
 	Total           int64           `json:"total"`
 	CreatedAt       time.Time       `json:"created_at"`
 }
 
+// Backorder записывает, сколько единиц позиции не хватило на складе
+func (i *OrderItem) Backorder(shortfall int, expectedAt *time.Time) {
+	i.Backordered = shortfall
+	i.ExpectedAt = nil
+	if shortfall > 0 {
+		i.ExpectedAt = expectedAt
+	}
+}
+
+// Allocate закрывает недостачу позиции поступившим товаром и возвращает, сколько единиц было распределено
+func (i *OrderItem) Allocate(available int) int {
+	allocated := min(i.Backordered, available)
+	if allocated <= 0 {
+		return 0
+	}
+
+	i.Backordered -= allocated
+	if i.Backordered == 0 {
+		i.ExpectedAt = nil
+	}
+
+	return allocated
+}
+
+// AllocatedQuantity - сколько единиц позиции зарезервировано на складе и может быть отгружено
+func (i *OrderItem) AllocatedQuantity() int {
+	return i.Quantity - i.Backordered
+}
+
+// ProductSnapshot фиксирует товар на момент заказа. Для вариантов сохраняются
+// артикул варианта и итоговые атрибуты (атрибуты варианта перекрывают атрибуты товара).
 type ProductSnapshot struct {
-	ID          uuid.UUID `json:"id"`
-	Description string    `json:"description"`
-	Tags        []string  `json:"tags"`
-	Price       int64     `json:"price"`
+	ID          uuid.UUID         `json:"id"`
+	VariantID   *uuid.UUID        `json:"variant_id,omitempty"`
+	SKU         string            `json:"sku,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description"`
+	Tags        []string          `json:"tags"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	Categories  []CategoryRef     `json:"categories,omitempty"`
+	Price       int64             `json:"price"`
+}
+
+// DisplayName возвращает название позиции для документов: имя товара (или описание) и атрибуты варианта
+func (s ProductSnapshot) DisplayName() string {
+	name := s.Name
+	if name == "" {
+		name = s.Description
+	}
+
+	if s.VariantID == nil || len(s.Attributes) == 0 {
+		return name
+	}
+
+	keys := slices.Sorted(maps.Keys(s.Attributes))
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+": "+s.Attributes[key])
+	}
+
+	return name + " (" + strings.Join(parts, ", ") + ")"
 }
 
 func NewOrder(userID uuid.UUID) *Order {
+	now := time.Now()
+
 	return &Order{
-		ID:        uuid.New(),
-		UserID:    userID,
-		Status:    OrderStatusPending,
-		Items:     make([]OrderItem, 0),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:         uuid.New(),
+		UserID:     userID,
+		Status:     OrderStatusPending,
+		Items:      make([]OrderItem, 0),
+		ReservedAt: now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
 	}
 }
 
@@ -61,33 +146,101 @@ func (o *Order) AddItem(product *Product, quantity int) error {
 		return domainErrors.ErrQuantityInvalid
 	}
 
-	if !product.IsAvailable(quantity) {
+	if product.HasVariants() {
+		return domainErrors.ErrVariantRequired
+	}
+
+	if !product.CanFulfill(product.Quantity, quantity) {
 		return domainErrors.ErrInsufficientStock
 	}
 
 	snapshot := ProductSnapshot{
 		ID:          product.ID,
+		SKU:         product.SKU,
+		Name:        product.Name,
 		Description: product.Description,
 		Tags:        product.Tags,
+		Attributes:  product.Attributes,
+		Categories:  product.Categories,
 		Price:       product.Price,
 	}
 
+	o.appendItem(product.ID, nil, snapshot, quantity)
+
+	return nil
+}
+
+// AddVariantItem добавляет в заказ вариант товара по его цене
+func (o *Order) AddVariantItem(product *Product, variant *ProductVariant, quantity int) error {
+	if quantity <= 0 {
+		return domainErrors.ErrQuantityInvalid
+	}
+
+	if variant.ProductID != product.ID {
+		return domainErrors.ErrVariantNotFound
+	}
+
+	if !product.CanFulfill(variant.Quantity, quantity) {
+		return domainErrors.ErrInsufficientStock
+	}
+
+	attributes := make(map[string]string, len(product.Attributes)+len(variant.Attributes))
+	maps.Copy(attributes, product.Attributes)
+	maps.Copy(attributes, variant.Attributes)
+
+	variantID := variant.ID
+	snapshot := ProductSnapshot{
+		ID:          product.ID,
+		VariantID:   &variantID,
+		SKU:         variant.SKU,
+		Name:        product.Name,
+		Description: product.Description,
+		Tags:        product.Tags,
+		Attributes:  attributes,
+		Categories:  product.Categories,
+		Price:       variant.Price,
+	}
+
+	o.appendItem(product.ID, &variantID, snapshot, quantity)
+
+	return nil
+}
+
+func (o *Order) appendItem(productID uuid.UUID, variantID *uuid.UUID, snapshot ProductSnapshot, quantity int) {
 	item := OrderItem{
 		ID:              uuid.New(),
 		OrderID:         o.ID,
-		ProductID:       product.ID,
+		ProductID:       productID,
+		VariantID:       variantID,
 		ProductSnapshot: snapshot,
 		Quantity:        quantity,
-		PricePerItem:    product.Price,
-		Total:           product.Price * int64(quantity),
+		PricePerItem:    snapshot.Price,
+		Total:           snapshot.Price * int64(quantity),
 		CreatedAt:       time.Now(),
 	}
 
 	o.Items = append(o.Items, item)
 	o.calculateTotal()
 	o.UpdatedAt = time.Now()
+}
 
-	return nil
+// HasBackorders сообщает, ждет ли какая-либо позиция заказа поступления на склад
+func (o *Order) HasBackorders() bool {
+	for _, item := range o.Items {
+		if item.Backordered > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// QuantitiesByProduct суммирует количество по товарам, включая разные варианты одного товара
+func (o *Order) QuantitiesByProduct() map[uuid.UUID]int {
+	quantities := make(map[uuid.UUID]int, len(o.Items))
+	for _, item := range o.Items {
+		quantities[item.ProductID] += item.Quantity
+	}
+	return quantities
 }
 
 func (o *Order) calculateTotal() {
@@ -114,13 +267,76 @@ func (o *Order) Confirm() error {
 	return nil
 }
 
+// Hold откладывает новый заказ на ручную проверку. Товар остается зарезервированным.
+func (o *Order) Hold(assessment *RiskAssessment) error {
+	if o.Status != OrderStatusPending {
+		return domainErrors.ErrOnlyPendingCanHold
+	}
+
+	o.Status = OrderStatusOnHold
+	o.RiskScore = assessment.Score
+	o.HoldReason = strings.Join(assessment.Reasons, "; ")
+	o.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Release возвращает проверенный заказ в работу; отклоненный заказ отменяется через Cancel.
+// Срок на подтверждение начинается заново: время на проверке в него не входит.
+func (o *Order) Release() error {
+	if o.Status != OrderStatusOnHold {
+		return domainErrors.ErrOnlyOnHoldCanRelease
+	}
+
+	o.Status = OrderStatusPending
+	o.ReservedAt = time.Now()
+	o.UpdatedAt = o.ReservedAt
+
+	return nil
+}
+
+// Cancel отменяет заказ; повторная отмена отклоняется, чтобы не возвращать резерв и не сообщать об отмене дважды
 func (o *Order) Cancel() error {
 	if o.Status == OrderStatusCompleted {
 		return domainErrors.ErrCompletedOrdersReadonly
 	}
+	if o.Status == OrderStatusCancelled {
+		return domainErrors.ErrOrderAlreadyCancelled
+	}
 
 	o.Status = OrderStatusCancelled
 	o.UpdatedAt = time.Now()
 
 	return nil
 }
+
+// Complete завершает заказ после доставки всех позиций
+func (o *Order) Complete() error {
+	if o.Status != OrderStatusConfirmed {
+		return domainErrors.ErrOnlyConfirmedCanComplete
+	}
+
+	o.Status = OrderStatusCompleted
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsFullyDelivered проверяет, что все позиции заказа доставлены
+func (o *Order) IsFullyDelivered(shipments []*Shipment) bool {
+	delivered := ShippedQuantities(shipments, true)
+	for _, item := range o.Items {
+		if delivered[item.ID] < item.Quantity {
+			return false
+		}
+	}
+	return len(o.Items) > 0
+}
+
+func (o *Order) findItem(itemID uuid.UUID) *OrderItem {
+	for i := range o.Items {
+		if o.Items[i].ID == itemID {
+			return &o.Items[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"time"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	"github.com/google/uuid"
+)
+
+// OrderLimits - ограничения, которые проверяются при создании заказа до резервирования товара.
+// Нулевое значение любого ограничения отключает его.
+type OrderLimits struct {
+	// MaxQuantityPerProduct - сколько единиц одного товара можно купить в одном заказе
+	MaxQuantityPerProduct int
+	// MaxQuantityPerUser - сколько единиц одного товара пользователь может купить за UserWindow
+	MaxQuantityPerUser int
+	UserWindow         time.Duration
+	// MaxOrderTotal - максимальная сумма заказа в копейках
+	MaxOrderTotal int64
+	// MaxOrdersPerUser - сколько заказов пользователь может создать за VelocityWindow
+	MaxOrdersPerUser int
+	VelocityWindow   time.Duration
+}
+
+// TracksUserQuantities сообщает, нужны ли для проверки покупки пользователя за окно
+func (l OrderLimits) TracksUserQuantities() bool {
+	return l.MaxQuantityPerUser > 0 && l.UserWindow > 0
+}
+
+// TracksVelocity сообщает, нужно ли для проверки число недавних заказов пользователя
+func (l OrderLimits) TracksVelocity() bool {
+	return l.MaxOrdersPerUser > 0 && l.VelocityWindow > 0
+}
+
+// UserOrderHistory - недавние покупки пользователя без учета отмененных заказов
+type UserOrderHistory struct {
+	// RecentOrders - число заказов за окно VelocityWindow
+	RecentOrders int
+	// RecentQuantities - купленные единицы по товарам за окно UserWindow
+	RecentQuantities map[uuid.UUID]int
+}
+
+// Check проверяет собранный, но еще не сохраненный заказ
+func (l OrderLimits) Check(order *Order, history *UserOrderHistory) error {
+	if l.TracksVelocity() && history.RecentOrders >= l.MaxOrdersPerUser {
+		return domainErrors.ErrOrderVelocityLimit
+	}
+
+	if l.MaxOrderTotal > 0 && order.Total > l.MaxOrderTotal {
+		return domainErrors.ErrOrderTotalLimit
+	}
+
+	for productID, quantity := range order.QuantitiesByProduct() {
+		if l.MaxQuantityPerProduct > 0 && quantity > l.MaxQuantityPerProduct {
+			return domainErrors.ErrOrderProductQuantityLimit
+		}
+
+		if l.TracksUserQuantities() && history.RecentQuantities[productID]+quantity > l.MaxQuantityPerUser {
+			return domainErrors.ErrUserProductQuantityLimit
+		}
+	}
+
+	return nil
+}
+
+// RiskAssessment - результат оценки риска мошенничества: Score от 0 (нет риска) до 1
+type RiskAssessment struct {
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons,omitempty"`
+}
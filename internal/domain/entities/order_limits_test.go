@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderLimits_Check(t *testing.T) {
+	product := &Product{ID: uuid.New(), Description: "Test Product", Quantity: 100, Price: 1000}
+
+	tests := []struct {
+		name        string
+		limits      OrderLimits
+		quantity    int
+		history     *UserOrderHistory
+		expectedErr string
+	}{
+		{
+			name:     "no limits",
+			quantity: 50,
+			history:  &UserOrderHistory{},
+		},
+		{
+			name:        "product quantity per order",
+			limits:      OrderLimits{MaxQuantityPerProduct: 5},
+			quantity:    6,
+			history:     &UserOrderHistory{},
+			expectedErr: "order exceeds the maximum quantity per product",
+		},
+		{
+			name:        "product quantity per user window",
+			limits:      OrderLimits{MaxQuantityPerUser: 10, UserWindow: 24 * time.Hour},
+			quantity:    3,
+			history:     &UserOrderHistory{RecentQuantities: map[uuid.UUID]int{product.ID: 8}},
+			expectedErr: "purchase limit for this product has been reached",
+		},
+		{
+			name:     "user window without window duration is disabled",
+			limits:   OrderLimits{MaxQuantityPerUser: 10},
+			quantity: 3,
+			history:  &UserOrderHistory{RecentQuantities: map[uuid.UUID]int{product.ID: 8}},
+		},
+		{
+			name:        "order total",
+			limits:      OrderLimits{MaxOrderTotal: 5000},
+			quantity:    6,
+			history:     &UserOrderHistory{},
+			expectedErr: "order total exceeds the allowed maximum",
+		},
+		{
+			name:        "velocity",
+			limits:      OrderLimits{MaxOrdersPerUser: 3, VelocityWindow: time.Hour},
+			quantity:    1,
+			history:     &UserOrderHistory{RecentOrders: 3},
+			expectedErr: "too many orders in a short period",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := NewOrder(uuid.New())
+			assert.NoError(t, order.AddItem(product, tt.quantity))
+
+			err := tt.limits.Check(order, tt.history)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedErr, err.Error())
+		})
+	}
+}
+
+func TestOrderLimits_Check_SumsRepeatedProduct(t *testing.T) {
+	product := &Product{ID: uuid.New(), Description: "Test Product", Quantity: 100, Price: 1000}
+
+	order := NewOrder(uuid.New())
+	assert.NoError(t, order.AddItem(product, 3))
+	assert.NoError(t, order.AddItem(product, 3))
+
+	err := OrderLimits{MaxQuantityPerProduct: 5}.Check(order, &UserOrderHistory{})
+
+	assert.Error(t, err)
+	assert.Equal(t, "order exceeds the maximum quantity per product", err.Error())
+}
+
+func TestOrder_HoldAndRelease(t *testing.T) {
+	order := NewOrder(uuid.New())
+
+	err := order.Release()
+	assert.Error(t, err)
+	assert.Equal(t, "only orders on hold can be released", err.Error())
+
+	err = order.Hold(&RiskAssessment{Score: 0.8, Reasons: []string{"new account", "high order total"}})
+	assert.NoError(t, err)
+	assert.Equal(t, OrderStatusOnHold, order.Status)
+	assert.Equal(t, 0.8, order.RiskScore)
+	assert.Equal(t, "new account; high order total", order.HoldReason)
+
+	// Заказ на проверке нельзя подтвердить до решения модератора
+	assert.Error(t, order.Confirm())
+	assert.Error(t, order.Hold(&RiskAssessment{Score: 1}))
+
+	err = order.Release()
+	assert.NoError(t, err)
+	assert.Equal(t, OrderStatusPending, order.Status)
+}
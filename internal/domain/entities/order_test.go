@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -123,3 +125,93 @@ func TestOrder_Cancel_CompletedOrder(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "completed orders cannot be cancelled", err.Error())
 }
+
+func TestOrder_Cancel_AlreadyCancelled(t *testing.T) {
+	order := NewOrder(uuid.New())
+	assert.NoError(t, order.Cancel())
+
+	err := order.Cancel()
+	assert.ErrorIs(t, err, domainErrors.ErrOrderAlreadyCancelled)
+}
+
+func TestOrder_Release_RestartsReservation(t *testing.T) {
+	order := NewOrder(uuid.New())
+	order.ReservedAt = time.Now().Add(-48 * time.Hour)
+	assert.NoError(t, order.Hold(&RiskAssessment{Score: 0.9, Reasons: []string{"new account"}}))
+
+	assert.NoError(t, order.Release())
+
+	// проверка заняла двое суток, но срок на подтверждение отсчитывается заново
+	assert.Equal(t, OrderStatusPending, order.Status)
+	assert.WithinDuration(t, time.Now(), order.ReservedAt, time.Second)
+}
+
+func TestOrder_Complete(t *testing.T) {
+	userID := uuid.New()
+	order := NewOrder(userID)
+
+	err := order.Complete()
+	assert.Error(t, err)
+	assert.Equal(t, "only confirmed orders can be completed", err.Error())
+
+	order.Status = OrderStatusConfirmed
+
+	err = order.Complete()
+	assert.NoError(t, err)
+	assert.Equal(t, OrderStatusCompleted, order.Status)
+}
+
+func TestOrder_IsFullyDelivered(t *testing.T) {
+	userID := uuid.New()
+	order := NewOrder(userID)
+	product := &Product{
+		ID:       uuid.New(),
+		Quantity: 10,
+		Price:    1000,
+	}
+
+	err := order.AddItem(product, 2)
+	assert.NoError(t, err)
+
+	shipment := &Shipment{
+		Status: ShipmentStatusInTransit,
+		Items:  []ShipmentItem{{OrderItemID: order.Items[0].ID, Quantity: 2}},
+	}
+
+	assert.False(t, order.IsFullyDelivered([]*Shipment{shipment}))
+
+	shipment.Status = ShipmentStatusDelivered
+	assert.True(t, order.IsFullyDelivered([]*Shipment{shipment}))
+}
+
+func TestOrder_AddItem_Backorder(t *testing.T) {
+	order := NewOrder(uuid.New())
+	product := &Product{ID: uuid.New(), Description: "Console", Quantity: 1, Price: 1000}
+
+	err := order.AddItem(product, 3)
+	assert.Error(t, err)
+	assert.Equal(t, "insufficient product quantity", err.Error())
+
+	// Заказ сверх остатка принимается, если товар продается под заказ
+	product.StockPolicy = StockPolicyBackorder
+	assert.NoError(t, order.AddItem(product, 3))
+	assert.Equal(t, int64(3000), order.Total)
+}
+
+func TestOrderItem_Allocate(t *testing.T) {
+	expectedAt := time.Now().AddDate(0, 0, 14)
+	item := &OrderItem{Quantity: 5}
+
+	item.Backorder(3, &expectedAt)
+	assert.Equal(t, 2, item.AllocatedQuantity())
+	assert.Equal(t, &expectedAt, item.ExpectedAt)
+
+	assert.Equal(t, 2, item.Allocate(2))
+	assert.Equal(t, 1, item.Backordered)
+	assert.NotNil(t, item.ExpectedAt)
+
+	assert.Equal(t, 1, item.Allocate(10))
+	assert.Equal(t, 0, item.Backordered)
+	assert.Nil(t, item.ExpectedAt)
+	assert.Equal(t, 0, item.Allocate(10))
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"time"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	"github.com/google/uuid"
+)
+
+type PriceStatus string
+
+const (
+	PriceStatusScheduled PriceStatus = "scheduled"
+	PriceStatusActive    PriceStatus = "active"
+	PriceStatusExpired   PriceStatus = "expired"
+)
+
+// PriceChange - запись истории цен товара. Цена действует в окне [ValidFrom, ValidTo),
+// ValidTo = nil означает, что окно открыто до следующего изменения.
+type PriceChange struct {
+	ID        uuid.UUID  `json:"id"`
+	ProductID uuid.UUID  `json:"product_id"`
+	Price     int64      `json:"price"`
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+	// AppliedAt - когда цена записана в карточку товара; пусто у еще не наступивших цен
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NewPriceChange(productID uuid.UUID, price int64, validFrom time.Time) (*PriceChange, error) {
+	if price <= 0 {
+		return nil, domainErrors.ErrProductPriceInvalid
+	}
+
+	return &PriceChange{
+		ID:        uuid.New(),
+		ProductID: productID,
+		Price:     price,
+		ValidFrom: validFrom,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// IsEffectiveAt проверяет, что момент at попадает в окно действия цены
+func (c *PriceChange) IsEffectiveAt(at time.Time) bool {
+	if at.Before(c.ValidFrom) {
+		return false
+	}
+	return c.ValidTo == nil || at.Before(*c.ValidTo)
+}
+
+// Status определяет положение окна цены относительно момента now
+func (c *PriceChange) Status(now time.Time) PriceStatus {
+	switch {
+	case now.Before(c.ValidFrom):
+		return PriceStatusScheduled
+	case c.IsEffectiveAt(now):
+		return PriceStatusActive
+	default:
+		return PriceStatusExpired
+	}
+}
+
+func (c *PriceChange) IsApplied() bool {
+	return c.AppliedAt != nil
+}
+
+func (c *PriceChange) MarkApplied(at time.Time) {
+	c.AppliedAt = &at
+}
+
+// PriceHistory - изменения цены одного товара, упорядоченные по ValidFrom
+type PriceHistory []*PriceChange
+
+// EffectiveAt возвращает цену, действующую в момент at, или nil, если истории на этот момент нет
+func (h PriceHistory) EffectiveAt(at time.Time) *PriceChange {
+	for _, change := range h {
+		if change.IsEffectiveAt(at) {
+			return change
+		}
+	}
+	return nil
+}
+
+// Insert встраивает новую цену в историю: окно цены, действовавшей на момент change.ValidFrom,
+// закрывается, а новая цена действует до начала следующей запланированной.
+// Возвращает запись, окно которой было закрыто (nil, если такой нет). Сама история не меняется.
+func (h PriceHistory) Insert(change *PriceChange) (*PriceChange, error) {
+	var previous *PriceChange
+	for _, existing := range h {
+		if existing.ValidFrom.Equal(change.ValidFrom) {
+			return nil, domainErrors.ErrPriceAlreadyScheduled
+		}
+		if existing.ValidFrom.Before(change.ValidFrom) {
+			previous = existing
+			continue
+		}
+
+		next := existing.ValidFrom
+		change.ValidTo = &next
+		break
+	}
+
+	if previous != nil {
+		validTo := change.ValidFrom
+		previous.ValidTo = &validTo
+	}
+
+	return previous, nil
+}
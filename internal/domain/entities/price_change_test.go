@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPriceChange_InvalidPrice(t *testing.T) {
+	change, err := NewPriceChange(uuid.New(), 0, time.Now())
+
+	assert.Error(t, err)
+	assert.Nil(t, change)
+	assert.Equal(t, "price must be greater than 0", err.Error())
+}
+
+func TestPriceHistory_Insert(t *testing.T) {
+	productID := uuid.New()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	saleStart := start.AddDate(0, 1, 0)
+	saleEnd := start.AddDate(0, 2, 0)
+
+	regular, _ := NewPriceChange(productID, 1000, start)
+	sale, _ := NewPriceChange(productID, 800, saleStart)
+
+	previous, err := PriceHistory{regular}.Insert(sale)
+	assert.NoError(t, err)
+	assert.Equal(t, regular, previous)
+	assert.Equal(t, saleStart, *regular.ValidTo)
+	assert.Nil(t, sale.ValidTo)
+
+	// Возврат обычной цены после распродажи
+	afterSale, _ := NewPriceChange(productID, 1000, saleEnd)
+	_, err = PriceHistory{regular, sale}.Insert(afterSale)
+	assert.NoError(t, err)
+	assert.Equal(t, saleEnd, *sale.ValidTo)
+
+	// Цена, вставленная внутрь распродажи, действует до ее следующего изменения
+	midSale, _ := NewPriceChange(productID, 700, saleStart.AddDate(0, 0, 10))
+	previous, err = PriceHistory{regular, sale, afterSale}.Insert(midSale)
+	assert.NoError(t, err)
+	assert.Equal(t, sale, previous)
+	assert.Equal(t, saleEnd, *midSale.ValidTo)
+
+	history := PriceHistory{regular, sale, midSale, afterSale}
+	assert.Equal(t, int64(1000), history.EffectiveAt(start.AddDate(0, 0, 5)).Price)
+	assert.Equal(t, int64(800), history.EffectiveAt(saleStart).Price)
+	assert.Equal(t, int64(700), history.EffectiveAt(saleEnd.Add(-time.Second)).Price)
+	assert.Equal(t, int64(1000), history.EffectiveAt(saleEnd).Price)
+	assert.Nil(t, history.EffectiveAt(start.Add(-time.Second)))
+}
+
+func TestPriceHistory_Insert_SameStart(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	regular, _ := NewPriceChange(uuid.New(), 1000, start)
+	duplicate, _ := NewPriceChange(regular.ProductID, 900, start)
+
+	previous, err := PriceHistory{regular}.Insert(duplicate)
+
+	assert.Error(t, err)
+	assert.Nil(t, previous)
+	assert.Equal(t, "a price is already scheduled for this time", err.Error())
+}
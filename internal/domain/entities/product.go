@@ -8,14 +8,59 @@ import (
 	"github.com/google/uuid"
 )
 
+// MaxSKULength - максимальная длина артикула
+const MaxSKULength = 64
+
+// StockPolicy определяет, можно ли заказать товар сверх текущего остатка
+type StockPolicy string
+
+const (
+	// StockPolicyInStock - товар продается только из наличия
+	StockPolicyInStock StockPolicy = "in_stock"
+	// StockPolicyBackorder - недостающие единицы ждут поступления на склад
+	StockPolicyBackorder StockPolicy = "backorder"
+	// StockPolicyPreorder - товар еще не вышел, дата поступления обязательна
+	StockPolicyPreorder StockPolicy = "preorder"
+)
+
+func (p StockPolicy) IsValid() bool {
+	switch p {
+	case StockPolicyInStock, StockPolicyBackorder, StockPolicyPreorder:
+		return true
+	}
+	return false
+}
+
+// Product - карточка товара. Если у товара есть варианты, цена и остаток
+// ведутся по вариантам, а Price и Quantity самого товара в заказах не используются.
+// StockPolicy и ExpectedAt действуют и на варианты товара. При снижении остатка товара
+// или варианта до LowStockThreshold сотрудники получают уведомление, 0 отключает порог.
 type Product struct {
-	ID          uuid.UUID `json:"id"`
-	Description string    `json:"description"`
-	Tags        []string  `json:"tags"`
-	Quantity    int       `json:"quantity"`
-	Price       int64     `json:"price"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                uuid.UUID         `json:"id"`
+	SKU               string            `json:"sku"`
+	Name              string            `json:"name"`
+	Description       string            `json:"description"`
+	Tags              []string          `json:"tags"`
+	Attributes        map[string]string `json:"attributes"`
+	Quantity          int               `json:"quantity"`
+	Price             int64             `json:"price"`
+	StockPolicy       StockPolicy       `json:"stock_policy"`
+	ExpectedAt        *time.Time        `json:"expected_at,omitempty"`
+	LowStockThreshold int               `json:"low_stock_threshold"`
+	Variants          []ProductVariant  `json:"variants"`
+	Categories        []CategoryRef     `json:"categories"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+}
+
+// StockReceipt - итог поступления товара на склад: сколько единиц ушло в ожидающие заказы
+// в порядке их создания и сколько осталось в свободном остатке
+type StockReceipt struct {
+	ProductID uuid.UUID  `json:"product_id"`
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	Received  int        `json:"received"`
+	Allocated int        `json:"allocated"`
+	Available int        `json:"available"`
 }
 
 func (p *Product) ValidateForCreation() error {
@@ -23,7 +68,11 @@ func (p *Product) ValidateForCreation() error {
 		return domainErrors.ErrProductDescriptionRequired
 	}
 
-	if p.Price <= 0 {
+	if p.Price <= 0 && !p.HasVariants() {
+		return domainErrors.ErrProductPriceInvalid
+	}
+
+	if p.Price < 0 {
 		return domainErrors.ErrProductPriceInvalid
 	}
 
@@ -31,6 +80,66 @@ func (p *Product) ValidateForCreation() error {
 		return domainErrors.ErrProductQuantityNegative
 	}
 
+	if len(p.SKU) > MaxSKULength {
+		return domainErrors.ErrProductSKUTooLong
+	}
+
+	if p.StockPolicy == "" {
+		p.StockPolicy = StockPolicyInStock
+	}
+
+	if !p.StockPolicy.IsValid() {
+		return domainErrors.ErrStockPolicyInvalid
+	}
+
+	if p.StockPolicy == StockPolicyPreorder && p.ExpectedAt == nil {
+		return domainErrors.ErrPreorderDateRequired
+	}
+
+	for i := range p.Variants {
+		if err := p.Variants[i].ValidateForCreation(); err != nil {
+			return err
+		}
+
+		for j := range i {
+			if p.Variants[j].SKU == p.Variants[i].SKU || p.Variants[j].sameAttributes(&p.Variants[i]) {
+				return domainErrors.ErrVariantDuplicate
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Product) HasVariants() bool {
+	return len(p.Variants) > 0
+}
+
+// AddVariant добавляет вариант, проверяя, что артикул и набор атрибутов не повторяются
+func (p *Product) AddVariant(variant *ProductVariant) error {
+	if err := variant.ValidateForCreation(); err != nil {
+		return err
+	}
+
+	for i := range p.Variants {
+		if p.Variants[i].SKU == variant.SKU || p.Variants[i].sameAttributes(variant) {
+			return domainErrors.ErrVariantDuplicate
+		}
+	}
+
+	variant.ProductID = p.ID
+	p.Variants = append(p.Variants, *variant)
+	p.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (p *Product) FindVariant(variantID uuid.UUID) *ProductVariant {
+	for i := range p.Variants {
+		if p.Variants[i].ID == variantID {
+			return &p.Variants[i]
+		}
+	}
 	return nil
 }
 
@@ -38,6 +147,54 @@ func (p *Product) IsAvailable(requestedQuantity int) bool {
 	return p.Quantity >= requestedQuantity
 }
 
+// AvailableQuantity возвращает остаток товара или варианта. Для товара с вариантами
+// без указания варианта возвращается суммарный остаток всех вариантов.
+func (p *Product) AvailableQuantity(variantID *uuid.UUID) (int, error) {
+	if variantID != nil {
+		variant := p.FindVariant(*variantID)
+		if variant == nil {
+			return 0, domainErrors.ErrVariantNotFound
+		}
+		return variant.Quantity, nil
+	}
+
+	if !p.HasVariants() {
+		return p.Quantity, nil
+	}
+
+	total := 0
+	for i := range p.Variants {
+		total += p.Variants[i].Quantity
+	}
+	return total, nil
+}
+
+// SetLowStockThreshold задает порог низкого остатка, 0 отключает уведомления
+func (p *Product) SetLowStockThreshold(threshold int) error {
+	if threshold < 0 {
+		return domainErrors.ErrLowStockThresholdInvalid
+	}
+
+	p.LowStockThreshold = threshold
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// StockChange описывает изменение остатка товара относительно значения before
+func (p *Product) StockChange(before int) StockChange {
+	return StockChange{ProductID: p.ID, SKU: p.SKU, Before: before, After: p.Quantity}
+}
+
+// AcceptsBackorders сообщает, можно ли заказать товар сверх остатка
+func (p *Product) AcceptsBackorders() bool {
+	return p.StockPolicy == StockPolicyBackorder || p.StockPolicy == StockPolicyPreorder
+}
+
+// CanFulfill проверяет, можно ли принять заказ на указанное количество
+func (p *Product) CanFulfill(available, requestedQuantity int) bool {
+	return available >= requestedQuantity || p.AcceptsBackorders()
+}
+
 func (p *Product) ReserveQuantity(quantity int) error {
 	if quantity <= 0 {
 		return domainErrors.ErrQuantityInvalid
@@ -51,3 +208,24 @@ func (p *Product) ReserveQuantity(quantity int) error {
 
 	return nil
 }
+
+// Restock возвращает единицы товара на склад
+func (p *Product) Restock(quantity int) error {
+	if quantity <= 0 {
+		return domainErrors.ErrQuantityInvalid
+	}
+
+	p.Quantity += quantity
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// ApplyPrice записывает в карточку товара цену из истории цен
+func (p *Product) ApplyPrice(change *PriceChange) {
+	if p.Price == change.Price {
+		return
+	}
+
+	p.Price = change.Price
+	p.UpdatedAt = time.Now()
+}
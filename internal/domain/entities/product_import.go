@@ -0,0 +1,54 @@
+package entities
+
+type ProductImportAction string
+
+const (
+	ProductImportCreated ProductImportAction = "created"
+	ProductImportUpdated ProductImportAction = "updated"
+	ProductImportFailed  ProductImportAction = "failed"
+)
+
+// ProductImportRowResult - итог обработки одной строки файла импорта
+type ProductImportRowResult struct {
+	Line   int                 `json:"line"`
+	SKU    string              `json:"sku"`
+	Action ProductImportAction `json:"action"`
+	Error  string              `json:"error,omitempty"`
+}
+
+// ProductImportReport - построчный отчет импорта каталога
+type ProductImportReport struct {
+	Total   int                      `json:"total"`
+	Created int                      `json:"created"`
+	Updated int                      `json:"updated"`
+	Failed  int                      `json:"failed"`
+	Rows    []ProductImportRowResult `json:"rows"`
+}
+
+func (r *ProductImportReport) AddSuccess(line int, sku string, created bool) {
+	action := ProductImportUpdated
+	if created {
+		action = ProductImportCreated
+		r.Created++
+	} else {
+		r.Updated++
+	}
+
+	r.Total++
+	r.Rows = append(r.Rows, ProductImportRowResult{Line: line, SKU: sku, Action: action})
+}
+
+func (r *ProductImportReport) AddFailure(line int, sku string, err error) {
+	r.Total++
+	r.Failed++
+	r.Rows = append(r.Rows, ProductImportRowResult{
+		Line:   line,
+		SKU:    sku,
+		Action: ProductImportFailed,
+		Error:  err.Error(),
+	})
+}
+
+func (r *ProductImportReport) HasFailures() bool {
+	return r.Failed > 0
+}
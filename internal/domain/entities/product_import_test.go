@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductImportReport_Counters(t *testing.T) {
+	report := &ProductImportReport{}
+
+	report.AddSuccess(2, "SKU-1", true)
+	report.AddSuccess(3, "SKU-2", false)
+	report.AddFailure(4, "SKU-3", errors.New("price must be greater than 0"))
+
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 1, report.Failed)
+	assert.True(t, report.HasFailures())
+
+	assert.Equal(t, ProductImportFailed, report.Rows[2].Action)
+	assert.Equal(t, "price must be greater than 0", report.Rows[2].Error)
+}
@@ -1,7 +1,9 @@
 package entities
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -116,3 +118,62 @@ func TestProduct_ReserveQuantity(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "quantity must be greater than 0", err.Error())
 }
+
+func TestProduct_Restock(t *testing.T) {
+	product := Product{
+		ID:       uuid.New(),
+		Quantity: 3,
+	}
+
+	err := product.Restock(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, product.Quantity)
+
+	err = product.Restock(0)
+	assert.Error(t, err)
+	assert.Equal(t, "quantity must be greater than 0", err.Error())
+	assert.Equal(t, 5, product.Quantity)
+}
+
+func TestProduct_ValidateForCreation_SKUTooLong(t *testing.T) {
+	product := &Product{
+		SKU:         strings.Repeat("A", MaxSKULength+1),
+		Description: "Test Product",
+		Quantity:    1,
+		Price:       100,
+	}
+
+	err := product.ValidateForCreation()
+
+	assert.Error(t, err)
+	assert.Equal(t, "sku must not exceed 64 characters", err.Error())
+}
+
+func TestProduct_ValidateForCreation_StockPolicy(t *testing.T) {
+	product := &Product{Description: "Test Product", Price: 100}
+
+	assert.NoError(t, product.ValidateForCreation())
+	assert.Equal(t, StockPolicyInStock, product.StockPolicy)
+
+	product.StockPolicy = StockPolicyPreorder
+	err := product.ValidateForCreation()
+	assert.Error(t, err)
+	assert.Equal(t, "preorder products require an expected availability date", err.Error())
+
+	expectedAt := time.Now().AddDate(0, 1, 0)
+	product.ExpectedAt = &expectedAt
+	assert.NoError(t, product.ValidateForCreation())
+
+	product.StockPolicy = "always"
+	assert.Error(t, product.ValidateForCreation())
+}
+
+func TestProduct_CanFulfill(t *testing.T) {
+	product := &Product{Quantity: 1}
+
+	assert.True(t, product.CanFulfill(product.Quantity, 1))
+	assert.False(t, product.CanFulfill(product.Quantity, 2))
+
+	product.StockPolicy = StockPolicyBackorder
+	assert.True(t, product.CanFulfill(product.Quantity, 2))
+}
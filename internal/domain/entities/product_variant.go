@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"maps"
+	"time"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	"github.com/google/uuid"
+)
+
+// ProductVariant - вариант товара (например, размер и цвет) со своим артикулом, ценой и остатком
+type ProductVariant struct {
+	ID         uuid.UUID         `json:"id"`
+	ProductID  uuid.UUID         `json:"product_id"`
+	SKU        string            `json:"sku"`
+	Attributes map[string]string `json:"attributes"`
+	Price      int64             `json:"price"`
+	Quantity   int               `json:"quantity"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+func (v *ProductVariant) ValidateForCreation() error {
+	if v.SKU == "" {
+		return domainErrors.ErrProductSKURequired
+	}
+
+	if len(v.SKU) > MaxSKULength {
+		return domainErrors.ErrProductSKUTooLong
+	}
+
+	if len(v.Attributes) == 0 {
+		return domainErrors.ErrVariantAttributesRequired
+	}
+
+	if v.Price <= 0 {
+		return domainErrors.ErrProductPriceInvalid
+	}
+
+	if v.Quantity < 0 {
+		return domainErrors.ErrProductQuantityNegative
+	}
+
+	return nil
+}
+
+func (v *ProductVariant) IsAvailable(requestedQuantity int) bool {
+	return v.Quantity >= requestedQuantity
+}
+
+func (v *ProductVariant) ReserveQuantity(quantity int) error {
+	if quantity <= 0 {
+		return domainErrors.ErrQuantityInvalid
+	}
+
+	if !v.IsAvailable(quantity) {
+		return domainErrors.ErrInsufficientQuantity
+	}
+
+	v.Quantity -= quantity
+	v.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Restock возвращает единицы варианта на склад
+func (v *ProductVariant) Restock(quantity int) error {
+	if quantity <= 0 {
+		return domainErrors.ErrQuantityInvalid
+	}
+
+	v.Quantity += quantity
+	v.UpdatedAt = time.Now()
+	return nil
+}
+
+// sameAttributes сравнивает наборы атрибутов двух вариантов
+func (v *ProductVariant) sameAttributes(other *ProductVariant) bool {
+	return maps.Equal(v.Attributes, other.Attributes)
+}
+
+// StockChange описывает изменение остатка варианта относительно значения before
+func (v *ProductVariant) StockChange(before int) StockChange {
+	variantID := v.ID
+	return StockChange{ProductID: v.ProductID, VariantID: &variantID, SKU: v.SKU, Before: before, After: v.Quantity}
+}
@@ -0,0 +1,138 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newShirt() *Product {
+	return &Product{
+		ID:          uuid.New(),
+		Name:        "Футболка",
+		Description: "Хлопковая футболка",
+		Attributes:  map[string]string{"material": "cotton"},
+	}
+}
+
+func TestProductVariant_ValidateForCreation(t *testing.T) {
+	tests := []struct {
+		name        string
+		variant     *ProductVariant
+		expectedErr string
+	}{
+		{
+			name:    "valid variant",
+			variant: &ProductVariant{SKU: "SHIRT-M-RED", Attributes: map[string]string{"size": "M"}, Price: 1500},
+		},
+		{
+			name:        "missing sku",
+			variant:     &ProductVariant{Attributes: map[string]string{"size": "M"}, Price: 1500},
+			expectedErr: "sku is required",
+		},
+		{
+			name:        "missing attributes",
+			variant:     &ProductVariant{SKU: "SHIRT", Price: 1500},
+			expectedErr: "variant must have at least one attribute",
+		},
+		{
+			name:        "zero price",
+			variant:     &ProductVariant{SKU: "SHIRT-M", Attributes: map[string]string{"size": "M"}},
+			expectedErr: "price must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.variant.ValidateForCreation()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedErr, err.Error())
+		})
+	}
+}
+
+func TestProduct_AddVariant_RejectsDuplicates(t *testing.T) {
+	product := newShirt()
+
+	err := product.AddVariant(&ProductVariant{
+		ID: uuid.New(), SKU: "SHIRT-M-RED", Attributes: map[string]string{"size": "M", "color": "red"}, Price: 1500,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, product.ID, product.Variants[0].ProductID)
+
+	err = product.AddVariant(&ProductVariant{
+		ID: uuid.New(), SKU: "SHIRT-M-RED-2", Attributes: map[string]string{"color": "red", "size": "M"}, Price: 1500,
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "variant with the same sku or attributes already exists", err.Error())
+
+	err = product.AddVariant(&ProductVariant{
+		ID: uuid.New(), SKU: "SHIRT-M-RED", Attributes: map[string]string{"size": "L"}, Price: 1500,
+	})
+	assert.Error(t, err)
+}
+
+func TestProduct_ValidateForCreation_WithVariants(t *testing.T) {
+	product := newShirt()
+	product.Variants = []ProductVariant{
+		{SKU: "SHIRT-M", Attributes: map[string]string{"size": "M"}, Price: 1500},
+		{SKU: "SHIRT-L", Attributes: map[string]string{"size": "L"}, Price: 1700},
+	}
+
+	// Цена самого товара не обязательна, если цены заданы у вариантов
+	assert.NoError(t, product.ValidateForCreation())
+
+	product.Variants[1].Attributes = map[string]string{"size": "M"}
+	assert.Error(t, product.ValidateForCreation())
+}
+
+func TestOrder_AddItem_ProductWithVariants(t *testing.T) {
+	product := newShirt()
+	product.Variants = []ProductVariant{{ID: uuid.New(), SKU: "SHIRT-M", Attributes: map[string]string{"size": "M"}, Price: 1500}}
+
+	order := NewOrder(uuid.New())
+	err := order.AddItem(product, 1)
+
+	assert.Error(t, err)
+	assert.Equal(t, "product has variants, variant_id is required", err.Error())
+}
+
+func TestOrder_AddVariantItem_Snapshot(t *testing.T) {
+	product := newShirt()
+	variant := &ProductVariant{
+		ID:         uuid.New(),
+		ProductID:  product.ID,
+		SKU:        "SHIRT-M-RED",
+		Attributes: map[string]string{"size": "M", "color": "red"},
+		Price:      1500,
+		Quantity:   3,
+	}
+
+	order := NewOrder(uuid.New())
+	err := order.AddVariantItem(product, variant, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3000), order.Total)
+
+	item := order.Items[0]
+	assert.Equal(t, variant.ID, *item.VariantID)
+	assert.Equal(t, "SHIRT-M-RED", item.ProductSnapshot.SKU)
+	assert.Equal(t, map[string]string{"material": "cotton", "size": "M", "color": "red"}, item.ProductSnapshot.Attributes)
+	assert.Equal(t, "Футболка (color: red, material: cotton, size: M)", item.ProductSnapshot.DisplayName())
+}
+
+func TestOrder_AddVariantItem_InsufficientStock(t *testing.T) {
+	product := newShirt()
+	variant := &ProductVariant{ID: uuid.New(), ProductID: product.ID, SKU: "SHIRT-M", Price: 1500, Quantity: 1}
+
+	order := NewOrder(uuid.New())
+	err := order.AddVariantItem(product, variant, 2)
+
+	assert.Error(t, err)
+	assert.Equal(t, "insufficient product quantity", err.Error())
+}
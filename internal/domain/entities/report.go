@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReportPeriod string
+
+const (
+	ReportPeriodDay   ReportPeriod = "day"
+	ReportPeriodWeek  ReportPeriod = "week"
+	ReportPeriodMonth ReportPeriod = "month"
+)
+
+func (p ReportPeriod) IsValid() bool {
+	switch p {
+	case ReportPeriodDay, ReportPeriodWeek, ReportPeriodMonth:
+		return true
+	}
+	return false
+}
+
+// RevenueOrderStatuses - статусы заказов, которые учитываются в выручке
+var RevenueOrderStatuses = []OrderStatus{OrderStatusConfirmed, OrderStatusCompleted}
+
+// SalesPoint - выручка за один период (день, неделю или месяц)
+type SalesPoint struct {
+	Period    time.Time `json:"period"`
+	Orders    int       `json:"orders"`
+	ItemsSold int       `json:"items_sold"`
+	Revenue   int64     `json:"revenue"`
+}
+
+type SalesSummary struct {
+	TotalOrders     int   `json:"total_orders"`
+	RevenueOrders   int   `json:"revenue_orders"`
+	CancelledOrders int   `json:"cancelled_orders"`
+	Revenue         int64 `json:"revenue"`
+}
+
+// AverageOrderValue считается только по заказам, попавшим в выручку
+func (s *SalesSummary) AverageOrderValue() int64 {
+	if s.RevenueOrders == 0 {
+		return 0
+	}
+	return s.Revenue / int64(s.RevenueOrders)
+}
+
+// CancellationRate - доля отмененных заказов от всех созданных за период
+func (s *SalesSummary) CancellationRate() float64 {
+	if s.TotalOrders == 0 {
+		return 0
+	}
+	return float64(s.CancelledOrders) / float64(s.TotalOrders)
+}
+
+type ProductSales struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	Description string    `json:"description"`
+	Quantity    int       `json:"quantity"`
+	Revenue     int64     `json:"revenue"`
+}
+
+type TagSales struct {
+	Tag      string `json:"tag"`
+	Quantity int    `json:"quantity"`
+	Revenue  int64  `json:"revenue"`
+}
+
+// CategorySales - продажи по категории из снимка позиции заказа (без учета вложенности)
+type CategorySales struct {
+	Slug     string `json:"slug"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	Revenue  int64  `json:"revenue"`
+}
+
+// LowStockProduct - товар или вариант товара с низким остатком
+type LowStockProduct struct {
+	ProductID   uuid.UUID  `json:"product_id"`
+	VariantID   *uuid.UUID `json:"variant_id,omitempty"`
+	SKU         string     `json:"sku"`
+	Description string     `json:"description"`
+	Quantity    int        `json:"quantity"`
+}
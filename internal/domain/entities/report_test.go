@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportPeriod_IsValid(t *testing.T) {
+	assert.True(t, ReportPeriodDay.IsValid())
+	assert.True(t, ReportPeriodWeek.IsValid())
+	assert.True(t, ReportPeriodMonth.IsValid())
+	assert.False(t, ReportPeriod("year").IsValid())
+}
+
+func TestSalesSummary_Metrics(t *testing.T) {
+	summary := &SalesSummary{
+		TotalOrders:     10,
+		RevenueOrders:   4,
+		CancelledOrders: 2,
+		Revenue:         10000,
+	}
+
+	assert.Equal(t, int64(2500), summary.AverageOrderValue())
+	assert.InDelta(t, 0.2, summary.CancellationRate(), 0.0001)
+}
+
+func TestSalesSummary_EmptyPeriod(t *testing.T) {
+	summary := &SalesSummary{}
+
+	assert.Equal(t, int64(0), summary.AverageOrderValue())
+	assert.Equal(t, float64(0), summary.CancellationRate())
+}
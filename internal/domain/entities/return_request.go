@@ -0,0 +1,184 @@
+package entities
+
+import (
+	"time"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	"github.com/google/uuid"
+)
+
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+	ReturnStatusReceived  ReturnStatus = "received"
+)
+
+type ReturnReason string
+
+const (
+	ReturnReasonDamaged        ReturnReason = "damaged"
+	ReturnReasonWrongItem      ReturnReason = "wrong_item"
+	ReturnReasonNotAsDescribed ReturnReason = "not_as_described"
+	ReturnReasonNoLongerNeeded ReturnReason = "no_longer_needed"
+	ReturnReasonOther          ReturnReason = "other"
+)
+
+func (r ReturnReason) IsValid() bool {
+	switch r {
+	case ReturnReasonDamaged, ReturnReasonWrongItem, ReturnReasonNotAsDescribed,
+		ReturnReasonNoLongerNeeded, ReturnReasonOther:
+		return true
+	}
+	return false
+}
+
+type ReturnRequest struct {
+	ID            uuid.UUID    `json:"id"`
+	OrderID       uuid.UUID    `json:"order_id"`
+	UserID        uuid.UUID    `json:"user_id"`
+	Status        ReturnStatus `json:"status"`
+	Items         []ReturnItem `json:"items"`
+	RefundAmount  int64        `json:"refund_amount"`
+	ReviewComment string       `json:"review_comment"`
+	ReviewedAt    *time.Time   `json:"reviewed_at,omitempty"`
+	ReceivedAt    *time.Time   `json:"received_at,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+type ReturnItem struct {
+	ID              uuid.UUID    `json:"id"`
+	ReturnRequestID uuid.UUID    `json:"return_request_id"`
+	OrderItemID     uuid.UUID    `json:"order_item_id"`
+	ProductID       uuid.UUID    `json:"product_id"`
+	VariantID       *uuid.UUID   `json:"variant_id,omitempty"`
+	Quantity        int          `json:"quantity"`
+	Reason          ReturnReason `json:"reason"`
+	Comment         string       `json:"comment"`
+	PricePerItem    int64        `json:"price_per_item"`
+	RefundAmount    int64        `json:"refund_amount"`
+}
+
+// NewReturnRequest открывает возврат по доставленному заказу.
+// Сумма возврата считается по цене из позиции заказа, а не по текущей цене товара.
+func NewReturnRequest(order *Order, userID uuid.UUID, items []ReturnItem, existing []*ReturnRequest) (*ReturnRequest, error) {
+	if order.UserID != userID {
+		return nil, domainErrors.ErrReturnNotOwner
+	}
+
+	if order.Status != OrderStatusCompleted {
+		return nil, domainErrors.ErrOnlyCompletedCanReturn
+	}
+
+	if len(items) == 0 {
+		return nil, domainErrors.ErrReturnMustHaveItems
+	}
+
+	returned := ReturnedQuantities(existing)
+
+	request := &ReturnRequest{
+		ID:        uuid.New(),
+		OrderID:   order.ID,
+		UserID:    userID,
+		Status:    ReturnStatusRequested,
+		Items:     make([]ReturnItem, 0, len(items)),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	for _, item := range items {
+		orderItem := order.findItem(item.OrderItemID)
+		if orderItem == nil {
+			return nil, domainErrors.ErrReturnItemNotInOrder
+		}
+		if item.Quantity <= 0 {
+			return nil, domainErrors.ErrQuantityInvalid
+		}
+		if !item.Reason.IsValid() {
+			return nil, domainErrors.ErrReturnReasonInvalid
+		}
+		if returned[item.OrderItemID]+item.Quantity > orderItem.Quantity {
+			return nil, domainErrors.ErrReturnQuantityExceeded
+		}
+		returned[item.OrderItemID] += item.Quantity
+
+		returnItem := ReturnItem{
+			ID:              uuid.New(),
+			ReturnRequestID: request.ID,
+			OrderItemID:     orderItem.ID,
+			ProductID:       orderItem.ProductID,
+			VariantID:       orderItem.VariantID,
+			Quantity:        item.Quantity,
+			Reason:          item.Reason,
+			Comment:         item.Comment,
+			PricePerItem:    orderItem.PricePerItem,
+			RefundAmount:    orderItem.PricePerItem * int64(item.Quantity),
+		}
+
+		request.Items = append(request.Items, returnItem)
+		request.RefundAmount += returnItem.RefundAmount
+	}
+
+	return request, nil
+}
+
+func (r *ReturnRequest) Approve(comment string) error {
+	if r.Status != ReturnStatusRequested {
+		return domainErrors.ErrOnlyRequestedCanReview
+	}
+
+	now := time.Now()
+	r.Status = ReturnStatusApproved
+	r.ReviewComment = comment
+	r.ReviewedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+func (r *ReturnRequest) Reject(comment string) error {
+	if r.Status != ReturnStatusRequested {
+		return domainErrors.ErrOnlyRequestedCanReview
+	}
+
+	if comment == "" {
+		return domainErrors.ErrRejectionCommentRequired
+	}
+
+	now := time.Now()
+	r.Status = ReturnStatusRejected
+	r.ReviewComment = comment
+	r.ReviewedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// MarkReceived фиксирует получение товара на складе
+func (r *ReturnRequest) MarkReceived() error {
+	if r.Status != ReturnStatusApproved {
+		return domainErrors.ErrOnlyApprovedCanReceive
+	}
+
+	now := time.Now()
+	r.Status = ReturnStatusReceived
+	r.ReceivedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// ReturnedQuantities считает количество единиц в действующих возвратах по позициям заказа
+func ReturnedQuantities(requests []*ReturnRequest) map[uuid.UUID]int {
+	result := make(map[uuid.UUID]int)
+	for _, request := range requests {
+		if request.Status == ReturnStatusRejected {
+			continue
+		}
+		for _, item := range request.Items {
+			result[item.OrderItemID] += item.Quantity
+		}
+	}
+	return result
+}
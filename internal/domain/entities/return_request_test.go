@@ -0,0 +1,158 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCompletedOrder(t *testing.T, quantity int, price int64) *Order {
+	order := NewOrder(uuid.New())
+	product := &Product{
+		ID:       uuid.New(),
+		Quantity: 10,
+		Price:    price,
+	}
+
+	assert.NoError(t, order.AddItem(product, quantity))
+	assert.NoError(t, order.Confirm())
+	assert.NoError(t, order.Complete())
+
+	return order
+}
+
+func TestNewReturnRequest(t *testing.T) {
+	order := newCompletedOrder(t, 3, 1500)
+	itemID := order.Items[0].ID
+
+	request, err := NewReturnRequest(order, order.UserID, []ReturnItem{
+		{OrderItemID: itemID, Quantity: 2, Reason: ReturnReasonDamaged},
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ReturnStatusRequested, request.Status)
+	assert.Equal(t, order.Items[0].ProductID, request.Items[0].ProductID)
+	assert.Equal(t, int64(1500), request.Items[0].PricePerItem)
+	assert.Equal(t, int64(3000), request.RefundAmount)
+}
+
+func TestNewReturnRequest_Validation(t *testing.T) {
+	order := newCompletedOrder(t, 3, 1500)
+	itemID := order.Items[0].ID
+
+	tests := []struct {
+		name     string
+		userID   uuid.UUID
+		items    []ReturnItem
+		existing []*ReturnRequest
+		errorMsg string
+	}{
+		{
+			name:     "another user",
+			userID:   uuid.New(),
+			items:    []ReturnItem{{OrderItemID: itemID, Quantity: 1, Reason: ReturnReasonOther}},
+			errorMsg: "order belongs to another user",
+		},
+		{
+			name:     "no items",
+			userID:   order.UserID,
+			errorMsg: "return must contain at least one item",
+		},
+		{
+			name:     "unknown item",
+			userID:   order.UserID,
+			items:    []ReturnItem{{OrderItemID: uuid.New(), Quantity: 1, Reason: ReturnReasonOther}},
+			errorMsg: "return item does not belong to the order",
+		},
+		{
+			name:     "unknown reason",
+			userID:   order.UserID,
+			items:    []ReturnItem{{OrderItemID: itemID, Quantity: 1, Reason: "bored"}},
+			errorMsg: "unknown return reason",
+		},
+		{
+			name:     "more than purchased",
+			userID:   order.UserID,
+			items:    []ReturnItem{{OrderItemID: itemID, Quantity: 4, Reason: ReturnReasonOther}},
+			errorMsg: "return quantity exceeds purchased quantity",
+		},
+		{
+			name:   "already returned",
+			userID: order.UserID,
+			items:  []ReturnItem{{OrderItemID: itemID, Quantity: 2, Reason: ReturnReasonOther}},
+			existing: []*ReturnRequest{{
+				Status: ReturnStatusApproved,
+				Items:  []ReturnItem{{OrderItemID: itemID, Quantity: 2}},
+			}},
+			errorMsg: "return quantity exceeds purchased quantity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReturnRequest(order, tt.userID, tt.items, tt.existing)
+			assert.Error(t, err)
+			assert.Equal(t, tt.errorMsg, err.Error())
+		})
+	}
+}
+
+func TestNewReturnRequest_RejectedReturnsIgnored(t *testing.T) {
+	order := newCompletedOrder(t, 1, 1000)
+	itemID := order.Items[0].ID
+
+	rejected := &ReturnRequest{
+		Status: ReturnStatusRejected,
+		Items:  []ReturnItem{{OrderItemID: itemID, Quantity: 1}},
+	}
+
+	_, err := NewReturnRequest(order, order.UserID, []ReturnItem{
+		{OrderItemID: itemID, Quantity: 1, Reason: ReturnReasonWrongItem},
+	}, []*ReturnRequest{rejected})
+
+	assert.NoError(t, err)
+}
+
+func TestNewReturnRequest_NotCompletedOrder(t *testing.T) {
+	order := NewOrder(uuid.New())
+
+	_, err := NewReturnRequest(order, order.UserID, nil, nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, "only completed orders can be returned", err.Error())
+}
+
+func TestReturnRequest_Workflow(t *testing.T) {
+	request := &ReturnRequest{Status: ReturnStatusRequested}
+
+	err := request.MarkReceived()
+	assert.Error(t, err)
+	assert.Equal(t, "only approved returns can be received", err.Error())
+
+	err = request.Approve("ok")
+	assert.NoError(t, err)
+	assert.Equal(t, ReturnStatusApproved, request.Status)
+	assert.NotNil(t, request.ReviewedAt)
+
+	err = request.Reject("changed my mind")
+	assert.Error(t, err)
+	assert.Equal(t, "only requested returns can be approved or rejected", err.Error())
+
+	err = request.MarkReceived()
+	assert.NoError(t, err)
+	assert.Equal(t, ReturnStatusReceived, request.Status)
+	assert.NotNil(t, request.ReceivedAt)
+}
+
+func TestReturnRequest_Reject_CommentRequired(t *testing.T) {
+	request := &ReturnRequest{Status: ReturnStatusRequested}
+
+	err := request.Reject("")
+	assert.Error(t, err)
+	assert.Equal(t, "rejection comment is required", err.Error())
+
+	err = request.Reject("used item")
+	assert.NoError(t, err)
+	assert.Equal(t, ReturnStatusRejected, request.Status)
+}
@@ -0,0 +1,194 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	"github.com/google/uuid"
+)
+
+type ShipmentStatus string
+
+const (
+	ShipmentStatusCreated        ShipmentStatus = "created"
+	ShipmentStatusInTransit      ShipmentStatus = "in_transit"
+	ShipmentStatusOutForDelivery ShipmentStatus = "out_for_delivery"
+	ShipmentStatusDelivered      ShipmentStatus = "delivered"
+	ShipmentStatusException      ShipmentStatus = "exception"
+)
+
+// IsValid проверяет, что статус входит в список известных статусов перевозчика
+func (s ShipmentStatus) IsValid() bool {
+	switch s {
+	case ShipmentStatusCreated, ShipmentStatusInTransit, ShipmentStatusOutForDelivery,
+		ShipmentStatusDelivered, ShipmentStatusException:
+		return true
+	}
+	return false
+}
+
+type Shipment struct {
+	ID             uuid.UUID       `json:"id"`
+	OrderID        uuid.UUID       `json:"order_id"`
+	Carrier        string          `json:"carrier"`
+	TrackingNumber string          `json:"tracking_number"`
+	Status         ShipmentStatus  `json:"status"`
+	Items          []ShipmentItem  `json:"items"`
+	Events         []ShipmentEvent `json:"events"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+type ShipmentItem struct {
+	ID          uuid.UUID `json:"id"`
+	ShipmentID  uuid.UUID `json:"shipment_id"`
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	Quantity    int       `json:"quantity"`
+}
+
+type ShipmentEvent struct {
+	ID          uuid.UUID      `json:"id"`
+	ShipmentID  uuid.UUID      `json:"shipment_id"`
+	Status      ShipmentStatus `json:"status"`
+	Location    string         `json:"location"`
+	Description string         `json:"description"`
+	OccurredAt  time.Time      `json:"occurred_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// NewShipment создает отправку подтвержденного заказа.
+// Пустой список позиций означает отправку всех еще не отгруженных товаров.
+func NewShipment(order *Order, carrier string, items []ShipmentItem, existing []*Shipment) (*Shipment, error) {
+	if order.Status != OrderStatusConfirmed {
+		return nil, domainErrors.ErrOnlyConfirmedCanShip
+	}
+
+	shipped := ShippedQuantities(existing, false)
+
+	shipment := &Shipment{
+		ID:        uuid.New(),
+		OrderID:   order.ID,
+		Carrier:   carrier,
+		Status:    ShipmentStatusCreated,
+		Items:     make([]ShipmentItem, 0, len(order.Items)),
+		Events:    make([]ShipmentEvent, 0),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if len(items) == 0 {
+		for _, orderItem := range order.Items {
+			remaining := orderItem.AllocatedQuantity() - shipped[orderItem.ID]
+			if remaining > 0 {
+				items = append(items, ShipmentItem{OrderItemID: orderItem.ID, Quantity: remaining})
+			}
+		}
+	}
+
+	if len(items) == 0 {
+		if order.HasBackorders() {
+			return nil, domainErrors.ErrOrderAwaitingStock
+		}
+		return nil, domainErrors.ErrOrderAlreadyShipped
+	}
+
+	for _, item := range items {
+		orderItem := order.findItem(item.OrderItemID)
+		if orderItem == nil {
+			return nil, domainErrors.ErrShipmentItemNotInOrder
+		}
+		if item.Quantity <= 0 {
+			return nil, domainErrors.ErrQuantityInvalid
+		}
+		if shipped[item.OrderItemID]+item.Quantity > orderItem.Quantity {
+			return nil, domainErrors.ErrShipmentQuantityExceeded
+		}
+		// Недостающие единицы еще не поступили на склад
+		if shipped[item.OrderItemID]+item.Quantity > orderItem.AllocatedQuantity() {
+			return nil, domainErrors.ErrShipmentAwaitingStock
+		}
+		shipped[item.OrderItemID] += item.Quantity
+
+		shipment.Items = append(shipment.Items, ShipmentItem{
+			ID:          uuid.New(),
+			ShipmentID:  shipment.ID,
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+		})
+	}
+
+	return shipment, nil
+}
+
+// AssignTrackingNumber сохраняет трек-номер, выданный перевозчиком
+func (s *Shipment) AssignTrackingNumber(trackingNumber string) {
+	s.TrackingNumber = trackingNumber
+	s.UpdatedAt = time.Now()
+}
+
+// ApplyEvent добавляет событие трекинга и пересчитывает статус отправки.
+// Возвращает false, если такое событие уже было получено ранее.
+func (s *Shipment) ApplyEvent(status ShipmentStatus, location, description string, occurredAt time.Time) (bool, error) {
+	if !status.IsValid() {
+		return false, domainErrors.ErrShipmentStatusInvalid
+	}
+
+	for _, event := range s.Events {
+		if event.Status == status && event.OccurredAt.Equal(occurredAt) {
+			return false, nil
+		}
+	}
+
+	if s.Status == ShipmentStatusDelivered {
+		return false, domainErrors.ErrShipmentAlreadyDelivered
+	}
+
+	s.Events = append(s.Events, ShipmentEvent{
+		ID:          uuid.New(),
+		ShipmentID:  s.ID,
+		Status:      status,
+		Location:    location,
+		Description: description,
+		OccurredAt:  occurredAt,
+		CreatedAt:   time.Now(),
+	})
+
+	// События от перевозчика могут приходить не по порядку,
+	// поэтому статус определяется самым поздним событием
+	sort.SliceStable(s.Events, func(i, j int) bool {
+		return s.Events[i].OccurredAt.Before(s.Events[j].OccurredAt)
+	})
+
+	latest := s.Events[len(s.Events)-1]
+	s.Status = latest.Status
+	if status == ShipmentStatusDelivered {
+		// Доставка - финальный статус, даже если промежуточные события пришли позже
+		s.Status = ShipmentStatusDelivered
+		deliveredAt := occurredAt
+		s.DeliveredAt = &deliveredAt
+	}
+	s.UpdatedAt = time.Now()
+
+	return true, nil
+}
+
+func (s *Shipment) IsDelivered() bool {
+	return s.Status == ShipmentStatusDelivered
+}
+
+// ShippedQuantities считает количество отгруженных единиц по позициям заказа
+func ShippedQuantities(shipments []*Shipment, onlyDelivered bool) map[uuid.UUID]int {
+	result := make(map[uuid.UUID]int)
+	for _, shipment := range shipments {
+		if onlyDelivered && !shipment.IsDelivered() {
+			continue
+		}
+		for _, item := range shipment.Items {
+			result[item.OrderItemID] += item.Quantity
+		}
+	}
+	return result
+}
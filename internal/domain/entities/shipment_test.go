@@ -0,0 +1,139 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newConfirmedOrder(t *testing.T, quantity int) *Order {
+	order := NewOrder(uuid.New())
+	product := &Product{
+		ID:       uuid.New(),
+		Quantity: 10,
+		Price:    1000,
+	}
+
+	assert.NoError(t, order.AddItem(product, quantity))
+	assert.NoError(t, order.Confirm())
+
+	return order
+}
+
+func TestNewShipment_AllItems(t *testing.T) {
+	order := newConfirmedOrder(t, 3)
+
+	shipment, err := NewShipment(order, "stub", nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, order.ID, shipment.OrderID)
+	assert.Equal(t, ShipmentStatusCreated, shipment.Status)
+	assert.Len(t, shipment.Items, 1)
+	assert.Equal(t, order.Items[0].ID, shipment.Items[0].OrderItemID)
+	assert.Equal(t, 3, shipment.Items[0].Quantity)
+}
+
+func TestNewShipment_PendingOrder(t *testing.T) {
+	order := NewOrder(uuid.New())
+
+	_, err := NewShipment(order, "stub", nil, nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, "only confirmed orders can be shipped", err.Error())
+}
+
+func TestNewShipment_PartialShipments(t *testing.T) {
+	order := newConfirmedOrder(t, 3)
+	itemID := order.Items[0].ID
+
+	first, err := NewShipment(order, "stub", []ShipmentItem{{OrderItemID: itemID, Quantity: 2}}, nil)
+	assert.NoError(t, err)
+
+	// Превышение неотгруженного остатка
+	_, err = NewShipment(order, "stub", []ShipmentItem{{OrderItemID: itemID, Quantity: 2}}, []*Shipment{first})
+	assert.Error(t, err)
+	assert.Equal(t, "shipment quantity exceeds unshipped quantity", err.Error())
+
+	// Без позиций отгружается остаток
+	second, err := NewShipment(order, "stub", nil, []*Shipment{first})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, second.Items[0].Quantity)
+
+	_, err = NewShipment(order, "stub", nil, []*Shipment{first, second})
+	assert.Error(t, err)
+	assert.Equal(t, "all order items are already shipped", err.Error())
+}
+
+func TestNewShipment_UnknownItem(t *testing.T) {
+	order := newConfirmedOrder(t, 1)
+
+	_, err := NewShipment(order, "stub", []ShipmentItem{{OrderItemID: uuid.New(), Quantity: 1}}, nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, "shipment item does not belong to the order", err.Error())
+}
+
+func TestShipment_ApplyEvent(t *testing.T) {
+	order := newConfirmedOrder(t, 1)
+	shipment, err := NewShipment(order, "stub", nil, nil)
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	applied, err := shipment.ApplyEvent(ShipmentStatusOutForDelivery, "Moscow", "", now)
+	assert.NoError(t, err)
+	assert.True(t, applied)
+	assert.Equal(t, ShipmentStatusOutForDelivery, shipment.Status)
+
+	// Событие из прошлого не откатывает статус
+	applied, err = shipment.ApplyEvent(ShipmentStatusInTransit, "Tver", "", now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, applied)
+	assert.Equal(t, ShipmentStatusOutForDelivery, shipment.Status)
+	assert.Equal(t, ShipmentStatusInTransit, shipment.Events[0].Status)
+
+	// Дубликат игнорируется
+	applied, err = shipment.ApplyEvent(ShipmentStatusOutForDelivery, "Moscow", "", now)
+	assert.NoError(t, err)
+	assert.False(t, applied)
+	assert.Len(t, shipment.Events, 2)
+
+	applied, err = shipment.ApplyEvent(ShipmentStatusDelivered, "Moscow", "", now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, applied)
+	assert.True(t, shipment.IsDelivered())
+	assert.NotNil(t, shipment.DeliveredAt)
+
+	_, err = shipment.ApplyEvent(ShipmentStatusException, "Moscow", "", now.Add(2*time.Hour))
+	assert.Error(t, err)
+	assert.Equal(t, "shipment is already delivered", err.Error())
+}
+
+func TestShipment_ApplyEvent_UnknownStatus(t *testing.T) {
+	shipment := &Shipment{ID: uuid.New(), Status: ShipmentStatusCreated}
+
+	_, err := shipment.ApplyEvent(ShipmentStatus("lost_in_space"), "", "", time.Now())
+
+	assert.Error(t, err)
+	assert.Equal(t, "unknown shipment status", err.Error())
+}
+
+func TestNewShipment_Backordered(t *testing.T) {
+	order := newConfirmedOrder(t, 3)
+	order.Items[0].Backorder(2, nil)
+
+	shipment, err := NewShipment(order, "stub", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, shipment.Items[0].Quantity)
+
+	// Отгружено все, что есть на складе, остальное ждет поступления
+	_, err = NewShipment(order, "stub", nil, []*Shipment{shipment})
+	assert.Error(t, err)
+	assert.Equal(t, "remaining order items are waiting for stock", err.Error())
+
+	_, err = NewShipment(order, "stub", []ShipmentItem{{OrderItemID: order.Items[0].ID, Quantity: 2}}, []*Shipment{shipment})
+	assert.Error(t, err)
+	assert.Equal(t, "shipment quantity exceeds stock allocated to the order", err.Error())
+}
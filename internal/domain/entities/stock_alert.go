@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxStockNotificationAttempts - после стольких неудачных отправок уведомление больше не повторяется
+const MaxStockNotificationAttempts = 5
+
+// StockChange - изменение остатка товара или варианта в рамках одной операции
+type StockChange struct {
+	ProductID uuid.UUID
+	VariantID *uuid.UUID
+	SKU       string
+	Before    int
+	After     int
+}
+
+// IsBackInStock сообщает, что товар, которого не было в наличии, снова можно купить
+func (c StockChange) IsBackInStock() bool {
+	return c.Before <= 0 && c.After > 0
+}
+
+// CrossedThreshold сообщает, что остаток опустился до порога; нулевой порог отключает проверку
+func (c StockChange) CrossedThreshold(threshold int) bool {
+	return threshold > 0 && c.Before > threshold && c.After <= threshold
+}
+
+// StockSubscription - подписка пользователя на поступление товара. Подписка на товар без варианта
+// срабатывает при поступлении любого его варианта. После уведомления подписка закрывается.
+type StockSubscription struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	ProductID  uuid.UUID  `json:"product_id"`
+	VariantID  *uuid.UUID `json:"variant_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	NotifiedAt *time.Time `json:"notified_at,omitempty"`
+}
+
+func NewStockSubscription(userID, productID uuid.UUID, variantID *uuid.UUID) *StockSubscription {
+	return &StockSubscription{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ProductID: productID,
+		VariantID: variantID,
+		CreatedAt: time.Now(),
+	}
+}
+
+type StockNotificationKind string
+
+const (
+	StockNotificationBackInStock StockNotificationKind = "back_in_stock"
+	StockNotificationLowStock    StockNotificationKind = "low_stock"
+)
+
+// StockNotification - уведомление об остатке, ожидающее отправки через Notifier.
+// Записывается в той же транзакции, что и изменение остатка, поэтому
+// откаченные операции уведомлений не порождают. UserID заполняется только для back_in_stock.
+type StockNotification struct {
+	ID          uuid.UUID             `json:"id"`
+	Kind        StockNotificationKind `json:"kind"`
+	UserID      *uuid.UUID            `json:"user_id,omitempty"`
+	ProductID   uuid.UUID             `json:"product_id"`
+	VariantID   *uuid.UUID            `json:"variant_id,omitempty"`
+	SKU         string                `json:"sku,omitempty"`
+	ProductName string                `json:"product_name"`
+	Quantity    int                   `json:"quantity"`
+	Threshold   int                   `json:"threshold,omitempty"`
+	Attempts    int                   `json:"attempts"`
+	LastError   string                `json:"last_error,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	SentAt      *time.Time            `json:"sent_at,omitempty"`
+}
+
+// NewBackInStockNotification готовит уведомление подписчику о поступлении товара
+func NewBackInStockNotification(product *Product, change StockChange, subscription *StockSubscription) *StockNotification {
+	notification := newStockNotification(StockNotificationBackInStock, product, change)
+	userID := subscription.UserID
+	notification.UserID = &userID
+
+	return notification
+}
+
+// NewLowStockNotification готовит уведомление сотрудникам о низком остатке
+func NewLowStockNotification(product *Product, change StockChange) *StockNotification {
+	notification := newStockNotification(StockNotificationLowStock, product, change)
+	notification.Threshold = product.LowStockThreshold
+
+	return notification
+}
+
+func newStockNotification(kind StockNotificationKind, product *Product, change StockChange) *StockNotification {
+	name := product.Name
+	if name == "" {
+		name = product.Description
+	}
+
+	return &StockNotification{
+		ID:          uuid.New(),
+		Kind:        kind,
+		ProductID:   change.ProductID,
+		VariantID:   change.VariantID,
+		SKU:         change.SKU,
+		ProductName: name,
+		Quantity:    change.After,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func (n *StockNotification) MarkSent(at time.Time) {
+	n.Attempts++
+	n.LastError = ""
+	n.SentAt = &at
+}
+
+// MarkFailed фиксирует неудачную отправку; уведомление повторится, пока не исчерпаны попытки
+func (n *StockNotification) MarkFailed(err error) {
+	n.Attempts++
+	n.LastError = err.Error()
+}
@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStockChange_Triggers(t *testing.T) {
+	tests := []struct {
+		name        string
+		before      int
+		after       int
+		threshold   int
+		backInStock bool
+		crossed     bool
+	}{
+		{name: "restock from zero", before: 0, after: 5, threshold: 3, backInStock: true},
+		{name: "restock of backordered product", before: -2, after: 1, backInStock: true},
+		{name: "sale above threshold", before: 10, after: 8, threshold: 3},
+		{name: "sale crosses threshold", before: 5, after: 3, threshold: 3, crossed: true},
+		{name: "sale below threshold does not repeat", before: 3, after: 1, threshold: 3},
+		{name: "threshold disabled", before: 5, after: 0, threshold: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := StockChange{ProductID: uuid.New(), Before: tt.before, After: tt.after}
+
+			assert.Equal(t, tt.backInStock, change.IsBackInStock())
+			assert.Equal(t, tt.crossed, change.CrossedThreshold(tt.threshold))
+		})
+	}
+}
+
+func TestProduct_AvailableQuantity(t *testing.T) {
+	product := &Product{ID: uuid.New(), Description: "Shirt", Quantity: 7}
+
+	available, err := product.AvailableQuantity(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, available)
+
+	small := ProductVariant{ID: uuid.New(), SKU: "SHIRT-S", Quantity: 0}
+	large := ProductVariant{ID: uuid.New(), SKU: "SHIRT-L", Quantity: 4}
+	product.Variants = []ProductVariant{small, large}
+
+	// У товара с вариантами остаток складывается из остатков вариантов
+	available, err = product.AvailableQuantity(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, available)
+
+	available, err = product.AvailableQuantity(&small.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, available)
+
+	unknown := uuid.New()
+	_, err = product.AvailableQuantity(&unknown)
+	assert.Error(t, err)
+}
+
+func TestProduct_SetLowStockThreshold(t *testing.T) {
+	product := &Product{ID: uuid.New(), Description: "Shirt", Quantity: 7}
+
+	err := product.SetLowStockThreshold(-1)
+	assert.Error(t, err)
+	assert.Equal(t, "low stock threshold cannot be negative", err.Error())
+
+	assert.NoError(t, product.SetLowStockThreshold(3))
+	assert.Equal(t, 3, product.LowStockThreshold)
+}
+
+func TestStockNotification_Lifecycle(t *testing.T) {
+	product := &Product{ID: uuid.New(), Description: "Shirt", LowStockThreshold: 3}
+	subscription := NewStockSubscription(uuid.New(), product.ID, nil)
+	change := StockChange{ProductID: product.ID, Before: 0, After: 5}
+
+	notification := NewBackInStockNotification(product, change, subscription)
+	assert.Equal(t, StockNotificationBackInStock, notification.Kind)
+	assert.Equal(t, subscription.UserID, *notification.UserID)
+	assert.Equal(t, "Shirt", notification.ProductName)
+	assert.Equal(t, 5, notification.Quantity)
+
+	notification.MarkFailed(errors.New("smtp unavailable"))
+	assert.Equal(t, 1, notification.Attempts)
+	assert.Equal(t, "smtp unavailable", notification.LastError)
+	assert.Nil(t, notification.SentAt)
+
+	now := time.Now()
+	notification.MarkSent(now)
+	assert.Equal(t, 2, notification.Attempts)
+	assert.Empty(t, notification.LastError)
+	assert.Equal(t, now, *notification.SentAt)
+
+	lowStock := NewLowStockNotification(product, StockChange{ProductID: product.ID, Before: 4, After: 2})
+	assert.Equal(t, StockNotificationLowStock, lowStock.Kind)
+	assert.Nil(t, lowStock.UserID)
+	assert.Equal(t, 3, lowStock.Threshold)
+}
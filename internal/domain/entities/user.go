@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -11,10 +12,14 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// User - покупатель. Email необязателен: без него письма о заказах не отправляются.
+// Language - язык писем, по умолчанию constants.DefaultLanguage.
 type User struct {
 	ID        uuid.UUID `json:"id"`
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
+	Email     string    `json:"email,omitempty"`
+	Language  string    `json:"language"`
 	Age       int       `json:"age"`
 	IsMarried bool      `json:"is_married"`
 	Password  string    `json:"-"`
@@ -43,9 +48,22 @@ func (u *User) ValidateForCreation(plainPassword string) error {
 		return domainErrors.ErrPasswordTooShort
 	}
 
+	u.Email = strings.TrimSpace(u.Email)
+	if u.Language == "" {
+		u.Language = constants.DefaultLanguage
+	}
+	if !slices.Contains(constants.SupportedLanguages, u.Language) {
+		return domainErrors.ErrLanguageUnsupported
+	}
+
 	return nil
 }
 
+// CanReceiveEmail сообщает, указал ли пользователь адрес для писем о заказах
+func (u *User) CanReceiveEmail() bool {
+	return u.Email != ""
+}
+
 func (u *User) SetPassword(plainPassword string) error {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(plainPassword), constants.BcryptCost)
 	if err != nil {
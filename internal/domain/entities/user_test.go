@@ -69,6 +69,18 @@ func TestUser_ValidateForCreation(t *testing.T) {
 			expectError: true,
 			errorMsg:    "password must be at least 8 characters long",
 		},
+		{
+			name: "unsupported language",
+			user: User{
+				FirstName: "John",
+				LastName:  "Doe",
+				Age:       25,
+				Language:  "de",
+			},
+			password:    "password123",
+			expectError: true,
+			errorMsg:    "language must be ru or en",
+		},
 	}
 
 	for _, tt := range tests {
@@ -85,6 +97,16 @@ func TestUser_ValidateForCreation(t *testing.T) {
 	}
 }
 
+func TestUser_ValidateForCreation_Defaults(t *testing.T) {
+	user := User{FirstName: "John", LastName: "Doe", Age: 25, Email: "  john@example.com "}
+
+	assert.NoError(t, user.ValidateForCreation("password123"))
+	assert.Equal(t, "ru", user.Language)
+	assert.Equal(t, "john@example.com", user.Email)
+	assert.True(t, user.CanReceiveEmail())
+	assert.False(t, (&User{}).CanReceiveEmail())
+}
+
 func TestUser_GetFullName(t *testing.T) {
 	user := User{
 		FirstName: "John",
@@ -0,0 +1,191 @@
+package entities
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+	"time"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	"github.com/google/uuid"
+)
+
+// MaxWebhookAttempts - после стольких неудачных попыток доставка переходит в статус dead
+const MaxWebhookAttempts = 8
+
+// MinWebhookSecretLength - секрет короче этого не дает надежной подписи
+const MinWebhookSecretLength = 16
+
+// webhookRetryBaseDelay - задержка перед первым повтором, каждый следующий повтор ждет вдвое дольше
+const webhookRetryBaseDelay = 30 * time.Second
+
+// WebhookEvents - события заказа, на которые можно подписаться
+var WebhookEvents = []OrderEvent{OrderEventCreated, OrderEventConfirmed, OrderEventCancelled, OrderEventCompleted}
+
+// WebhookSubscription - подписка партнера на события заказов. Secret используется
+// для подписи доставок HMAC-SHA256 и наружу не отдается.
+type WebhookSubscription struct {
+	ID        uuid.UUID    `json:"id"`
+	URL       string       `json:"url"`
+	Events    []OrderEvent `json:"events"`
+	Secret    string       `json:"-"`
+	Active    bool         `json:"active"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+func NewWebhookSubscription(endpoint string, events []OrderEvent, secret string) (*WebhookSubscription, error) {
+	now := time.Now()
+	subscription := &WebhookSubscription{
+		ID:        uuid.New(),
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := subscription.Update(endpoint, events, secret, true); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Update меняет адрес, события и активность подписки; пустой secret оставляет прежний
+func (s *WebhookSubscription) Update(endpoint string, events []OrderEvent, secret string, active bool) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return domainErrors.ErrWebhookURLInvalid
+	}
+
+	if len(events) == 0 {
+		return domainErrors.ErrWebhookEventsRequired
+	}
+	for _, event := range events {
+		if !slices.Contains(WebhookEvents, event) {
+			return domainErrors.ErrWebhookEventUnknown
+		}
+	}
+
+	if secret == "" {
+		secret = s.Secret
+	}
+	if len(secret) < MinWebhookSecretLength {
+		return domainErrors.ErrWebhookSecretTooShort
+	}
+
+	s.URL = endpoint
+	s.Events = slices.Compact(slices.Sorted(slices.Values(events)))
+	s.Secret = secret
+	s.Active = active
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *WebhookSubscription) Subscribes(event OrderEvent) bool {
+	return s.Active && slices.Contains(s.Events, event)
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "dead"
+)
+
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusDelivered, WebhookDeliveryStatusDead:
+		return true
+	}
+	return false
+}
+
+// OrderEventPayload - тело доставки вебхука
+type OrderEventPayload struct {
+	Event      OrderEvent `json:"event"`
+	OccurredAt time.Time  `json:"occurred_at"`
+	Order      *Order     `json:"order"`
+}
+
+// NewOrderEventPayload фиксирует состояние заказа на момент события: повторы и replay
+// отправляют тот же снимок, а не текущее состояние заказа
+func NewOrderEventPayload(event OrderEvent, order *Order, occurredAt time.Time) ([]byte, error) {
+	return json.Marshal(&OrderEventPayload{Event: event, OccurredAt: occurredAt, Order: order})
+}
+
+// WebhookDelivery - запись журнала доставки события подписчику. ResponseStatus - HTTP-статус
+// последней попытки (0, если ответа не было). ReplayOf ссылается на доставку, повтор которой
+// запрошен вручную.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	SubscriptionID uuid.UUID             `json:"subscription_id"`
+	Event          OrderEvent            `json:"event"`
+	OrderID        uuid.UUID             `json:"order_id"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	ResponseStatus int                   `json:"response_status,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	ReplayOf       *uuid.UUID            `json:"replay_of,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+}
+
+func NewWebhookDelivery(subscription *WebhookSubscription, event OrderEvent, orderID uuid.UUID, payload []byte) *WebhookDelivery {
+	now := time.Now()
+
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		Event:          event,
+		OrderID:        orderID,
+		Payload:        payload,
+		Status:         WebhookDeliveryStatusPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+}
+
+func (d *WebhookDelivery) MarkDelivered(responseStatus int, at time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliveryStatusDelivered
+	d.ResponseStatus = responseStatus
+	d.LastError = ""
+	d.DeliveredAt = &at
+}
+
+// MarkFailed фиксирует неудачную попытку и назначает следующую с экспоненциальной задержкой;
+// после MaxWebhookAttempts попыток доставка уходит в dead и ждет ручного replay
+func (d *WebhookDelivery) MarkFailed(responseStatus int, err error, at time.Time) {
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+	d.LastError = err.Error()
+
+	if d.Attempts >= MaxWebhookAttempts {
+		d.Status = WebhookDeliveryStatusDead
+		return
+	}
+
+	d.NextAttemptAt = at.Add(webhookRetryBaseDelay << (d.Attempts - 1))
+}
+
+// Replay создает новую доставку с тем же телом; исходная запись журнала не меняется
+func (d *WebhookDelivery) Replay() *WebhookDelivery {
+	now := time.Now()
+	original := d.ID
+
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: d.SubscriptionID,
+		Event:          d.Event,
+		OrderID:        d.OrderID,
+		Payload:        d.Payload,
+		Status:         WebhookDeliveryStatusPending,
+		NextAttemptAt:  now,
+		ReplayOf:       &original,
+		CreatedAt:      now,
+	}
+}
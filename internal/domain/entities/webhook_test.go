@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const testWebhookSecret = "partner-secret-0123456789"
+
+func TestNewWebhookSubscription_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		events      []OrderEvent
+		secret      string
+		expectedErr error
+	}{
+		{
+			name:   "valid subscription",
+			url:    "https://partner.example.com/hooks/orders",
+			events: []OrderEvent{OrderEventConfirmed, OrderEventCreated},
+			secret: testWebhookSecret,
+		},
+		{
+			name:        "relative url",
+			url:         "/hooks/orders",
+			events:      []OrderEvent{OrderEventCreated},
+			secret:      testWebhookSecret,
+			expectedErr: domainErrors.ErrWebhookURLInvalid,
+		},
+		{
+			name:        "unsupported scheme",
+			url:         "ftp://partner.example.com/hooks",
+			events:      []OrderEvent{OrderEventCreated},
+			secret:      testWebhookSecret,
+			expectedErr: domainErrors.ErrWebhookURLInvalid,
+		},
+		{
+			name:        "no events",
+			url:         "https://partner.example.com/hooks/orders",
+			secret:      testWebhookSecret,
+			expectedErr: domainErrors.ErrWebhookEventsRequired,
+		},
+		{
+			name:        "unknown event",
+			url:         "https://partner.example.com/hooks/orders",
+			events:      []OrderEvent{"order_shipped"},
+			secret:      testWebhookSecret,
+			expectedErr: domainErrors.ErrWebhookEventUnknown,
+		},
+		{
+			name:        "short secret",
+			url:         "https://partner.example.com/hooks/orders",
+			events:      []OrderEvent{OrderEventCreated},
+			secret:      "short",
+			expectedErr: domainErrors.ErrWebhookSecretTooShort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription, err := NewWebhookSubscription(tt.url, tt.events, tt.secret)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, subscription)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, subscription.Active)
+			assert.Equal(t, []OrderEvent{OrderEventConfirmed, OrderEventCreated}, subscription.Events)
+		})
+	}
+}
+
+func TestWebhookSubscription_Update_KeepsSecret(t *testing.T) {
+	subscription, err := NewWebhookSubscription("https://partner.example.com/hooks", []OrderEvent{OrderEventCreated}, testWebhookSecret)
+	assert.NoError(t, err)
+
+	err = subscription.Update("https://partner.example.com/v2/hooks", []OrderEvent{OrderEventCompleted, OrderEventCompleted}, "", false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testWebhookSecret, subscription.Secret)
+	assert.Equal(t, []OrderEvent{OrderEventCompleted}, subscription.Events)
+	assert.False(t, subscription.Subscribes(OrderEventCompleted))
+}
+
+func TestWebhookDelivery_MarkFailed_DeadLetter(t *testing.T) {
+	subscription := &WebhookSubscription{ID: uuid.New()}
+	delivery := NewWebhookDelivery(subscription, OrderEventCreated, uuid.New(), []byte(`{}`))
+	now := time.Now()
+
+	// Задержка удваивается с каждой попыткой, начиная с 30 секунд
+	for attempt := 1; attempt < MaxWebhookAttempts; attempt++ {
+		delivery.MarkFailed(500, errors.New("internal server error"), now)
+		assert.Equal(t, WebhookDeliveryStatusPending, delivery.Status)
+		assert.Equal(t, now.Add(30*time.Second<<(attempt-1)), delivery.NextAttemptAt)
+	}
+
+	delivery.MarkFailed(0, errors.New("connection refused"), now)
+	assert.Equal(t, MaxWebhookAttempts, delivery.Attempts)
+	assert.Equal(t, WebhookDeliveryStatusDead, delivery.Status)
+	assert.Equal(t, 0, delivery.ResponseStatus)
+	assert.Equal(t, "connection refused", delivery.LastError)
+}
+
+func TestWebhookDelivery_Replay(t *testing.T) {
+	order := NewOrder(uuid.New())
+	payload, err := NewOrderEventPayload(OrderEventCancelled, order, time.Now())
+	assert.NoError(t, err)
+
+	subscription := &WebhookSubscription{ID: uuid.New()}
+	delivery := NewWebhookDelivery(subscription, OrderEventCancelled, order.ID, payload)
+	delivery.Status = WebhookDeliveryStatusDead
+	delivery.Attempts = MaxWebhookAttempts
+
+	replay := delivery.Replay()
+
+	assert.NotEqual(t, delivery.ID, replay.ID)
+	assert.Equal(t, delivery.ID, *replay.ReplayOf)
+	assert.Equal(t, WebhookDeliveryStatusPending, replay.Status)
+	assert.Zero(t, replay.Attempts)
+	assert.Equal(t, WebhookDeliveryStatusDead, delivery.Status)
+
+	var body OrderEventPayload
+	assert.NoError(t, json.Unmarshal(replay.Payload, &body))
+	assert.Equal(t, OrderEventCancelled, body.Event)
+	assert.Equal(t, order.ID, body.Order.ID)
+}
@@ -4,11 +4,12 @@ import "errors"
 
 // User domain errors
 var (
-	ErrFirstNameRequired = errors.New("first name is required")
-	ErrLastNameRequired  = errors.New("last name is required")
-	ErrUserTooYoung      = errors.New("user must be at least 18 years old")
-	ErrPasswordTooShort  = errors.New("password must be at least 8 characters long")
-	ErrUserNotFound      = errors.New("user not found")
+	ErrFirstNameRequired   = errors.New("first name is required")
+	ErrLastNameRequired    = errors.New("last name is required")
+	ErrUserTooYoung        = errors.New("user must be at least 18 years old")
+	ErrPasswordTooShort    = errors.New("password must be at least 8 characters long")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrLanguageUnsupported = errors.New("language must be ru or en")
 )
 
 // Product domain errors
@@ -17,21 +18,127 @@ var (
 	ErrProductPriceInvalid        = errors.New("price must be greater than 0")
 	ErrProductQuantityNegative    = errors.New("quantity cannot be negative")
 	ErrInsufficientQuantity       = errors.New("insufficient quantity available")
+	ErrProductSKURequired         = errors.New("sku is required")
+	ErrProductSKUTooLong          = errors.New("sku must not exceed 64 characters")
+	ErrProductSKUDuplicate        = errors.New("sku appears more than once in the import")
+	ErrCatalogFormatInvalid       = errors.New("catalog format must be csv or ndjson")
+	ErrVariantAttributesRequired  = errors.New("variant must have at least one attribute")
+	ErrVariantDuplicate           = errors.New("variant with the same sku or attributes already exists")
+	ErrVariantRequired            = errors.New("product has variants, variant_id is required")
+	ErrVariantNotFound            = errors.New("product variant not found")
+	ErrProductNotFound            = errors.New("product not found")
+	ErrStockPolicyInvalid         = errors.New("stock policy must be in_stock, backorder or preorder")
+	ErrPreorderDateRequired       = errors.New("preorder products require an expected availability date")
+)
+
+// Stock alert errors
+var (
+	ErrStockSubscriptionNotFound = errors.New("stock subscription not found")
+	ErrProductInStock            = errors.New("product is in stock")
+	ErrLowStockThresholdInvalid  = errors.New("low stock threshold cannot be negative")
+)
+
+// Webhook errors
+var (
+	ErrWebhookURLInvalid            = errors.New("webhook url must be an absolute http or https url")
+	ErrWebhookEventsRequired        = errors.New("at least one event type is required")
+	ErrWebhookEventUnknown          = errors.New("unknown webhook event type")
+	ErrWebhookSecretTooShort        = errors.New("webhook secret must be at least 16 characters long")
+	ErrWebhookSubscriptionNotFound  = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrWebhookDeliveryStatusInvalid = errors.New("delivery status must be pending, delivered or dead")
 )
 
 // Order domain errors
 var (
-	ErrQuantityInvalid         = errors.New("quantity must be greater than 0")
-	ErrInsufficientStock       = errors.New("insufficient product quantity")
-	ErrOnlyPendingCanConfirm   = errors.New("only pending orders can be confirmed")
-	ErrCannotConfirmEmptyOrder = errors.New("cannot confirm empty order")
-	ErrCompletedOrdersReadonly = errors.New("completed orders cannot be cancelled")
-	ErrOrderMustHaveItems      = errors.New("order must contain at least one item")
-	ErrOrderNotFound           = errors.New("order not found")
+	ErrQuantityInvalid          = errors.New("quantity must be greater than 0")
+	ErrInsufficientStock        = errors.New("insufficient product quantity")
+	ErrOnlyPendingCanConfirm    = errors.New("only pending orders can be confirmed")
+	ErrCannotConfirmEmptyOrder  = errors.New("cannot confirm empty order")
+	ErrCompletedOrdersReadonly  = errors.New("completed orders cannot be cancelled")
+	ErrOrderAlreadyCancelled    = errors.New("order is already cancelled")
+	ErrOrderMustHaveItems       = errors.New("order must contain at least one item")
+	ErrOrderNotFound            = errors.New("order not found")
+	ErrOnlyConfirmedCanComplete = errors.New("only confirmed orders can be completed")
+)
+
+// Shipment domain errors
+var (
+	ErrOnlyConfirmedCanShip       = errors.New("only confirmed orders can be shipped")
+	ErrOrderAlreadyShipped        = errors.New("all order items are already shipped")
+	ErrShipmentItemNotInOrder     = errors.New("shipment item does not belong to the order")
+	ErrShipmentQuantityExceeded   = errors.New("shipment quantity exceeds unshipped quantity")
+	ErrShipmentAwaitingStock      = errors.New("shipment quantity exceeds stock allocated to the order")
+	ErrOrderAwaitingStock         = errors.New("remaining order items are waiting for stock")
+	ErrShipmentStatusInvalid      = errors.New("unknown shipment status")
+	ErrShipmentAlreadyDelivered   = errors.New("shipment is already delivered")
+	ErrShipmentNotFound           = errors.New("shipment not found")
+	ErrCarrierNotSupported        = errors.New("carrier is not supported")
+	ErrCarrierWebhookUnauthorized = errors.New("invalid carrier webhook token")
+)
+
+// Return domain errors
+var (
+	ErrReturnNotOwner           = errors.New("order belongs to another user")
+	ErrOnlyCompletedCanReturn   = errors.New("only completed orders can be returned")
+	ErrReturnMustHaveItems      = errors.New("return must contain at least one item")
+	ErrReturnItemNotInOrder     = errors.New("return item does not belong to the order")
+	ErrReturnReasonInvalid      = errors.New("unknown return reason")
+	ErrReturnQuantityExceeded   = errors.New("return quantity exceeds purchased quantity")
+	ErrOnlyRequestedCanReview   = errors.New("only requested returns can be approved or rejected")
+	ErrRejectionCommentRequired = errors.New("rejection comment is required")
+	ErrOnlyApprovedCanReceive   = errors.New("only approved returns can be received")
+	ErrReturnNotFound           = errors.New("return not found")
+)
+
+// Order limit errors
+var (
+	ErrOrderProductQuantityLimit = errors.New("order exceeds the maximum quantity per product")
+	ErrUserProductQuantityLimit  = errors.New("purchase limit for this product has been reached")
+	ErrOrderTotalLimit           = errors.New("order total exceeds the allowed maximum")
+	ErrOrderVelocityLimit        = errors.New("too many orders in a short period")
+	ErrOnlyPendingCanHold        = errors.New("only pending orders can be put on hold")
+	ErrOnlyOnHoldCanRelease      = errors.New("only orders on hold can be released")
+)
+
+// Invoice domain errors
+var (
+	ErrOnlyCompletedCanInvoice = errors.New("invoices are issued only for completed orders")
+	ErrInvoiceSequenceInvalid  = errors.New("invoice sequence must be greater than 0")
+	ErrInvoiceNotFound         = errors.New("invoice not found")
+	ErrInvoiceFormatInvalid    = errors.New("unsupported invoice format")
+)
+
+// Price domain errors
+var (
+	ErrPriceNotFound           = errors.New("price not found")
+	ErrPriceAlreadyScheduled   = errors.New("a price is already scheduled for this time")
+	ErrPriceStartInPast        = errors.New("scheduled price must start in the future")
+	ErrProductPricedByVariants = errors.New("product with variants is priced per variant")
+)
+
+// Category domain errors
+var (
+	ErrCategoryNameRequired = errors.New("category name is required")
+	ErrCategorySlugInvalid  = errors.New("category slug must contain only lowercase letters, digits and hyphens")
+	ErrCategorySlugTaken    = errors.New("category slug is already taken")
+	ErrCategoryNotFound     = errors.New("category not found")
+)
+
+// Report errors
+var (
+	ErrReportRangeInvalid  = errors.New("report range start must be before its end")
+	ErrReportRangeTooLarge = errors.New("report range must not exceed 366 days")
+	ErrReportPeriodInvalid = errors.New("report grouping must be day, week or month")
+	ErrReportDateInvalid   = errors.New("report dates must be YYYY-MM-DD or RFC3339")
+	ErrReportFormatInvalid = errors.New("report format must be json or csv")
 )
 
 // Validation errors
 var (
-	ErrInvalidOrderID = errors.New("invalid order ID format")
-	ErrInvalidUserID  = errors.New("invalid user ID format")
+	ErrInvalidOrderID   = errors.New("invalid order ID format")
+	ErrInvalidUserID    = errors.New("invalid user ID format")
+	ErrInvalidReturnID  = errors.New("invalid return ID format")
+	ErrInvalidProductID = errors.New("invalid product ID format")
+	ErrInvalidVariantID = errors.New("invalid variant ID format")
 )
@@ -0,0 +1,20 @@
+package repositories
+
+//go:generate mockgen -source=category_repository.go -destination=mocks/category_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// CategoryRepository определяет контракт для работы с категориями товаров
+type CategoryRepository interface {
+	Create(ctx context.Context, category *entities.Category) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*entities.Category, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.Category, error)
+	GetAll(ctx context.Context) ([]*entities.Category, error)
+}
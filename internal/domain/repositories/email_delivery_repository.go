@@ -0,0 +1,23 @@
+package repositories
+
+//go:generate mockgen -source=email_delivery_repository.go -destination=mocks/email_delivery_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// EmailDeliveryRepository определяет контракт для журнала доставки писем о заказах
+type EmailDeliveryRepository interface {
+	Create(ctx context.Context, delivery *entities.EmailDelivery) error
+	Update(ctx context.Context, delivery *entities.EmailDelivery) error
+	// ClaimDue забирает письма в статусе pending, время отправки которых наступило, и откладывает
+	// их следующую попытку на lease: другие экземпляры сервиса эти письма не получат. Если экземпляр
+	// упадет, не сохранив результат, письмо снова станет доступно по истечении lease.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.EmailDelivery, error)
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.EmailDelivery, error)
+}
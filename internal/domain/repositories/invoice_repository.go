@@ -0,0 +1,22 @@
+package repositories
+
+//go:generate mockgen -source=invoice_repository.go -destination=mocks/invoice_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceRepository определяет контракт для работы со счетами
+type InvoiceRepository interface {
+	// NextSequence выдает следующий номер счета за год.
+	// Номер не теряется только если вызов и Create выполняются в одной транзакции.
+	NextSequence(ctx context.Context, year int) (int, error)
+	Create(ctx context.Context, invoice *entities.Invoice, pdf []byte) error
+	// GetByOrderID возвращает ErrInvoiceNotFound, если счет еще не выставлен
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) (*entities.Invoice, error)
+	GetPDF(ctx context.Context, invoiceID uuid.UUID) ([]byte, error)
+}
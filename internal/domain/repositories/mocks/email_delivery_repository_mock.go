@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: email_delivery_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=email_delivery_repository.go -destination=mocks/email_delivery_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/AndrivA89/orders/internal/domain/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmailDeliveryRepository is a mock of EmailDeliveryRepository interface.
+type MockEmailDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailDeliveryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailDeliveryRepositoryMockRecorder is the mock recorder for MockEmailDeliveryRepository.
+type MockEmailDeliveryRepositoryMockRecorder struct {
+	mock *MockEmailDeliveryRepository
+}
+
+// NewMockEmailDeliveryRepository creates a new mock instance.
+func NewMockEmailDeliveryRepository(ctrl *gomock.Controller) *MockEmailDeliveryRepository {
+	mock := &MockEmailDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailDeliveryRepository) EXPECT() *MockEmailDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockEmailDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.EmailDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]*entities.EmailDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockEmailDeliveryRepositoryMockRecorder) ClaimDue(ctx, now, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockEmailDeliveryRepository)(nil).ClaimDue), ctx, now, lease, limit)
+}
+
+// Create mocks base method.
+func (m *MockEmailDeliveryRepository) Create(ctx context.Context, delivery *entities.EmailDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEmailDeliveryRepositoryMockRecorder) Create(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmailDeliveryRepository)(nil).Create), ctx, delivery)
+}
+
+// GetByOrderID mocks base method.
+func (m *MockEmailDeliveryRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.EmailDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrderID", ctx, orderID)
+	ret0, _ := ret[0].([]*entities.EmailDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrderID indicates an expected call of GetByOrderID.
+func (mr *MockEmailDeliveryRepositoryMockRecorder) GetByOrderID(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderID", reflect.TypeOf((*MockEmailDeliveryRepository)(nil).GetByOrderID), ctx, orderID)
+}
+
+// Update mocks base method.
+func (m *MockEmailDeliveryRepository) Update(ctx context.Context, delivery *entities.EmailDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEmailDeliveryRepositoryMockRecorder) Update(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEmailDeliveryRepository)(nil).Update), ctx, delivery)
+}
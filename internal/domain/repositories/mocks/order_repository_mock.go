@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entities "github.com/AndrivA89/orders/internal/domain/entities"
 	uuid "github.com/google/uuid"
@@ -42,6 +43,21 @@ func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByUserSince mocks base method.
+func (m *MockOrderRepository) CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUserSince", ctx, userID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUserSince indicates an expected call of CountByUserSince.
+func (mr *MockOrderRepositoryMockRecorder) CountByUserSince(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUserSince", reflect.TypeOf((*MockOrderRepository)(nil).CountByUserSince), ctx, userID, since)
+}
+
 // Create mocks base method.
 func (m *MockOrderRepository) Create(ctx context.Context, order *entities.Order) error {
 	m.ctrl.T.Helper()
@@ -70,6 +86,21 @@ func (mr *MockOrderRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepository)(nil).Delete), ctx, id)
 }
 
+// GetBackorderedItemsForUpdate mocks base method.
+func (m *MockOrderRepository) GetBackorderedItemsForUpdate(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID) ([]*entities.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBackorderedItemsForUpdate", ctx, productID, variantID)
+	ret0, _ := ret[0].([]*entities.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBackorderedItemsForUpdate indicates an expected call of GetBackorderedItemsForUpdate.
+func (mr *MockOrderRepositoryMockRecorder) GetBackorderedItemsForUpdate(ctx, productID, variantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBackorderedItemsForUpdate", reflect.TypeOf((*MockOrderRepository)(nil).GetBackorderedItemsForUpdate), ctx, productID, variantID)
+}
+
 // GetByID mocks base method.
 func (m *MockOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +116,21 @@ func (mr *MockOrderRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrderRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByIDForUpdate mocks base method.
+func (m *MockOrderRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entities.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockOrderRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockOrderRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
 // GetByUserID mocks base method.
 func (m *MockOrderRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Order, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +146,36 @@ func (mr *MockOrderRepositoryMockRecorder) GetByUserID(ctx, userID, limit, offse
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockOrderRepository)(nil).GetByUserID), ctx, userID, limit, offset)
 }
 
+// GetReservedBefore mocks base method.
+func (m *MockOrderRepository) GetReservedBefore(ctx context.Context, status entities.OrderStatus, before time.Time, limit int) ([]*entities.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedBefore", ctx, status, before, limit)
+	ret0, _ := ret[0].([]*entities.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedBefore indicates an expected call of GetReservedBefore.
+func (mr *MockOrderRepositoryMockRecorder) GetReservedBefore(ctx, status, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedBefore", reflect.TypeOf((*MockOrderRepository)(nil).GetReservedBefore), ctx, status, before, limit)
+}
+
+// QuantitiesByUserSince mocks base method.
+func (m *MockOrderRepository) QuantitiesByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (map[uuid.UUID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuantitiesByUserSince", ctx, userID, since)
+	ret0, _ := ret[0].(map[uuid.UUID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuantitiesByUserSince indicates an expected call of QuantitiesByUserSince.
+func (mr *MockOrderRepositoryMockRecorder) QuantitiesByUserSince(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuantitiesByUserSince", reflect.TypeOf((*MockOrderRepository)(nil).QuantitiesByUserSince), ctx, userID, since)
+}
+
 // Update mocks base method.
 func (m *MockOrderRepository) Update(ctx context.Context, order *entities.Order) error {
 	m.ctrl.T.Helper()
@@ -113,3 +189,17 @@ func (mr *MockOrderRepositoryMockRecorder) Update(ctx, order any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderRepository)(nil).Update), ctx, order)
 }
+
+// UpdateItem mocks base method.
+func (m *MockOrderRepository) UpdateItem(ctx context.Context, item *entities.OrderItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockOrderRepositoryMockRecorder) UpdateItem(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockOrderRepository)(nil).UpdateItem), ctx, item)
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: price_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=price_repository.go -destination=mocks/price_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/AndrivA89/orders/internal/domain/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPriceRepository is a mock of PriceRepository interface.
+type MockPriceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceRepositoryMockRecorder is the mock recorder for MockPriceRepository.
+type MockPriceRepositoryMockRecorder struct {
+	mock *MockPriceRepository
+}
+
+// NewMockPriceRepository creates a new mock instance.
+func NewMockPriceRepository(ctrl *gomock.Controller) *MockPriceRepository {
+	mock := &MockPriceRepository{ctrl: ctrl}
+	mock.recorder = &MockPriceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceRepository) EXPECT() *MockPriceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPriceRepository) Create(ctx context.Context, change *entities.PriceChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPriceRepositoryMockRecorder) Create(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPriceRepository)(nil).Create), ctx, change)
+}
+
+// GetByProductID mocks base method.
+func (m *MockPriceRepository) GetByProductID(ctx context.Context, productID uuid.UUID) (entities.PriceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID)
+	ret0, _ := ret[0].(entities.PriceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockPriceRepositoryMockRecorder) GetByProductID(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockPriceRepository)(nil).GetByProductID), ctx, productID)
+}
+
+// GetByProductIDs mocks base method.
+func (m *MockPriceRepository) GetByProductIDs(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]entities.PriceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductIDs", ctx, productIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]entities.PriceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductIDs indicates an expected call of GetByProductIDs.
+func (mr *MockPriceRepositoryMockRecorder) GetByProductIDs(ctx, productIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductIDs", reflect.TypeOf((*MockPriceRepository)(nil).GetByProductIDs), ctx, productIDs)
+}
+
+// GetDue mocks base method.
+func (m *MockPriceRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*entities.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", ctx, now, limit)
+	ret0, _ := ret[0].([]*entities.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockPriceRepositoryMockRecorder) GetDue(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockPriceRepository)(nil).GetDue), ctx, now, limit)
+}
+
+// GetEffective mocks base method.
+func (m *MockPriceRepository) GetEffective(ctx context.Context, productID uuid.UUID, at time.Time) (*entities.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffective", ctx, productID, at)
+	ret0, _ := ret[0].(*entities.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffective indicates an expected call of GetEffective.
+func (mr *MockPriceRepositoryMockRecorder) GetEffective(ctx, productID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffective", reflect.TypeOf((*MockPriceRepository)(nil).GetEffective), ctx, productID, at)
+}
+
+// Update mocks base method.
+func (m *MockPriceRepository) Update(ctx context.Context, change *entities.PriceChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPriceRepositoryMockRecorder) Update(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPriceRepository)(nil).Update), ctx, change)
+}
@@ -56,6 +56,34 @@ func (mr *MockProductRepositoryMockRecorder) Create(ctx, product any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductRepository)(nil).Create), ctx, product)
 }
 
+// CreateVariant mocks base method.
+func (m *MockProductRepository) CreateVariant(ctx context.Context, variant *entities.ProductVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", ctx, variant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVariant indicates an expected call of CreateVariant.
+func (mr *MockProductRepositoryMockRecorder) CreateVariant(ctx, variant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockProductRepository)(nil).CreateVariant), ctx, variant)
+}
+
+// ForEachBatch mocks base method.
+func (m *MockProductRepository) ForEachBatch(ctx context.Context, batchSize int, fn func([]*entities.Product) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachBatch", ctx, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachBatch indicates an expected call of ForEachBatch.
+func (mr *MockProductRepositoryMockRecorder) ForEachBatch(ctx, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachBatch", reflect.TypeOf((*MockProductRepository)(nil).ForEachBatch), ctx, batchSize, fn)
+}
+
 // GetAll mocks base method.
 func (m *MockProductRepository) GetAll(ctx context.Context, limit, offset int) ([]*entities.Product, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +99,36 @@ func (mr *MockProductRepositoryMockRecorder) GetAll(ctx, limit, offset any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProductRepository)(nil).GetAll), ctx, limit, offset)
 }
 
+// GetBackorderedIDs mocks base method.
+func (m *MockProductRepository) GetBackorderedIDs(ctx context.Context) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBackorderedIDs", ctx)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBackorderedIDs indicates an expected call of GetBackorderedIDs.
+func (mr *MockProductRepositoryMockRecorder) GetBackorderedIDs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBackorderedIDs", reflect.TypeOf((*MockProductRepository)(nil).GetBackorderedIDs), ctx)
+}
+
+// GetByCategory mocks base method.
+func (m *MockProductRepository) GetByCategory(ctx context.Context, categoryID uuid.UUID, limit, offset int) ([]*entities.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCategory", ctx, categoryID, limit, offset)
+	ret0, _ := ret[0].([]*entities.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCategory indicates an expected call of GetByCategory.
+func (mr *MockProductRepositoryMockRecorder) GetByCategory(ctx, categoryID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCategory", reflect.TypeOf((*MockProductRepository)(nil).GetByCategory), ctx, categoryID, limit, offset)
+}
+
 // GetByID mocks base method.
 func (m *MockProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +159,65 @@ func (mr *MockProductRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockProductRepository)(nil).GetByIDForUpdate), ctx, id)
 }
 
+// GetByIDs mocks base method.
+func (m *MockProductRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*entities.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockProductRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockProductRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// GetBySKUsForUpdate mocks base method.
+func (m *MockProductRepository) GetBySKUsForUpdate(ctx context.Context, skus []string) ([]*entities.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySKUsForUpdate", ctx, skus)
+	ret0, _ := ret[0].([]*entities.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySKUsForUpdate indicates an expected call of GetBySKUsForUpdate.
+func (mr *MockProductRepositoryMockRecorder) GetBySKUsForUpdate(ctx, skus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKUsForUpdate", reflect.TypeOf((*MockProductRepository)(nil).GetBySKUsForUpdate), ctx, skus)
+}
+
+// GetVariantByIDForUpdate mocks base method.
+func (m *MockProductRepository) GetVariantByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entities.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantByIDForUpdate indicates an expected call of GetVariantByIDForUpdate.
+func (mr *MockProductRepositoryMockRecorder) GetVariantByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantByIDForUpdate", reflect.TypeOf((*MockProductRepository)(nil).GetVariantByIDForUpdate), ctx, id)
+}
+
+// SetCategories mocks base method.
+func (m *MockProductRepository) SetCategories(ctx context.Context, productID uuid.UUID, categoryIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCategories", ctx, productID, categoryIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCategories indicates an expected call of SetCategories.
+func (mr *MockProductRepositoryMockRecorder) SetCategories(ctx, productID, categoryIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategories", reflect.TypeOf((*MockProductRepository)(nil).SetCategories), ctx, productID, categoryIDs)
+}
+
 // Update mocks base method.
 func (m *MockProductRepository) Update(ctx context.Context, product *entities.Product) error {
 	m.ctrl.T.Helper()
@@ -114,3 +231,31 @@ func (mr *MockProductRepositoryMockRecorder) Update(ctx, product any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductRepository)(nil).Update), ctx, product)
 }
+
+// UpdateVariant mocks base method.
+func (m *MockProductRepository) UpdateVariant(ctx context.Context, variant *entities.ProductVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVariant", ctx, variant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVariant indicates an expected call of UpdateVariant.
+func (mr *MockProductRepositoryMockRecorder) UpdateVariant(ctx, variant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVariant", reflect.TypeOf((*MockProductRepository)(nil).UpdateVariant), ctx, variant)
+}
+
+// UpsertBySKU mocks base method.
+func (m *MockProductRepository) UpsertBySKU(ctx context.Context, products []*entities.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertBySKU", ctx, products)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertBySKU indicates an expected call of UpsertBySKU.
+func (mr *MockProductRepositoryMockRecorder) UpsertBySKU(ctx, products any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertBySKU", reflect.TypeOf((*MockProductRepository)(nil).UpsertBySKU), ctx, products)
+}
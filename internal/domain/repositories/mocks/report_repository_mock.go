@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=report_repository.go -destination=mocks/report_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/AndrivA89/orders/internal/domain/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// LowStockProducts mocks base method.
+func (m *MockReportRepository) LowStockProducts(ctx context.Context, threshold, limit int) ([]entities.LowStockProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LowStockProducts", ctx, threshold, limit)
+	ret0, _ := ret[0].([]entities.LowStockProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LowStockProducts indicates an expected call of LowStockProducts.
+func (mr *MockReportRepositoryMockRecorder) LowStockProducts(ctx, threshold, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LowStockProducts", reflect.TypeOf((*MockReportRepository)(nil).LowStockProducts), ctx, threshold, limit)
+}
+
+// SalesByCategory mocks base method.
+func (m *MockReportRepository) SalesByCategory(ctx context.Context, from, to time.Time) ([]entities.CategorySales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SalesByCategory", ctx, from, to)
+	ret0, _ := ret[0].([]entities.CategorySales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SalesByCategory indicates an expected call of SalesByCategory.
+func (mr *MockReportRepositoryMockRecorder) SalesByCategory(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SalesByCategory", reflect.TypeOf((*MockReportRepository)(nil).SalesByCategory), ctx, from, to)
+}
+
+// SalesByPeriod mocks base method.
+func (m *MockReportRepository) SalesByPeriod(ctx context.Context, from, to time.Time, period entities.ReportPeriod) ([]entities.SalesPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SalesByPeriod", ctx, from, to, period)
+	ret0, _ := ret[0].([]entities.SalesPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SalesByPeriod indicates an expected call of SalesByPeriod.
+func (mr *MockReportRepositoryMockRecorder) SalesByPeriod(ctx, from, to, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SalesByPeriod", reflect.TypeOf((*MockReportRepository)(nil).SalesByPeriod), ctx, from, to, period)
+}
+
+// SalesByTag mocks base method.
+func (m *MockReportRepository) SalesByTag(ctx context.Context, from, to time.Time) ([]entities.TagSales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SalesByTag", ctx, from, to)
+	ret0, _ := ret[0].([]entities.TagSales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SalesByTag indicates an expected call of SalesByTag.
+func (mr *MockReportRepositoryMockRecorder) SalesByTag(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SalesByTag", reflect.TypeOf((*MockReportRepository)(nil).SalesByTag), ctx, from, to)
+}
+
+// SalesSummary mocks base method.
+func (m *MockReportRepository) SalesSummary(ctx context.Context, from, to time.Time) (*entities.SalesSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SalesSummary", ctx, from, to)
+	ret0, _ := ret[0].(*entities.SalesSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SalesSummary indicates an expected call of SalesSummary.
+func (mr *MockReportRepositoryMockRecorder) SalesSummary(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SalesSummary", reflect.TypeOf((*MockReportRepository)(nil).SalesSummary), ctx, from, to)
+}
+
+// TopProducts mocks base method.
+func (m *MockReportRepository) TopProducts(ctx context.Context, from, to time.Time, limit int) ([]entities.ProductSales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopProducts", ctx, from, to, limit)
+	ret0, _ := ret[0].([]entities.ProductSales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopProducts indicates an expected call of TopProducts.
+func (mr *MockReportRepositoryMockRecorder) TopProducts(ctx, from, to, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopProducts", reflect.TypeOf((*MockReportRepository)(nil).TopProducts), ctx, from, to, limit)
+}
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stock_alert_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=stock_alert_repository.go -destination=mocks/stock_alert_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/AndrivA89/orders/internal/domain/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStockAlertRepository is a mock of StockAlertRepository interface.
+type MockStockAlertRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockAlertRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStockAlertRepositoryMockRecorder is the mock recorder for MockStockAlertRepository.
+type MockStockAlertRepositoryMockRecorder struct {
+	mock *MockStockAlertRepository
+}
+
+// NewMockStockAlertRepository creates a new mock instance.
+func NewMockStockAlertRepository(ctrl *gomock.Controller) *MockStockAlertRepository {
+	mock := &MockStockAlertRepository{ctrl: ctrl}
+	mock.recorder = &MockStockAlertRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockAlertRepository) EXPECT() *MockStockAlertRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateNotifications mocks base method.
+func (m *MockStockAlertRepository) CreateNotifications(ctx context.Context, notifications []*entities.StockNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotifications", ctx, notifications)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotifications indicates an expected call of CreateNotifications.
+func (mr *MockStockAlertRepositoryMockRecorder) CreateNotifications(ctx, notifications any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotifications", reflect.TypeOf((*MockStockAlertRepository)(nil).CreateNotifications), ctx, notifications)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockStockAlertRepository) CreateSubscription(ctx context.Context, subscription *entities.StockSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockStockAlertRepositoryMockRecorder) CreateSubscription(ctx, subscription any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockStockAlertRepository)(nil).CreateSubscription), ctx, subscription)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockStockAlertRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockStockAlertRepositoryMockRecorder) DeleteSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockStockAlertRepository)(nil).DeleteSubscription), ctx, id)
+}
+
+// GetActiveSubscription mocks base method.
+func (m *MockStockAlertRepository) GetActiveSubscription(ctx context.Context, userID, productID uuid.UUID, variantID *uuid.UUID) (*entities.StockSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSubscription", ctx, userID, productID, variantID)
+	ret0, _ := ret[0].(*entities.StockSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSubscription indicates an expected call of GetActiveSubscription.
+func (mr *MockStockAlertRepositoryMockRecorder) GetActiveSubscription(ctx, userID, productID, variantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSubscription", reflect.TypeOf((*MockStockAlertRepository)(nil).GetActiveSubscription), ctx, userID, productID, variantID)
+}
+
+// GetPendingNotificationsForUpdate mocks base method.
+func (m *MockStockAlertRepository) GetPendingNotificationsForUpdate(ctx context.Context, limit int) ([]*entities.StockNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingNotificationsForUpdate", ctx, limit)
+	ret0, _ := ret[0].([]*entities.StockNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingNotificationsForUpdate indicates an expected call of GetPendingNotificationsForUpdate.
+func (mr *MockStockAlertRepositoryMockRecorder) GetPendingNotificationsForUpdate(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNotificationsForUpdate", reflect.TypeOf((*MockStockAlertRepository)(nil).GetPendingNotificationsForUpdate), ctx, limit)
+}
+
+// GetSubscribersForUpdate mocks base method.
+func (m *MockStockAlertRepository) GetSubscribersForUpdate(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID) ([]*entities.StockSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscribersForUpdate", ctx, productID, variantID)
+	ret0, _ := ret[0].([]*entities.StockSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscribersForUpdate indicates an expected call of GetSubscribersForUpdate.
+func (mr *MockStockAlertRepositoryMockRecorder) GetSubscribersForUpdate(ctx, productID, variantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribersForUpdate", reflect.TypeOf((*MockStockAlertRepository)(nil).GetSubscribersForUpdate), ctx, productID, variantID)
+}
+
+// MarkSubscriptionsNotified mocks base method.
+func (m *MockStockAlertRepository) MarkSubscriptionsNotified(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSubscriptionsNotified", ctx, ids, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSubscriptionsNotified indicates an expected call of MarkSubscriptionsNotified.
+func (mr *MockStockAlertRepositoryMockRecorder) MarkSubscriptionsNotified(ctx, ids, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSubscriptionsNotified", reflect.TypeOf((*MockStockAlertRepository)(nil).MarkSubscriptionsNotified), ctx, ids, at)
+}
+
+// UpdateNotification mocks base method.
+func (m *MockStockAlertRepository) UpdateNotification(ctx context.Context, notification *entities.StockNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotification", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotification indicates an expected call of UpdateNotification.
+func (mr *MockStockAlertRepositoryMockRecorder) UpdateNotification(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotification", reflect.TypeOf((*MockStockAlertRepository)(nil).UpdateNotification), ctx, notification)
+}
@@ -4,6 +4,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/AndrivA89/orders/internal/domain/entities"
 
@@ -14,7 +15,21 @@ import (
 type OrderRepository interface {
 	Create(ctx context.Context, order *entities.Order) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error)
+	// GetByIDForUpdate блокирует заказ до конца транзакции, чтобы операции над одним заказом
+	// (отправки, возвраты) проверяли его состояние по очереди
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Order, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Order, error)
 	Update(ctx context.Context, order *entities.Order) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// CountByUserSince считает заказы пользователя с момента since, не учитывая отмененные
+	CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+	// QuantitiesByUserSince суммирует купленные пользователем единицы по товарам, не учитывая отмененные заказы
+	QuantitiesByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (map[uuid.UUID]int, error)
+	// GetBackorderedItemsForUpdate блокирует и возвращает позиции неотмененных заказов, ожидающие
+	// поступления товара (или варианта, если variantID задан), в порядке создания заказов
+	GetBackorderedItemsForUpdate(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID) ([]*entities.OrderItem, error)
+	UpdateItem(ctx context.Context, item *entities.OrderItem) error
+	// GetReservedBefore возвращает до limit заказов в статусе status, резерв которых начат раньше before,
+	// начиная со старых
+	GetReservedBefore(ctx context.Context, status entities.OrderStatus, before time.Time, limit int) ([]*entities.Order, error)
 }
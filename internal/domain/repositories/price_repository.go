@@ -0,0 +1,25 @@
+package repositories
+
+//go:generate mockgen -source=price_repository.go -destination=mocks/price_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// PriceRepository определяет контракт для работы с историей цен товаров
+type PriceRepository interface {
+	Create(ctx context.Context, change *entities.PriceChange) error
+	Update(ctx context.Context, change *entities.PriceChange) error
+	// GetByProductID возвращает историю цен товара, упорядоченную по началу действия
+	GetByProductID(ctx context.Context, productID uuid.UUID) (entities.PriceHistory, error)
+	GetByProductIDs(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]entities.PriceHistory, error)
+	// GetEffective возвращает ErrPriceNotFound, если у товара нет истории цен на момент at
+	GetEffective(ctx context.Context, productID uuid.UUID, at time.Time) (*entities.PriceChange, error)
+	// GetDue возвращает наступившие, но еще не записанные в карточки товаров цены
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*entities.PriceChange, error)
+}
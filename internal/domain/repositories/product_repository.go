@@ -14,7 +14,24 @@ import (
 type ProductRepository interface {
 	Create(ctx context.Context, product *entities.Product) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Product, error)
+	// GetByIDs загружает несколько товаров одним запросом; отсутствующие идентификаторы пропускаются
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.Product, error)
 	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Product, error)
 	GetAll(ctx context.Context, limit, offset int) ([]*entities.Product, error)
+	// GetByCategory возвращает товары категории и всех ее подкатегорий
+	GetByCategory(ctx context.Context, categoryID uuid.UUID, limit, offset int) ([]*entities.Product, error)
+	SetCategories(ctx context.Context, productID uuid.UUID, categoryIDs []uuid.UUID) error
 	Update(ctx context.Context, product *entities.Product) error
+	CreateVariant(ctx context.Context, variant *entities.ProductVariant) error
+	GetVariantByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.ProductVariant, error)
+	UpdateVariant(ctx context.Context, variant *entities.ProductVariant) error
+	// GetBySKUsForUpdate блокирует найденные товары до конца транзакции в порядке артикулов,
+	// чтобы резервы заказов не вклинились между чтением остатка и его записью при импорте
+	GetBySKUsForUpdate(ctx context.Context, skus []string) ([]*entities.Product, error)
+	// UpsertBySKU создает товары или обновляет существующие с тем же артикулом одним запросом
+	UpsertBySKU(ctx context.Context, products []*entities.Product) error
+	// ForEachBatch обходит весь каталог пачками, не загружая его в память целиком
+	ForEachBatch(ctx context.Context, batchSize int, fn func(products []*entities.Product) error) error
+	// GetBackorderedIDs возвращает товары, поступления которых ждут позиции неотмененных заказов
+	GetBackorderedIDs(ctx context.Context) ([]uuid.UUID, error)
 }
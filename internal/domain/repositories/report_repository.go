@@ -0,0 +1,21 @@
+package repositories
+
+//go:generate mockgen -source=report_repository.go -destination=mocks/report_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+)
+
+// ReportRepository определяет контракт агрегирующих запросов для отчетов.
+// Интервал [from, to) включает начало и не включает конец.
+type ReportRepository interface {
+	SalesByPeriod(ctx context.Context, from, to time.Time, period entities.ReportPeriod) ([]entities.SalesPoint, error)
+	SalesSummary(ctx context.Context, from, to time.Time) (*entities.SalesSummary, error)
+	TopProducts(ctx context.Context, from, to time.Time, limit int) ([]entities.ProductSales, error)
+	SalesByTag(ctx context.Context, from, to time.Time) ([]entities.TagSales, error)
+	SalesByCategory(ctx context.Context, from, to time.Time) ([]entities.CategorySales, error)
+	LowStockProducts(ctx context.Context, threshold, limit int) ([]entities.LowStockProduct, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+//go:generate mockgen -source=return_repository.go -destination=mocks/return_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// ReturnRepository определяет контракт для работы с заявками на возврат
+type ReturnRepository interface {
+	Create(ctx context.Context, request *entities.ReturnRequest) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.ReturnRequest, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.ReturnRequest, error)
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.ReturnRequest, error)
+	Update(ctx context.Context, request *entities.ReturnRequest) error
+}
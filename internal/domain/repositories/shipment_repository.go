@@ -0,0 +1,21 @@
+package repositories
+
+//go:generate mockgen -source=shipment_repository.go -destination=mocks/shipment_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// ShipmentRepository определяет контракт для работы с отправками
+type ShipmentRepository interface {
+	Create(ctx context.Context, shipment *entities.Shipment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Shipment, error)
+	// GetByTrackingNumberForUpdate блокирует отправку, чтобы события трекинга применялись по одному
+	GetByTrackingNumberForUpdate(ctx context.Context, carrier, trackingNumber string) (*entities.Shipment, error)
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Shipment, error)
+	Update(ctx context.Context, shipment *entities.Shipment) error
+}
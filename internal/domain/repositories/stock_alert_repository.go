@@ -0,0 +1,34 @@
+package repositories
+
+//go:generate mockgen -source=stock_alert_repository.go -destination=mocks/stock_alert_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// StockAlertRepository определяет контракт для подписок на поступление и уведомлений об остатках
+type StockAlertRepository interface {
+	CreateSubscription(ctx context.Context, subscription *entities.StockSubscription) error
+	// GetActiveSubscription возвращает ErrStockSubscriptionNotFound, если открытой подписки нет
+	GetActiveSubscription(
+		ctx context.Context,
+		userID, productID uuid.UUID,
+		variantID *uuid.UUID,
+	) (*entities.StockSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	// GetSubscribersForUpdate блокирует открытые подписки на вариант (если variantID задан)
+	// и на товар целиком
+	GetSubscribersForUpdate(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID) ([]*entities.StockSubscription, error)
+	MarkSubscriptionsNotified(ctx context.Context, ids []uuid.UUID, at time.Time) error
+
+	CreateNotifications(ctx context.Context, notifications []*entities.StockNotification) error
+	// GetPendingNotificationsForUpdate блокирует неотправленные уведомления, у которых остались попытки.
+	// Уведомления, уже заблокированные другой транзакцией, пропускаются (SKIP LOCKED).
+	GetPendingNotificationsForUpdate(ctx context.Context, limit int) ([]*entities.StockNotification, error)
+	UpdateNotification(ctx context.Context, notification *entities.StockNotification) error
+}
@@ -12,7 +12,14 @@ type TransactionManager interface {
 }
 
 type TransactionalRepositories struct {
-	OrderRepository   OrderRepository
-	ProductRepository ProductRepository
-	UserRepository    UserRepository
+	OrderRepository         OrderRepository
+	ProductRepository       ProductRepository
+	UserRepository          UserRepository
+	ShipmentRepository      ShipmentRepository
+	ReturnRepository        ReturnRepository
+	InvoiceRepository       InvoiceRepository
+	PriceRepository         PriceRepository
+	StockAlertRepository    StockAlertRepository
+	EmailDeliveryRepository EmailDeliveryRepository
+	WebhookRepository       WebhookRepository
 }
@@ -14,4 +14,6 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
+	// GetByIDForUpdate блокирует пользователя до конца транзакции, чтобы заказы одного пользователя проверялись по очереди
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.User, error)
 }
@@ -0,0 +1,39 @@
+package repositories
+
+//go:generate mockgen -source=webhook_repository.go -destination=mocks/webhook_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// WebhookRepository определяет контракт для подписок партнеров и журнала доставок вебхуков
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error
+	UpdateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error
+	// DeleteSubscription удаляет подписку вместе с журналом доставок
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	// GetSubscriptionByID возвращает ErrWebhookSubscriptionNotFound, если подписки нет
+	GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error)
+	GetSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	GetActiveSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error)
+
+	CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+	// GetDeliveryByID возвращает ErrWebhookDeliveryNotFound, если доставки нет
+	GetDeliveryByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error)
+	// GetDeliveries возвращает журнал доставок подписки, новые первыми; пустой status - все статусы
+	GetDeliveries(
+		ctx context.Context,
+		subscriptionID uuid.UUID,
+		status entities.WebhookDeliveryStatus,
+		limit, offset int,
+	) ([]*entities.WebhookDelivery, error)
+	// ClaimDueDeliveries забирает доставки в статусе pending, время попытки которых наступило, и
+	// откладывает их следующую попытку на lease: другие экземпляры сервиса эти доставки не получат
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.WebhookDelivery, error)
+}
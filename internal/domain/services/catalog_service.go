@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+)
+
+// CatalogService отвечает за массовый импорт и выгрузку каталога товаров
+type CatalogService interface {
+	// ImportProducts создает или обновляет товары по артикулу и возвращает построчный отчет.
+	// Ошибки отдельных строк попадают в отчет, ошибка возвращается только если импорт прерван.
+	ImportProducts(ctx context.Context, rows []ProductImportRow) (*entities.ProductImportReport, error)
+	// ExportProducts передает товары каталога в fn по одному
+	ExportProducts(ctx context.Context, fn func(product *entities.Product) error) error
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+)
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, req *CreateCategoryRequest) (*entities.Category, error)
+	// GetCategoryTree возвращает корневые категории с вложенными подкатегориями
+	GetCategoryTree(ctx context.Context) ([]*entities.Category, error)
+}
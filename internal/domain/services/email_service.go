@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type EmailService interface {
+	// DispatchEmails отправляет письма, время которых наступило, и возвращает число отправленных
+	DispatchEmails(ctx context.Context, now time.Time) (int, error)
+	// GetOrderEmails возвращает журнал доставки писем по заказу
+	GetOrderEmails(ctx context.Context, orderID uuid.UUID) ([]*entities.EmailDelivery, error)
+}
+
+// EmailRenderer собирает письмо о событии заказа по шаблону на нужном языке
+type EmailRenderer interface {
+	RenderOrderEmail(delivery *entities.EmailDelivery, order *entities.Order, user *entities.User) (*entities.EmailMessage, error)
+}
+
+// EmailSender доставляет готовое письмо получателю
+type EmailSender interface {
+	Send(ctx context.Context, message *entities.EmailMessage) error
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceRenderer отрисовывает счет в печатную форму
+type InvoiceRenderer interface {
+	RenderPDF(invoice *entities.Invoice) ([]byte, error)
+}
+
+type InvoiceService interface {
+	// GetOrIssueInvoice возвращает счет заказа, выставляя его при первом обращении
+	GetOrIssueInvoice(ctx context.Context, orderID uuid.UUID) (*entities.Invoice, error)
+	GetInvoicePDF(ctx context.Context, invoice *entities.Invoice) ([]byte, error)
+}
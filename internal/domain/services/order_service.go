@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/AndrivA89/orders/internal/domain/entities"
 
@@ -15,6 +16,8 @@ type OrderRequest struct {
 
 type OrderItemRequest struct {
 	ProductID uuid.UUID
+	// VariantID обязателен для товаров с вариантами
+	VariantID *uuid.UUID
 	Quantity  int
 }
 
@@ -26,4 +29,10 @@ type OrderService interface {
 	GetOrdersByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Order, error)
 	ConfirmOrder(ctx context.Context, orderID uuid.UUID) error
 	CancelOrder(ctx context.Context, orderID uuid.UUID) error
+	// ReleaseOrder возвращает в работу заказ, отложенный на ручную проверку
+	ReleaseOrder(ctx context.Context, orderID uuid.UUID) error
+	// ExpireReservations отменяет неподтвержденные заказы, зарезервированные раньше before, и отложенные
+	// на проверку, зарезервированные раньше heldBefore (нулевое значение их не трогает), и возвращает
+	// их резерв на склад. Возвращает число отмененных заказов.
+	ExpireReservations(ctx context.Context, before, heldBefore time.Time) (int, error)
 }
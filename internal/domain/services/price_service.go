@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type PriceService interface {
+	// SchedulePrice меняет цену товара сразу или с указанного момента в будущем
+	SchedulePrice(ctx context.Context, productID uuid.UUID, req *SchedulePriceRequest) (*entities.PriceChange, error)
+	GetPriceHistory(ctx context.Context, productID uuid.UUID) (entities.PriceHistory, error)
+	// ApplyScheduledPrices записывает наступившие цены в карточки товаров и возвращает число примененных записей
+	ApplyScheduledPrices(ctx context.Context, now time.Time) (int, error)
+}
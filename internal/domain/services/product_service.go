@@ -11,5 +11,15 @@ import (
 type ProductService interface {
 	CreateProduct(ctx context.Context, req *CreateProductRequest) (*entities.Product, error)
 	GetProductByID(ctx context.Context, id uuid.UUID) (*entities.Product, error)
+	// GetProductsByIDs возвращает найденные товары по идентификаторам; отсутствующие в результат не попадают
+	GetProductsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.Product, error)
 	GetProducts(ctx context.Context, limit, offset int) ([]*entities.Product, error)
+	// GetProductsByCategory возвращает товары категории, включая подкатегории
+	GetProductsByCategory(ctx context.Context, categorySlug string, limit, offset int) ([]*entities.Product, error)
+	SetProductCategories(ctx context.Context, productID uuid.UUID, categoryIDs []uuid.UUID) (*entities.Product, error)
+	AddVariant(ctx context.Context, productID uuid.UUID, req *CreateVariantRequest) (*entities.ProductVariant, error)
+	// ReceiveStock пополняет остаток и сразу распределяет поступивший товар по ожидающим заказам
+	ReceiveStock(ctx context.Context, productID uuid.UUID, req *ReceiveStockRequest) (*entities.StockReceipt, error)
+	// ReconcileStock распределяет свободный остаток по ожидающим заказам, если он появился не через ReceiveStock
+	ReconcileStock(ctx context.Context) ([]*entities.StockReceipt, error)
 }
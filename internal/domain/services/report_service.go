@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+)
+
+type ReportService interface {
+	GetSalesByPeriod(ctx context.Context, req *SalesReportRequest) ([]entities.SalesPoint, error)
+	GetSalesSummary(ctx context.Context, req *SalesReportRequest) (*entities.SalesSummary, error)
+	GetTopProducts(ctx context.Context, req *SalesReportRequest) ([]entities.ProductSales, error)
+	GetSalesByTag(ctx context.Context, req *SalesReportRequest) ([]entities.TagSales, error)
+	GetSalesByCategory(ctx context.Context, req *SalesReportRequest) ([]entities.CategorySales, error)
+	GetLowStockProducts(ctx context.Context, threshold, limit int) ([]entities.LowStockProduct, error)
+}
@@ -1,9 +1,19 @@
 package services
 
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
 // CreateUserRequest объединяет параметры для создания пользователя
 type CreateUserRequest struct {
 	FirstName string
 	LastName  string
+	Email     string
+	Language  string
 	Age       int
 	IsMarried bool
 	Password  string
@@ -11,8 +21,111 @@ type CreateUserRequest struct {
 
 // CreateProductRequest объединяет параметры для создания товара
 type CreateProductRequest struct {
+	SKU         string
+	Name        string
 	Description string
 	Tags        []string
+	Attributes  map[string]string
 	Quantity    int
 	Price       int64
+	StockPolicy entities.StockPolicy
+	ExpectedAt  *time.Time
+	Variants    []CreateVariantRequest
+	CategoryIDs []uuid.UUID
+}
+
+// CreateCategoryRequest объединяет параметры для создания категории
+type CreateCategoryRequest struct {
+	ParentID *uuid.UUID
+	Name     string
+	Slug     string
+	Position int
+}
+
+// CreateVariantRequest объединяет параметры варианта товара
+type CreateVariantRequest struct {
+	SKU        string
+	Attributes map[string]string
+	Price      int64
+	Quantity   int
+}
+
+// ReceiveStockRequest описывает поступление товара на склад; VariantID задается для товаров с вариантами
+type ReceiveStockRequest struct {
+	VariantID *uuid.UUID
+	Quantity  int
+}
+
+// StockSubscriptionRequest - подписка на поступление товара; без VariantID - на любой вариант
+type StockSubscriptionRequest struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+	VariantID *uuid.UUID
+}
+
+// SchedulePriceRequest объединяет параметры изменения цены.
+// Пустой EffectiveFrom означает, что цена меняется немедленно.
+type SchedulePriceRequest struct {
+	Price         int64
+	EffectiveFrom *time.Time
+}
+
+// ProductImportRow - строка файла импорта каталога.
+// DecodeErr заполняется, если строку не удалось разобрать: она попадает в отчет как ошибочная.
+type ProductImportRow struct {
+	Line        int
+	SKU         string
+	Description string
+	Tags        []string
+	Quantity    int
+	Price       int64
+	DecodeErr   error
+}
+
+// CreateShipmentRequest объединяет параметры для создания отправки
+type CreateShipmentRequest struct {
+	OrderID uuid.UUID
+	Carrier string
+	Items   []ShipmentItemRequest
+}
+
+type ShipmentItemRequest struct {
+	OrderItemID uuid.UUID
+	Quantity    int
+}
+
+// OpenReturnRequest объединяет параметры для открытия возврата
+type OpenReturnRequest struct {
+	OrderID uuid.UUID
+	UserID  uuid.UUID
+	Items   []ReturnItemRequest
+}
+
+type ReturnItemRequest struct {
+	OrderItemID uuid.UUID
+	Quantity    int
+	Reason      string
+	Comment     string
+}
+
+// SalesReportRequest объединяет параметры отчетов по продажам
+type SalesReportRequest struct {
+	From    time.Time
+	To      time.Time
+	GroupBy entities.ReportPeriod
+	Limit   int
+}
+
+// WebhookSubscriptionRequest - при обновлении пустой Secret оставляет прежний, Active по умолчанию true
+type WebhookSubscriptionRequest struct {
+	URL    string
+	Events []entities.OrderEvent
+	Secret string
+	Active *bool
+}
+
+type WebhookDeliveryFilter struct {
+	Status entities.WebhookDeliveryStatus
+	Limit  int
+	Offset int
 }
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type ReturnService interface {
+	OpenReturn(ctx context.Context, req *OpenReturnRequest) (*entities.ReturnRequest, error)
+	GetReturnByID(ctx context.Context, id uuid.UUID) (*entities.ReturnRequest, error)
+	GetReturnsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.ReturnRequest, error)
+	ApproveReturn(ctx context.Context, id uuid.UUID, comment string) (*entities.ReturnRequest, error)
+	RejectReturn(ctx context.Context, id uuid.UUID, comment string) (*entities.ReturnRequest, error)
+	// ReceiveReturn фиксирует получение товара и возвращает его на склад
+	ReceiveReturn(ctx context.Context, id uuid.UUID) (*entities.ReturnRequest, error)
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+)
+
+// RiskInput - данные, доступные для оценки риска при создании заказа
+type RiskInput struct {
+	User    *entities.User
+	Order   *entities.Order
+	History *entities.UserOrderHistory
+}
+
+// RiskScorer определяет контракт оценки риска мошенничества по новому заказу
+type RiskScorer interface {
+	Score(ctx context.Context, input *RiskInput) (*entities.RiskAssessment, error)
+}
+
+// OrderPolicy объединяет правила, которые проверяются при создании заказа до резервирования товара.
+// Нулевое значение отключает все проверки.
+type OrderPolicy struct {
+	Limits entities.OrderLimits
+	// RiskScorer = nil отключает оценку риска
+	RiskScorer RiskScorer
+	// HoldThreshold - оценка риска, начиная с которой заказ откладывается на ручную проверку
+	HoldThreshold float64
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// TrackingEvent - событие трекинга, полученное от перевозчика
+type TrackingEvent struct {
+	Carrier        string
+	TrackingNumber string
+	Status         entities.ShipmentStatus
+	Location       string
+	Description    string
+	OccurredAt     time.Time
+}
+
+// TrackingEventHandler обрабатывает события трекинга, которые перевозчик отправляет сам
+type TrackingEventHandler func(ctx context.Context, event *TrackingEvent) error
+
+// CarrierClient определяет контракт интеграции с перевозчиком
+type CarrierClient interface {
+	// Name возвращает идентификатор перевозчика, например "stub"
+	Name() string
+	// RegisterShipment регистрирует отправку у перевозчика и возвращает трек-номер
+	RegisterShipment(ctx context.Context, shipment *entities.Shipment) (string, error)
+}
+
+// TrackingCarrier - перевозчик, который сам присылает события трекинга в процесс (заглушка).
+// StartTracking вызывается после фиксации отправки: события не должны опережать ее появление в БД.
+type TrackingCarrier interface {
+	CarrierClient
+	StartTracking(shipment *entities.Shipment)
+}
+
+type ShipmentService interface {
+	CreateShipment(ctx context.Context, req *CreateShipmentRequest) (*entities.Shipment, error)
+	GetShipmentsByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Shipment, error)
+	ProcessTrackingEvent(ctx context.Context, event *TrackingEvent) (*entities.Shipment, error)
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type StockAlertService interface {
+	// Subscribe подписывает пользователя на поступление товара, которого нет в наличии.
+	// Повторная подписка возвращает уже открытую.
+	Subscribe(ctx context.Context, req *StockSubscriptionRequest) (*entities.StockSubscription, error)
+	Unsubscribe(ctx context.Context, subscriptionID uuid.UUID) error
+	SetLowStockThreshold(ctx context.Context, productID uuid.UUID, threshold int) (*entities.Product, error)
+	// DispatchNotifications отправляет накопленные уведомления и возвращает число отправленных
+	DispatchNotifications(ctx context.Context, now time.Time) (int, error)
+}
+
+// Notifier доставляет уведомления об остатках: покупателям - о поступлении товара,
+// сотрудникам - о низком остатке
+type Notifier interface {
+	Notify(ctx context.Context, notification *entities.StockNotification) error
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, req *WebhookSubscriptionRequest) (*entities.WebhookSubscription, error)
+	GetSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id uuid.UUID, req *WebhookSubscriptionRequest) (*entities.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, filter *WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error)
+	// ReplayDelivery ставит в очередь повторную отправку доставки с тем же телом
+	ReplayDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*entities.WebhookDelivery, error)
+	// DispatchDeliveries отправляет доставки, время которых наступило, и возвращает число доставленных
+	DispatchDeliveries(ctx context.Context, now time.Time) (int, error)
+}
+
+// WebhookSender отправляет доставку подписчику и возвращает HTTP-статус ответа (0, если ответа не было).
+// Ответ вне диапазона 2xx считается ошибкой.
+type WebhookSender interface {
+	Send(ctx context.Context, subscription *entities.WebhookSubscription, delivery *entities.WebhookDelivery) (int, error)
+}
@@ -0,0 +1,147 @@
+package carriers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const StubCarrierName = "stub"
+
+// stubProgression - последовательность статусов, которую эмулирует заглушка
+var stubProgression = []entities.ShipmentStatus{
+	entities.ShipmentStatusInTransit,
+	entities.ShipmentStatusOutForDelivery,
+	entities.ShipmentStatusDelivered,
+}
+
+// StubClient эмулирует перевозчика для локальной разработки:
+// выдает трек-номер и через равные интервалы отправляет события трекинга.
+// Эмуляция живет до Shutdown, который прерывает ожидание следующих событий.
+type StubClient struct {
+	step    time.Duration
+	logger  *logrus.Logger
+	mu      sync.RWMutex
+	handler services.TrackingEventHandler
+
+	ctx      context.Context
+	stop     context.CancelFunc
+	emitters sync.WaitGroup
+}
+
+func NewStubClient(step time.Duration, logger *logrus.Logger) *StubClient {
+	ctx, stop := context.WithCancel(context.Background())
+
+	return &StubClient{
+		step:   step,
+		logger: logger,
+		ctx:    ctx,
+		stop:   stop,
+	}
+}
+
+// SetTrackingEventHandler задает обработчик событий трекинга.
+// Без обработчика заглушка только выдает трек-номера.
+func (c *StubClient) SetTrackingEventHandler(handler services.TrackingEventHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.handler = handler
+}
+
+func (c *StubClient) Name() string {
+	return StubCarrierName
+}
+
+func (c *StubClient) RegisterShipment(_ context.Context, shipment *entities.Shipment) (string, error) {
+	trackingNumber := fmt.Sprintf("STUB-%s", strings.ToUpper(uuid.NewString()[:13]))
+
+	c.logger.WithFields(logrus.Fields{
+		"shipment_id":     shipment.ID,
+		"tracking_number": trackingNumber,
+	}).Info("Stub carrier registered shipment")
+
+	return trackingNumber, nil
+}
+
+// StartTracking начинает эмулировать события по сохраненной отправке; после Shutdown ничего не делает
+func (c *StubClient) StartTracking(shipment *entities.Shipment) {
+	// Add под блокировкой, чтобы не разойтись с Wait в Shutdown
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ctx.Err() != nil {
+		return
+	}
+
+	c.emitters.Add(1)
+	go func() {
+		defer c.emitters.Done()
+		c.emitEvents(c.ctx, shipment.TrackingNumber)
+	}()
+}
+
+// Shutdown останавливает эмуляцию и ждет, пока обработаются уже отправленные события, но не дольше ctx
+func (c *StubClient) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	c.stop()
+	c.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		c.emitters.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *StubClient) emitEvents(ctx context.Context, trackingNumber string) {
+	for _, status := range stubProgression {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.step):
+		}
+
+		c.mu.RLock()
+		handler := c.handler
+		c.mu.RUnlock()
+
+		if handler == nil {
+			return
+		}
+
+		event := &services.TrackingEvent{
+			Carrier:        StubCarrierName,
+			TrackingNumber: trackingNumber,
+			Status:         status,
+			Location:       "Stub warehouse",
+			Description:    fmt.Sprintf("Shipment is %s", status),
+			OccurredAt:     time.Now(),
+		}
+
+		// отдельный контекст: начатая обработка события не обрывается остановкой на середине транзакции
+		if err := handler(context.WithoutCancel(ctx), event); err != nil {
+			c.logger.WithFields(logrus.Fields{
+				"tracking_number": trackingNumber,
+				"status":          status,
+				"error":           err.Error(),
+			}).Error("Stub carrier failed to deliver tracking event")
+			return
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package carriers
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStubClient(step time.Duration) *StubClient {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return NewStubClient(step, logger)
+}
+
+func TestStubClient_StartTracking_EmitsProgression(t *testing.T) {
+	client := newTestStubClient(time.Millisecond)
+
+	var mu sync.Mutex
+	var statuses []entities.ShipmentStatus
+	client.SetTrackingEventHandler(func(ctx context.Context, event *services.TrackingEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses = append(statuses, event.Status)
+		return nil
+	})
+
+	client.StartTracking(&entities.Shipment{TrackingNumber: "STUB-1"})
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(statuses) == len(stubProgression)
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, client.Shutdown(context.Background()))
+	assert.Equal(t, stubProgression, statuses)
+}
+
+func TestStubClient_Shutdown_StopsPendingEvents(t *testing.T) {
+	client := newTestStubClient(time.Hour)
+
+	called := false
+	client.SetTrackingEventHandler(func(ctx context.Context, event *services.TrackingEvent) error {
+		called = true
+		return nil
+	})
+
+	client.StartTracking(&entities.Shipment{TrackingNumber: "STUB-1"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, client.Shutdown(ctx))
+	assert.False(t, called)
+
+	// после остановки новые отправки не отслеживаются
+	client.StartTracking(&entities.Shipment{TrackingNumber: "STUB-2"})
+	assert.NoError(t, client.Shutdown(ctx))
+	assert.False(t, called)
+}
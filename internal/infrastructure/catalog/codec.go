@@ -0,0 +1,251 @@
+package catalog
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+)
+
+// Format - формат файла каталога
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Колонки CSV. Теги внутри ячейки разделяются вертикальной чертой.
+var csvColumns = []string{"sku", "description", "tags", "quantity", "price"}
+
+const (
+	tagSeparator = "|"
+
+	// Максимальная длина строки NDJSON
+	maxLineSize = 1 << 20
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatNDJSON, "jsonl", "json":
+		return FormatNDJSON, nil
+	}
+	return "", domainErrors.ErrCatalogFormatInvalid
+}
+
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// productRecord - представление товара в NDJSON
+type productRecord struct {
+	SKU         string   `json:"sku"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Quantity    int      `json:"quantity"`
+	Price       int64    `json:"price"`
+}
+
+// Decode читает строки импорта. Ошибки разбора отдельных строк сохраняются в DecodeErr,
+// а ошибка возвращается только если файл не удается прочитать целиком.
+func Decode(r io.Reader, format Format) ([]services.ProductImportRow, error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSV(r)
+	case FormatNDJSON:
+		return decodeNDJSON(r)
+	}
+	return nil, domainErrors.ErrCatalogFormatInvalid
+}
+
+func decodeCSV(r io.Reader) ([]services.ProductImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header must contain column %q", name)
+		}
+	}
+
+	var rows []services.ProductImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, services.ProductImportRow{Line: parseErr.StartLine, DecodeErr: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, csvRow(line, record, columns, len(header)))
+	}
+
+	return rows, nil
+}
+
+func csvRow(line int, record []string, columns map[string]int, width int) services.ProductImportRow {
+	row := services.ProductImportRow{Line: line}
+
+	if len(record) != width {
+		row.DecodeErr = fmt.Errorf("expected %d fields, got %d", width, len(record))
+		return row
+	}
+
+	field := func(name string) string {
+		return strings.TrimSpace(record[columns[name]])
+	}
+
+	row.SKU = field("sku")
+	row.Description = field("description")
+
+	if tags := field("tags"); tags != "" {
+		for _, tag := range strings.Split(tags, tagSeparator) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				row.Tags = append(row.Tags, tag)
+			}
+		}
+	}
+
+	quantity, err := strconv.Atoi(field("quantity"))
+	if err != nil {
+		row.DecodeErr = fmt.Errorf("invalid quantity %q", field("quantity"))
+		return row
+	}
+	row.Quantity = quantity
+
+	price, err := strconv.ParseInt(field("price"), 10, 64)
+	if err != nil {
+		row.DecodeErr = fmt.Errorf("invalid price %q", field("price"))
+		return row
+	}
+	row.Price = price
+
+	return row
+}
+
+func decodeNDJSON(r io.Reader) ([]services.ProductImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var rows []services.ProductImportRow
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record productRecord
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			rows = append(rows, services.ProductImportRow{Line: line, DecodeErr: fmt.Errorf("invalid json: %w", err)})
+			continue
+		}
+
+		rows = append(rows, services.ProductImportRow{
+			Line:        line,
+			SKU:         strings.TrimSpace(record.SKU),
+			Description: record.Description,
+			Tags:        record.Tags,
+			Quantity:    record.Quantity,
+			Price:       record.Price,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// Encoder записывает товары в выбранном формате по мере поступления
+type Encoder struct {
+	format  Format
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+}
+
+func NewEncoder(w io.Writer, format Format) *Encoder {
+	encoder := &Encoder{format: format}
+	if format == FormatCSV {
+		encoder.csv = csv.NewWriter(w)
+	} else {
+		encoder.json = json.NewEncoder(w)
+	}
+	return encoder
+}
+
+func (e *Encoder) Encode(product *entities.Product) error {
+	if e.format != FormatCSV {
+		return e.json.Encode(productRecord{
+			SKU:         product.SKU,
+			Description: product.Description,
+			Tags:        product.Tags,
+			Quantity:    product.Quantity,
+			Price:       product.Price,
+		})
+	}
+
+	if !e.started {
+		e.started = true
+		if err := e.csv.Write(csvColumns); err != nil {
+			return err
+		}
+	}
+
+	return e.csv.Write([]string{
+		product.SKU,
+		product.Description,
+		strings.Join(product.Tags, tagSeparator),
+		strconv.Itoa(product.Quantity),
+		strconv.FormatInt(product.Price, 10),
+	})
+}
+
+// Flush дописывает буферизованные данные; для пустого CSV выводит только заголовок
+func (e *Encoder) Flush() error {
+	if e.format != FormatCSV {
+		return nil
+	}
+
+	if !e.started {
+		e.started = true
+		if err := e.csv.Write(csvColumns); err != nil {
+			return err
+		}
+	}
+
+	e.csv.Flush()
+	return e.csv.Error()
+}
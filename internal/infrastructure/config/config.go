@@ -4,26 +4,90 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
 	Database DatabaseConfig
 	Server   ServerConfig
+	GRPC     GRPCConfig
+	GraphQL  GraphQLConfig
 	Logger   LoggerConfig
+	Carrier  CarrierConfig
+	Invoice  InvoiceConfig
+	Pricing  PricingConfig
+	Orders   OrdersConfig
+	Stock    StockConfig
+	Email    EmailConfig
+	Webhooks WebhooksConfig
+	Health   HealthConfig
+	Tracing  TracingConfig
 }
 
+// DatabaseConfig - подключение к Postgres; MigrateOnStart применяет миграции при запуске сервера
 type DatabaseConfig struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	DBName   string
-	SSLMode  string
+	Host           string
+	Port           int
+	User           string
+	Password       string `secret:"true"`
+	DBName         string
+	SSLMode        string
+	MigrateOnStart bool
+
+	// Пул соединений; нулевые значения оставляют настройки database/sql по умолчанию
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// StatementTimeout - statement_timeout сессии: Postgres прерывает запрос, работающий дольше
+	StatementTimeout time.Duration
+
+	// LogLevel - журнал SQL: silent, error, warn или info (все запросы, пишутся на уровне debug).
+	// Запросы дольше SlowQueryThreshold пишутся предупреждением, начиная с уровня warn.
+	LogLevel           string
+	SlowQueryThreshold time.Duration
+
+	// ConnectRetries - сколько раз повторить подключение при запуске, если Postgres еще не принимает
+	// соединения; пауза начинается с ConnectBackoff и удваивается после каждой попытки
+	ConnectRetries int
+	ConnectBackoff time.Duration
+
+	// ReplicaDSNs - реплики для чтения; без них все запросы идут в основную БД. ReplicaPolicy
+	// выбирает реплику для очередного чтения: round-robin или random. После записи заказа
+	// чтения этого клиента ReadYourWritesWindow идут в основную БД.
+	ReplicaDSNs          []string `secret:"true"`
+	ReplicaPolicy        string
+	ReadYourWritesWindow time.Duration
+	// ReplicaCheckInterval - как часто проверять реплики: не ответившая не получает чтений, пока снова не ответит
+	ReplicaCheckInterval time.Duration
 }
 
+// ServerConfig - HTTP-сервер. ShutdownTimeout - сколько при остановке ждать завершения начатых запросов
+// и фоновых задач; по истечении оставшиеся соединения закрываются.
 type ServerConfig struct {
-	Port string
-	Host string
+	Port              string
+	Host              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+}
+
+// GRPCConfig - gRPC API на отдельном порту; без AuthToken вызовы отклоняются
+type GRPCConfig struct {
+	Port      string
+	AuthToken string `secret:"true"`
+	// AllowUnauthenticated явно разрешает вызовы без токена - для локального запуска
+	AllowUnauthenticated bool
+}
+
+// GraphQLConfig - ограничения на запросы к /graphql, которые отсекаются до выполнения
+type GraphQLConfig struct {
+	MaxDepth      int
+	MaxComplexity int
 }
 
 type LoggerConfig struct {
@@ -31,6 +95,94 @@ type LoggerConfig struct {
 	Format string
 }
 
+type CarrierConfig struct {
+	// WebhookToken проверяется во входящих вебхуках трекинга; без него вебхуки отклоняются
+	WebhookToken string `secret:"true"`
+	// WebhookAllowUnauthenticated явно разрешает вебхуки без токена - для локального запуска с заглушкой
+	WebhookAllowUnauthenticated bool
+	StubStep                    time.Duration
+}
+
+type InvoiceConfig struct {
+	SellerName string
+}
+
+type PricingConfig struct {
+	// JobInterval - как часто запланированные цены переносятся в карточки товаров
+	JobInterval time.Duration
+}
+
+type StockConfig struct {
+	// AlertInterval - как часто отправляются накопленные уведомления об остатках
+	AlertInterval time.Duration
+}
+
+// EmailConfig - отправка писем покупателям. Sender: file (письма складываются в DropDir) или smtp
+type EmailConfig struct {
+	Sender       string
+	From         string
+	DropDir      string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string `secret:"true"`
+	// JobInterval - как часто отправляются письма из очереди
+	JobInterval time.Duration
+}
+
+// WebhooksConfig - исходящие вебхуки о событиях заказов
+type WebhooksConfig struct {
+	// JobInterval - как часто отправляются доставки из очереди, включая повторы
+	JobInterval time.Duration
+	// Timeout - сколько ждать ответа подписчика, после чего попытка считается неудачной
+	Timeout time.Duration
+}
+
+// HealthConfig - проверки готовности для /readyz
+type HealthConfig struct {
+	// CheckTimeout - сколько ждать одну проверку, после чего она считается неудачной
+	CheckTimeout time.Duration
+	// CacheTTL - сколько переиспользовать результат проверки, чтобы частые пробы не нагружали БД
+	CacheTTL time.Duration
+	// JobStallAfter - фоновая задача, не завершившая прогон за это время, считается зависшей
+	JobStallAfter time.Duration
+	// DetailedReport открывает подробный отчет по проверкам (/readyz?verbose=true) с текстами ошибок
+	DetailedReport bool
+}
+
+// TracingConfig - трассировка OpenTelemetry. Exporter: stdout, otlp-grpc, otlp-http или none
+type TracingConfig struct {
+	Exporter string
+	// Endpoint - адрес коллектора OTLP (http://otel-collector:4317); пустой - адрес экспортера по умолчанию
+	Endpoint string
+	// SampleRatio - доля записываемых новых трасс; трасса, начатая клиентом, следует его решению
+	SampleRatio    float64
+	ServiceName    string
+	ServiceVersion string
+}
+
+// OrdersConfig - ограничения и антифрод при создании заказа, нулевые значения отключают ограничения
+type OrdersConfig struct {
+	MaxQuantityPerProduct int
+	MaxQuantityPerUser    int
+	UserWindow            time.Duration
+	MaxOrderTotal         int64
+	MaxOrdersPerUser      int
+	VelocityWindow        time.Duration
+
+	RiskEnabled        bool
+	RiskHoldThreshold  float64
+	RiskNewAccountAge  time.Duration
+	RiskHighValueTotal int64
+
+	// ReservationTTL - через сколько неподтвержденный заказ отменяется
+	ReservationTTL time.Duration
+	// HoldTTL - через сколько отменяется заказ, который модератор так и не проверил; 0 - никогда
+	HoldTTL time.Duration
+	// ExpireInterval - как часто serve отменяет просроченные заказы; 0 - только командой expire-reservations
+	ExpireInterval time.Duration
+}
+
 func (db *DatabaseConfig) GetDSN() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		db.Host, db.Port, db.User, db.Password, db.DBName, db.SSLMode)
@@ -39,21 +191,112 @@ func (db *DatabaseConfig) GetDSN() string {
 func LoadConfig() *Config {
 	return &Config{
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnvInt("DB_PORT", 5432),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			DBName:   getEnv("DB_NAME", "orders"),
-			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+			Host:           getEnv("DB_HOST", "localhost"),
+			Port:           getEnvInt("DB_PORT", 5432),
+			User:           getEnv("DB_USER", "postgres"),
+			Password:       getEnv("DB_PASSWORD", "postgres"),
+			DBName:         getEnv("DB_NAME", "orders"),
+			SSLMode:        getEnv("DB_SSL_MODE", "disable"),
+			MigrateOnStart: getEnvBool("DB_MIGRATE_ON_START", true),
+
+			MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+			ConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+
+			StatementTimeout: getEnvDuration("DB_STATEMENT_TIMEOUT", 30*time.Second),
+
+			LogLevel:           getEnv("DB_LOG_LEVEL", "warn"),
+			SlowQueryThreshold: getEnvDuration("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+
+			ConnectRetries: getEnvInt("DB_CONNECT_RETRIES", 5),
+			ConnectBackoff: getEnvDuration("DB_CONNECT_BACKOFF", time.Second),
+
+			ReplicaDSNs:          getEnvList("DB_REPLICA_DSNS", ";"),
+			ReplicaPolicy:        getEnv("DB_REPLICA_POLICY", "round-robin"),
+			ReadYourWritesWindow: getEnvDuration("DB_READ_YOUR_WRITES_WINDOW", 5*time.Second),
+			ReplicaCheckInterval: getEnvDuration("DB_REPLICA_CHECK_INTERVAL", 10*time.Second),
 		},
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "8080"),
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
+			Port:              getEnv("SERVER_PORT", "8080"),
+			Host:              getEnv("SERVER_HOST", "0.0.0.0"),
+			ReadTimeout:       getEnvDuration("SERVER_READ_TIMEOUT", 30*time.Second),
+			ReadHeaderTimeout: getEnvDuration("SERVER_READ_HEADER_TIMEOUT", 5*time.Second),
+			WriteTimeout:      getEnvDuration("SERVER_WRITE_TIMEOUT", 60*time.Second),
+			IdleTimeout:       getEnvDuration("SERVER_IDLE_TIMEOUT", 120*time.Second),
+			ShutdownTimeout:   getEnvDuration("SERVER_SHUTDOWN_TIMEOUT", 30*time.Second),
+		},
+		GRPC: GRPCConfig{
+			Port:                 getEnv("GRPC_PORT", "9090"),
+			AuthToken:            getEnv("GRPC_AUTH_TOKEN", ""),
+			AllowUnauthenticated: getEnvBool("GRPC_ALLOW_UNAUTHENTICATED", false),
+		},
+		GraphQL: GraphQLConfig{
+			MaxDepth:      getEnvInt("GRAPHQL_MAX_DEPTH", 7),
+			MaxComplexity: getEnvInt("GRAPHQL_MAX_COMPLEXITY", 1000),
 		},
 		Logger: LoggerConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
 		},
+		Carrier: CarrierConfig{
+			WebhookToken:                getEnv("CARRIER_WEBHOOK_TOKEN", ""),
+			WebhookAllowUnauthenticated: getEnvBool("CARRIER_WEBHOOK_ALLOW_UNAUTHENTICATED", false),
+			StubStep:                    getEnvDuration("CARRIER_STUB_STEP", 30*time.Second),
+		},
+		Invoice: InvoiceConfig{
+			SellerName: getEnv("INVOICE_SELLER_NAME", "Orders Service"),
+		},
+		Pricing: PricingConfig{
+			JobInterval: getEnvDuration("PRICE_JOB_INTERVAL", time.Minute),
+		},
+		Orders: OrdersConfig{
+			MaxQuantityPerProduct: getEnvInt("ORDER_MAX_QUANTITY_PER_PRODUCT", 0),
+			MaxQuantityPerUser:    getEnvInt("ORDER_MAX_QUANTITY_PER_USER", 0),
+			UserWindow:            getEnvDuration("ORDER_USER_WINDOW", 24*time.Hour),
+			MaxOrderTotal:         getEnvInt64("ORDER_MAX_TOTAL", 0),
+			MaxOrdersPerUser:      getEnvInt("ORDER_MAX_PER_USER", 0),
+			VelocityWindow:        getEnvDuration("ORDER_VELOCITY_WINDOW", time.Hour),
+
+			RiskEnabled:        getEnvBool("RISK_SCORING_ENABLED", true),
+			RiskHoldThreshold:  getEnvFloat("RISK_HOLD_THRESHOLD", 0.7),
+			RiskNewAccountAge:  getEnvDuration("RISK_NEW_ACCOUNT_AGE", 24*time.Hour),
+			RiskHighValueTotal: getEnvInt64("RISK_HIGH_VALUE_TOTAL", 5_000_000),
+
+			ReservationTTL: getEnvDuration("ORDER_RESERVATION_TTL", 24*time.Hour),
+			HoldTTL:        getEnvDuration("ORDER_HOLD_TTL", 72*time.Hour),
+			ExpireInterval: getEnvDuration("ORDER_EXPIRE_INTERVAL", 5*time.Minute),
+		},
+		Stock: StockConfig{
+			AlertInterval: getEnvDuration("STOCK_ALERT_INTERVAL", 30*time.Second),
+		},
+		Email: EmailConfig{
+			Sender:       getEnv("EMAIL_SENDER", "file"),
+			From:         getEnv("EMAIL_FROM", "orders@localhost"),
+			DropDir:      getEnv("EMAIL_DROP_DIR", "tmp/emails"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     getEnvInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			JobInterval:  getEnvDuration("EMAIL_JOB_INTERVAL", 30*time.Second),
+		},
+		Webhooks: WebhooksConfig{
+			JobInterval: getEnvDuration("WEBHOOK_JOB_INTERVAL", 10*time.Second),
+			Timeout:     getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		},
+		Health: HealthConfig{
+			CheckTimeout:   getEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+			CacheTTL:       getEnvDuration("HEALTH_CACHE_TTL", 5*time.Second),
+			JobStallAfter:  getEnvDuration("HEALTH_JOB_STALL_AFTER", 15*time.Minute),
+			DetailedReport: getEnvBool("HEALTH_DETAILED_REPORT", false),
+		},
+		Tracing: TracingConfig{
+			Exporter:       getEnv("TRACING_EXPORTER", "none"),
+			Endpoint:       getEnv("TRACING_ENDPOINT", ""),
+			SampleRatio:    getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+			ServiceName:    getEnv("TRACING_SERVICE_NAME", "orders-service"),
+			ServiceVersion: getEnv("TRACING_SERVICE_VERSION", ""),
+		},
 	}
 }
 
@@ -72,3 +315,50 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+func getEnvInt64(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList делит значение по sep и отбрасывает пустые элементы; без значения возвращает nil
+func getEnvList(key, sep string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), sep) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
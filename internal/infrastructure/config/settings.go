@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const redacted = "[REDACTED]"
+
+// Setting - одно значение конфигурации, например Database.Host
+type Setting struct {
+	Key   string
+	Value string
+}
+
+// Settings раскладывает конфигурацию в плоский список в порядке объявления полей.
+// Поля с тегом secret:"true" скрываются, если заданы, чтобы вывод можно было приложить к тикету.
+func (c *Config) Settings() []Setting {
+	return collectSettings("", reflect.ValueOf(c).Elem())
+}
+
+func collectSettings(prefix string, value reflect.Value) []Setting {
+	var settings []Setting
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		fieldValue := value.Field(i)
+		key := prefix + field.Name
+
+		if fieldValue.Kind() == reflect.Struct {
+			settings = append(settings, collectSettings(key+".", fieldValue)...)
+			continue
+		}
+
+		text := fmt.Sprint(fieldValue.Interface())
+		// пустой список реплик печатается как [], но скрывать в нем нечего
+		if field.Tag.Get("secret") == "true" && !fieldValue.IsZero() {
+			text = redacted
+		}
+
+		settings = append(settings, Setting{Key: key, Value: text})
+	}
+
+	return settings
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Settings_RedactsSecrets(t *testing.T) {
+	cfg := &Config{
+		Database: DatabaseConfig{Host: "db.internal", Password: "s3cret", ReplicaDSNs: []string{"host=replica password=s3cret"}},
+		GRPC:     GRPCConfig{AuthToken: ""},
+		Email:    EmailConfig{SMTPPassword: "mail-pass", JobInterval: 30 * time.Second},
+	}
+
+	values := make(map[string]string)
+	for _, setting := range cfg.Settings() {
+		values[setting.Key] = setting.Value
+	}
+
+	assert.Equal(t, "db.internal", values["Database.Host"])
+	assert.Equal(t, redacted, values["Database.Password"])
+	assert.Equal(t, redacted, values["Database.ReplicaDSNs"])
+	assert.Equal(t, redacted, values["Email.SMTPPassword"])
+	assert.Equal(t, "30s", values["Email.JobInterval"])
+	// пустой секрет показывается как есть: видно, что токен не задан
+	assert.Equal(t, "", values["GRPC.AuthToken"])
+	assert.NotContains(t, values, "Database")
+}
@@ -1,45 +1,236 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/AndrivA89/orders/internal/infrastructure/config"
-	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
+	"github.com/AndrivA89/orders/internal/infrastructure/database/migrations"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+const (
+	// maxConnectBackoff ограничивает паузу между попытками подключения при запуске
+	maxConnectBackoff = 30 * time.Second
+	// replicaStartupCheckTimeout - сколько при запуске ждать ответа реплик, прежде чем считать их недоступными
+	replicaStartupCheckTimeout = 5 * time.Second
+)
+
 type Connection struct {
-	DB *gorm.DB
+	DB       *gorm.DB
+	Replicas []*gorm.DB
+	// Reads выбирает для запросов только на чтение реплику или основную БД
+	Reads *ReadRouter
+
+	log *logrus.Logger
+}
+
+// NewConnection подключается к основной БД и репликам с настройками пула и журнала из cfg.
+// Если основной Postgres еще не принимает соединения (контейнеры стартуют одновременно), подключение
+// повторяется ConnectRetries раз с растущей паузой, пока не будет отменен ctx. Реплики необязательны:
+// недоступная при запуске реплика не получает чтений, пока не ответит на проверку (см. MonitorReplicas).
+func NewConnection(ctx context.Context, cfg *config.DatabaseConfig, log *logrus.Logger) (*Connection, error) {
+	logLevel, err := parseLogLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+	// ошибка в политике видна сразу, а не после подключения ко всем репликам
+	if _, err := replicaPicker(cfg.ReplicaPolicy); err != nil {
+		return nil, err
+	}
+
+	db, err := open(ctx, cfg.GetDSN(), cfg, newSQLLogger(log, logLevel, cfg.SlowQueryThreshold), cfg.ConnectRetries, log)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &Connection{DB: db, log: log}
+	for i, dsn := range cfg.ReplicaDSNs {
+		// пул реплики создается без ожидания: соединения откроются, когда она станет доступна
+		replica, err := open(ctx, dsn, cfg, newSQLLogger(log, logLevel, cfg.SlowQueryThreshold), -1, log)
+		if err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("configure replica %d: %w", i+1, err)
+		}
+		conn.Replicas = append(conn.Replicas, replica)
+	}
+
+	conn.Reads, err = NewReadRouter(db, conn.Replicas, cfg.ReplicaPolicy, cfg.ReadYourWritesWindow)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, replicaStartupCheckTimeout)
+	conn.checkReplicas(checkCtx)
+	cancel()
+
+	return conn, nil
 }
 
-func NewConnection(cfg *config.DatabaseConfig) (*Connection, error) {
-	dsn := cfg.GetDSN()
+// open создает пул с настройками из cfg и ждет, пока БД ответит, повторяя проверку retries раз;
+// отрицательный retries открывает пул без проверки
+func open(
+	ctx context.Context,
+	dsn string,
+	cfg *config.DatabaseConfig,
+	sqlLogger logger.Interface,
+	retries int,
+	log *logrus.Logger,
+) (*gorm.DB, error) {
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	// параметр передается при установке соединения и действует на всю сессию, в том числе после RESET
+	if cfg.StatementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+
+	sqlDB := stdlib.OpenDB(*connConfig)
+	if cfg.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+	if retries >= 0 {
+		if err := waitForDB(ctx, sqlDB, retries, cfg.ConnectBackoff, log); err != nil {
+			_ = sqlDB.Close()
+			return nil, err
+		}
+	}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger:               sqlLogger,
+		DisableAutomaticPing: true,
 	})
 	if err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+
+	// SQL-запросы попадают в трассу дочерними спанами запроса или вызова сервиса
+	if err := db.Use(newTracingPlugin(otel.GetTracerProvider())); err != nil {
+		_ = sqlDB.Close()
 		return nil, err
 	}
 
-	return &Connection{DB: db}, nil
+	return db, nil
 }
 
-func (c *Connection) AutoMigrate() error {
-	return c.DB.AutoMigrate(
-		&models.UserModel{},
-		&models.ProductModel{},
-		&models.OrderModel{},
-		&models.OrderItemModel{},
-	)
+// waitForDB проверяет соединение и при неудаче повторяет попытку retries раз, удваивая паузу
+func waitForDB(ctx context.Context, sqlDB *sql.DB, retries int, backoff time.Duration, log *logrus.Logger) error {
+	for attempt := 1; ; attempt++ {
+		err := sqlDB.PingContext(ctx)
+		if err == nil || attempt > retries {
+			return err
+		}
+
+		log.Warnf("Database is not ready, retrying in %s (attempt %d of %d): %v", backoff, attempt, retries, err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
 }
 
-func (c *Connection) Close() error {
+// Migrator возвращает применятор встроенных SQL-миграций для этого подключения
+func (c *Connection) Migrator(log *logrus.Logger) (*migrations.Migrator, error) {
+	sqlDB, err := c.DB.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	return migrations.NewMigrator(sqlDB, log)
+}
+
+// Ping проверяет, что БД отвечает, для проверки готовности
+func (c *Connection) Ping(ctx context.Context) error {
 	sqlDB, err := c.DB.DB()
 	if err != nil {
 		return err
 	}
 
-	return sqlDB.Close()
+	return sqlDB.PingContext(ctx)
+}
+
+// PingReplica проверяет реплику с номером i (с нуля). Не ответившая реплика перестает получать
+// чтения, ответившая снова - возвращается в ротацию.
+func (c *Connection) PingReplica(ctx context.Context, i int) error {
+	sqlDB, err := c.Replicas[i].DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+
+	if c.Reads.SetReplicaHealthy(i, err == nil) {
+		if err != nil {
+			c.log.WithError(err).Warnf("Replica %d is unavailable, its reads go to other replicas or the primary", i+1)
+		} else {
+			c.log.Infof("Replica %d is available again", i+1)
+		}
+	}
+
+	return err
+}
+
+// MonitorReplicas проверяет реплики каждые interval, пока не отменен ctx
+func (c *Connection) MonitorReplicas(ctx context.Context, interval time.Duration) {
+	if len(c.Replicas) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		c.checkReplicas(checkCtx)
+		cancel()
+	}
+}
+
+func (c *Connection) checkReplicas(ctx context.Context) {
+	for i := range c.Replicas {
+		_ = c.PingReplica(ctx, i)
+	}
+}
+
+// Close закрывает основную БД и реплики; возвращает первую ошибку
+func (c *Connection) Close() error {
+	var firstErr error
+	for _, db := range append([]*gorm.DB{c.DB}, c.Replicas...) {
+		sqlDB, err := db.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
 }
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unreachableDB - пул к порту, на котором никто не слушает: каждая попытка сразу получает отказ
+func unreachableDB(t *testing.T) *sql.DB {
+	connConfig, err := pgx.ParseConfig("host=127.0.0.1 port=1 user=postgres dbname=orders connect_timeout=1")
+	require.NoError(t, err)
+
+	db := stdlib.OpenDB(*connConfig)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestWaitForDB_RetriesThenFails(t *testing.T) {
+	log, hook := test.NewNullLogger()
+
+	err := waitForDB(context.Background(), unreachableDB(t), 2, time.Millisecond, log)
+
+	assert.Error(t, err)
+	assert.Len(t, hook.AllEntries(), 2)
+}
+
+func TestWaitForDB_StopsOnCancel(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	err := waitForDB(ctx, unreachableDB(t), 10, time.Minute, log)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), 5*time.Second)
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// parseLogLevel переводит уровень журнала SQL из конфигурации; пустая строка означает warn
+func parseLogLevel(level string) (logger.LogLevel, error) {
+	switch strings.ToLower(level) {
+	case "silent":
+		return logger.Silent, nil
+	case "error":
+		return logger.Error, nil
+	case "warn", "":
+		return logger.Warn, nil
+	case "info":
+		return logger.Info, nil
+	default:
+		return 0, fmt.Errorf("unknown SQL log level %q: expected silent, error, warn or info", level)
+	}
+}
+
+// sqlLogger пишет журнал GORM в logrus сервиса, чтобы SQL попадал в тот же поток и формат, что и остальные записи.
+// Обычные запросы пишутся на уровне debug: даже с DB_LOG_LEVEL=info они не заполняют журнал, пока не включен LOG_LEVEL=debug.
+type sqlLogger struct {
+	log           *logrus.Logger
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+func newSQLLogger(log *logrus.Logger, level logger.LogLevel, slowThreshold time.Duration) logger.Interface {
+	return &sqlLogger{log: log, level: level, slowThreshold: slowThreshold}
+}
+
+func (l *sqlLogger) LogMode(level logger.LogLevel) logger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *sqlLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Info {
+		l.log.WithContext(ctx).Infof(msg, data...)
+	}
+}
+
+func (l *sqlLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Warn {
+		l.log.WithContext(ctx).Warnf(msg, data...)
+	}
+}
+
+func (l *sqlLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Error {
+		l.log.WithContext(ctx).Errorf(msg, data...)
+	}
+}
+
+func (l *sqlLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+
+	switch {
+	// отсутствие записи - обычный ответ для First, сервисы превращают его в 404
+	case err != nil && l.level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		l.entry(ctx, elapsed, fc).WithError(err).Error("SQL query failed")
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= logger.Warn:
+		l.entry(ctx, elapsed, fc).WithField("slow_threshold_ms", l.slowThreshold.Milliseconds()).Warn("Slow SQL query")
+	case l.level >= logger.Info:
+		l.entry(ctx, elapsed, fc).Debug("SQL query")
+	}
+}
+
+func (l *sqlLogger) entry(ctx context.Context, elapsed time.Duration, fc func() (string, int64)) *logrus.Entry {
+	sql, rows := fc()
+
+	return l.log.WithContext(ctx).WithFields(logrus.Fields{
+		"sql":         sql,
+		"rows":        rows,
+		"duration_ms": float64(elapsed.Nanoseconds()) / 1000000.0,
+	})
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSQLLogger_Trace(t *testing.T) {
+	query := func() (string, int64) { return "SELECT 1", 1 }
+
+	tests := []struct {
+		name    string
+		level   logger.LogLevel
+		elapsed time.Duration
+		err     error
+		want    []logrus.Level
+	}{
+		{name: "fast query at warn is skipped", level: logger.Warn, elapsed: time.Millisecond},
+		{name: "slow query at warn", level: logger.Warn, elapsed: time.Second, want: []logrus.Level{logrus.WarnLevel}},
+		{name: "slow query at error is skipped", level: logger.Error, elapsed: time.Second},
+		{name: "failed query", level: logger.Error, err: errors.New("boom"), want: []logrus.Level{logrus.ErrorLevel}},
+		{name: "record not found is not an error", level: logger.Info, err: gorm.ErrRecordNotFound, want: []logrus.Level{logrus.DebugLevel}},
+		{name: "info writes every query at debug", level: logger.Info, elapsed: time.Millisecond, want: []logrus.Level{logrus.DebugLevel}},
+		{name: "silent", level: logger.Silent, err: errors.New("boom")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, hook := test.NewNullLogger()
+			log.SetLevel(logrus.DebugLevel)
+
+			sqlLog := newSQLLogger(log, tt.level, 200*time.Millisecond)
+			sqlLog.Trace(context.Background(), time.Now().Add(-tt.elapsed), query, tt.err)
+
+			var levels []logrus.Level
+			for _, entry := range hook.AllEntries() {
+				levels = append(levels, entry.Level)
+				assert.Equal(t, "SELECT 1", entry.Data["sql"])
+			}
+			assert.Equal(t, tt.want, levels)
+		})
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	level, err := parseLogLevel("")
+	assert.NoError(t, err)
+	assert.Equal(t, logger.Warn, level)
+
+	level, err = parseLogLevel("INFO")
+	assert.NoError(t, err)
+	assert.Equal(t, logger.Info, level)
+
+	_, err = parseLogLevel("verbose")
+	assert.Error(t, err)
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "order_item_models";
+DROP TABLE IF EXISTS "order_models";
+DROP TABLE IF EXISTS "product_variant_models";
+DROP TABLE IF EXISTS "product_models";
+DROP TABLE IF EXISTS "user_models";
@@ -0,0 +1,97 @@
+-- Пользователи, товары с вариантами и заказы
+
+CREATE TABLE IF NOT EXISTS "user_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "first_name" varchar(100) NOT NULL,
+    "last_name" varchar(100) NOT NULL,
+    "email" varchar(255),
+    "language" varchar(5) NOT NULL DEFAULT 'ru',
+    "age" bigint NOT NULL,
+    "is_married" boolean DEFAULT false,
+    "password" varchar(255) NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_user_models_deleted_at" ON "user_models" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "product_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "sku" varchar(64),
+    "name" varchar(255) NOT NULL DEFAULT '',
+    "description" varchar(500) NOT NULL,
+    "tags" JSONB,
+    "attributes" JSONB,
+    "quantity" bigint NOT NULL DEFAULT 0,
+    "price" bigint NOT NULL,
+    "stock_policy" varchar(20) NOT NULL DEFAULT 'in_stock',
+    "expected_at" timestamptz,
+    "low_stock_threshold" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_product_models_deleted_at" ON "product_models" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_product_models_quantity" ON "product_models" ("quantity");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_product_models_sku" ON "product_models" ("sku");
+
+CREATE TABLE IF NOT EXISTS "product_variant_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "product_id" uuid NOT NULL,
+    "sku" varchar(64) NOT NULL,
+    "attributes" JSONB NOT NULL,
+    "price" bigint NOT NULL,
+    "quantity" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_product_models_variants" FOREIGN KEY ("product_id") REFERENCES "product_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_product_variant_models_deleted_at" ON "product_variant_models" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_product_variant_models_quantity" ON "product_variant_models" ("quantity");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_product_variant_models_sku" ON "product_variant_models" ("sku");
+CREATE INDEX IF NOT EXISTS "idx_product_variant_models_product_id" ON "product_variant_models" ("product_id");
+
+CREATE TABLE IF NOT EXISTS "order_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "user_id" uuid NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'pending',
+    "total" bigint NOT NULL DEFAULT 0,
+    "risk_score" decimal NOT NULL DEFAULT 0,
+    "hold_reason" varchar(500),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user_models_orders" FOREIGN KEY ("user_id") REFERENCES "user_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_order_models_deleted_at" ON "order_models" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_order_models_created_at" ON "order_models" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_order_models_status_created_at" ON "order_models" ("status","created_at");
+CREATE INDEX IF NOT EXISTS "idx_order_models_user_id" ON "order_models" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "order_item_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "order_id" uuid NOT NULL,
+    "product_id" uuid NOT NULL,
+    "variant_id" uuid,
+    "product_snapshot" JSONB NOT NULL,
+    "quantity" bigint NOT NULL,
+    "backordered" bigint NOT NULL DEFAULT 0,
+    "expected_at" timestamptz,
+    "price_per_item" bigint NOT NULL,
+    "total" bigint NOT NULL,
+    "created_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_order_item_models_product" FOREIGN KEY ("product_id") REFERENCES "product_models"("id"),
+    CONSTRAINT "fk_order_models_items" FOREIGN KEY ("order_id") REFERENCES "order_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_order_item_models_deleted_at" ON "order_item_models" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_order_item_models_variant_id" ON "order_item_models" ("variant_id");
+CREATE INDEX IF NOT EXISTS "idx_order_item_models_backordered" ON "order_item_models" ("product_id") WHERE backordered > 0;
+CREATE INDEX IF NOT EXISTS "idx_order_item_models_product_id" ON "order_item_models" ("product_id");
+CREATE INDEX IF NOT EXISTS "idx_order_item_models_order_id" ON "order_item_models" ("order_id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "shipment_event_models";
+DROP TABLE IF EXISTS "shipment_item_models";
+DROP TABLE IF EXISTS "shipment_models";
@@ -0,0 +1,43 @@
+-- Отгрузки и события трекинга
+
+CREATE TABLE IF NOT EXISTS "shipment_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "order_id" uuid NOT NULL,
+    "carrier" varchar(50) NOT NULL,
+    "tracking_number" varchar(100) NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'created',
+    "delivered_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_shipment_models_order" FOREIGN KEY ("order_id") REFERENCES "order_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_shipment_models_deleted_at" ON "shipment_models" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_shipments_carrier_tracking" ON "shipment_models" ("carrier","tracking_number");
+CREATE INDEX IF NOT EXISTS "idx_shipment_models_order_id" ON "shipment_models" ("order_id");
+
+CREATE TABLE IF NOT EXISTS "shipment_item_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "shipment_id" uuid NOT NULL,
+    "order_item_id" uuid NOT NULL,
+    "quantity" bigint NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_shipment_item_models_order_item" FOREIGN KEY ("order_item_id") REFERENCES "order_item_models"("id"),
+    CONSTRAINT "fk_shipment_models_items" FOREIGN KEY ("shipment_id") REFERENCES "shipment_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_shipment_item_models_order_item_id" ON "shipment_item_models" ("order_item_id");
+CREATE INDEX IF NOT EXISTS "idx_shipment_item_models_shipment_id" ON "shipment_item_models" ("shipment_id");
+
+CREATE TABLE IF NOT EXISTS "shipment_event_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "shipment_id" uuid NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "location" varchar(255),
+    "description" varchar(500),
+    "occurred_at" timestamptz NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_shipment_models_events" FOREIGN KEY ("shipment_id") REFERENCES "shipment_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_shipment_event_models_shipment_id" ON "shipment_event_models" ("shipment_id");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "return_item_models";
+DROP TABLE IF EXISTS "return_request_models";
@@ -0,0 +1,39 @@
+-- Возвраты
+
+CREATE TABLE IF NOT EXISTS "return_request_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "order_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'requested',
+    "refund_amount" bigint NOT NULL DEFAULT 0,
+    "review_comment" varchar(500),
+    "reviewed_at" timestamptz,
+    "received_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_return_request_models_order" FOREIGN KEY ("order_id") REFERENCES "order_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_return_request_models_deleted_at" ON "return_request_models" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_return_request_models_user_id" ON "return_request_models" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_return_request_models_order_id" ON "return_request_models" ("order_id");
+
+CREATE TABLE IF NOT EXISTS "return_item_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "return_request_id" uuid NOT NULL,
+    "order_item_id" uuid NOT NULL,
+    "product_id" uuid NOT NULL,
+    "variant_id" uuid,
+    "quantity" bigint NOT NULL,
+    "reason" varchar(30) NOT NULL,
+    "comment" varchar(500),
+    "price_per_item" bigint NOT NULL,
+    "refund_amount" bigint NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_return_item_models_order_item" FOREIGN KEY ("order_item_id") REFERENCES "order_item_models"("id"),
+    CONSTRAINT "fk_return_request_models_items" FOREIGN KEY ("return_request_id") REFERENCES "return_request_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_return_item_models_product_id" ON "return_item_models" ("product_id");
+CREATE INDEX IF NOT EXISTS "idx_return_item_models_order_item_id" ON "return_item_models" ("order_item_id");
+CREATE INDEX IF NOT EXISTS "idx_return_item_models_return_request_id" ON "return_item_models" ("return_request_id");
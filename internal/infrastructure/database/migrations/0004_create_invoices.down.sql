@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "invoice_sequence_models";
+DROP TABLE IF EXISTS "invoice_document_models";
+DROP TABLE IF EXISTS "invoice_models";
@@ -0,0 +1,38 @@
+-- Счета и их нумерация
+
+CREATE TABLE IF NOT EXISTS "invoice_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "order_id" uuid NOT NULL,
+    "number" varchar(30) NOT NULL,
+    "year" bigint NOT NULL,
+    "sequence" bigint NOT NULL,
+    "seller_name" varchar(255) NOT NULL,
+    "customer_id" uuid NOT NULL,
+    "customer_name" varchar(255) NOT NULL,
+    "lines" JSONB NOT NULL,
+    "total" bigint NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "issued_at" timestamptz NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_invoice_models_order" FOREIGN KEY ("order_id") REFERENCES "order_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_invoice_models_customer_id" ON "invoice_models" ("customer_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_invoices_year_sequence" ON "invoice_models" ("year","sequence");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_invoice_models_number" ON "invoice_models" ("number");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_invoice_models_order_id" ON "invoice_models" ("order_id");
+
+CREATE TABLE IF NOT EXISTS "invoice_document_models" (
+    "invoice_id" uuid,
+    "content_type" varchar(100) NOT NULL,
+    "content" bytea NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("invoice_id"),
+    CONSTRAINT "fk_invoice_models_document" FOREIGN KEY ("invoice_id") REFERENCES "invoice_models"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "invoice_sequence_models" (
+    "year" bigint,
+    "last_number" bigint NOT NULL,
+    PRIMARY KEY ("year")
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "price_change_models";
+DROP TABLE IF EXISTS "product_category_models";
+DROP TABLE IF EXISTS "category_models";
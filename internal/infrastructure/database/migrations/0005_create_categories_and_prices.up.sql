@@ -0,0 +1,38 @@
+-- Категории каталога и запланированные цены
+
+CREATE TABLE IF NOT EXISTS "category_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "parent_id" uuid,
+    "name" varchar(255) NOT NULL,
+    "slug" varchar(100) NOT NULL,
+    "position" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_category_models_parent" FOREIGN KEY ("parent_id") REFERENCES "category_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_category_models_deleted_at" ON "category_models" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_category_models_slug" ON "category_models" ("slug");
+CREATE INDEX IF NOT EXISTS "idx_category_models_parent_id" ON "category_models" ("parent_id");
+
+CREATE TABLE IF NOT EXISTS "product_category_models" (
+    "product_id" uuid,
+    "category_id" uuid,
+    PRIMARY KEY ("product_id","category_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_product_category_models_category_id" ON "product_category_models" ("category_id");
+
+CREATE TABLE IF NOT EXISTS "price_change_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "product_id" uuid NOT NULL,
+    "price" bigint NOT NULL,
+    "valid_from" timestamptz NOT NULL,
+    "valid_to" timestamptz,
+    "applied_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_price_change_models_product" FOREIGN KEY ("product_id") REFERENCES "product_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_price_change_models_pending" ON "price_change_models" ("valid_from") WHERE applied_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_price_change_models_product_valid_from" ON "price_change_models" ("product_id","valid_from");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "stock_notification_models";
+DROP TABLE IF EXISTS "stock_subscription_models";
@@ -0,0 +1,34 @@
+-- Подписки на поступление и уведомления об остатках
+
+CREATE TABLE IF NOT EXISTS "stock_subscription_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "user_id" uuid NOT NULL,
+    "product_id" uuid NOT NULL,
+    "variant_id" uuid,
+    "created_at" timestamptz,
+    "notified_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_stock_subscription_models_user" FOREIGN KEY ("user_id") REFERENCES "user_models"("id"),
+    CONSTRAINT "fk_stock_subscription_models_product" FOREIGN KEY ("product_id") REFERENCES "product_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_stock_subscription_models_open" ON "stock_subscription_models" ("product_id") WHERE notified_at IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_stock_subscription_models_user_id" ON "stock_subscription_models" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "stock_notification_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "kind" varchar(20) NOT NULL,
+    "user_id" uuid,
+    "product_id" uuid NOT NULL,
+    "variant_id" uuid,
+    "sku" varchar(64),
+    "product_name" varchar(500) NOT NULL,
+    "quantity" bigint NOT NULL,
+    "threshold" bigint NOT NULL DEFAULT 0,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "last_error" varchar(1000),
+    "created_at" timestamptz,
+    "sent_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_stock_notification_models_pending" ON "stock_notification_models" ("created_at") WHERE sent_at IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_stock_notification_models_product_id" ON "stock_notification_models" ("product_id");
@@ -0,0 +1,22 @@
+-- Очередь писем покупателям
+
+CREATE TABLE IF NOT EXISTS "email_delivery_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "event" varchar(30) NOT NULL,
+    "order_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "recipient" varchar(255) NOT NULL,
+    "language" varchar(5) NOT NULL,
+    "subject" varchar(255),
+    "status" varchar(20) NOT NULL,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "last_error" varchar(1000),
+    "next_attempt_at" timestamptz NOT NULL,
+    "created_at" timestamptz,
+    "sent_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_email_delivery_models_order" FOREIGN KEY ("order_id") REFERENCES "order_models"("id"),
+    CONSTRAINT "fk_email_delivery_models_user" FOREIGN KEY ("user_id") REFERENCES "user_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_email_delivery_models_due" ON "email_delivery_models" ("next_attempt_at") WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS "idx_email_delivery_models_order_id" ON "email_delivery_models" ("order_id");
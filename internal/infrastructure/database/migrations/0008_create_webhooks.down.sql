@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "webhook_delivery_models";
+DROP TABLE IF EXISTS "webhook_subscription_models";
@@ -0,0 +1,32 @@
+-- Подписки на вебхуки и журнал доставок
+
+CREATE TABLE IF NOT EXISTS "webhook_subscription_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "url" varchar(2048) NOT NULL,
+    "events" JSONB NOT NULL,
+    "secret" varchar(255) NOT NULL,
+    "active" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "webhook_delivery_models" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "subscription_id" uuid NOT NULL,
+    "event" varchar(30) NOT NULL,
+    "order_id" uuid NOT NULL,
+    "payload" JSONB NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "response_status" bigint NOT NULL DEFAULT 0,
+    "last_error" varchar(1000),
+    "next_attempt_at" timestamptz NOT NULL,
+    "replay_of" uuid,
+    "created_at" timestamptz,
+    "delivered_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_webhook_delivery_models_subscription" FOREIGN KEY ("subscription_id") REFERENCES "webhook_subscription_models"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_models_due" ON "webhook_delivery_models" ("next_attempt_at") WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_models_log" ON "webhook_delivery_models" ("subscription_id","created_at");
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_order_models_status_reserved_at";
+CREATE INDEX IF NOT EXISTS "idx_order_models_status_created_at" ON "order_models" ("status","created_at");
+
+ALTER TABLE "order_models" DROP COLUMN IF EXISTS "reserved_at";
@@ -0,0 +1,8 @@
+-- Начало резерва заказа: срок неподтвержденного заказа отсчитывается от него, а не от создания,
+-- потому что заказ, возвращенный модератором в работу, получает резерв заново
+
+ALTER TABLE "order_models" ADD COLUMN IF NOT EXISTS "reserved_at" timestamptz;
+UPDATE "order_models" SET "reserved_at" = "created_at" WHERE "reserved_at" IS NULL;
+
+DROP INDEX IF EXISTS "idx_order_models_status_created_at";
+CREATE INDEX IF NOT EXISTS "idx_order_models_status_reserved_at" ON "order_models" ("status","reserved_at");
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var createTablePattern = regexp.MustCompile(`CREATE TABLE IF NOT EXISTS "(\w+)"`)
+
+func TestLoad_VersionsAreSequentialAndReversible(t *testing.T) {
+	migrations, err := Load()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		assert.Equal(t, int64(i+1), migration.Version, "versions must go without gaps")
+
+		// каждая созданная таблица должна удаляться откатом той же миграции
+		for _, match := range createTablePattern.FindAllStringSubmatch(migration.Up, -1) {
+			assert.True(t, strings.Contains(migration.Down, `DROP TABLE IF EXISTS "`+match[1]+`"`),
+				"migration %d_%s does not drop %s", migration.Version, migration.Name, match[1])
+		}
+	}
+}
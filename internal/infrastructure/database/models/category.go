@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CategoryModel struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ParentID  *uuid.UUID     `gorm:"type:uuid;index" json:"parent_id"`
+	Name      string         `gorm:"column:name;not null;size:255" json:"name"`
+	Slug      string         `gorm:"column:slug;not null;size:100;uniqueIndex" json:"slug"`
+	Position  int            `gorm:"column:position;not null;default:0" json:"position"`
+	CreatedAt time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	Parent *CategoryModel `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
+}
+
+// ProductCategoryModel - связь товара с категорией (many-to-many)
+type ProductCategoryModel struct {
+	ProductID  uuid.UUID `gorm:"type:uuid;primaryKey" json:"product_id"`
+	CategoryID uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"category_id"`
+}
+
+func (c *CategoryModel) ToEntity() *entities.Category {
+	return &entities.Category{
+		ID:        c.ID,
+		ParentID:  c.ParentID,
+		Name:      c.Name,
+		Slug:      c.Slug,
+		Position:  c.Position,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func (c *CategoryModel) FromEntity(entity *entities.Category) {
+	c.ID = entity.ID
+	c.ParentID = entity.ParentID
+	c.Name = entity.Name
+	c.Slug = entity.Slug
+	c.Position = entity.Position
+	c.CreatedAt = entity.CreatedAt
+	c.UpdatedAt = entity.UpdatedAt
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// EmailDeliveryModel - журнал доставки писем о заказах. Частичный индекс
+// покрывает выборку писем, ожидающих отправки.
+type EmailDeliveryModel struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Event         string     `gorm:"column:event;not null;size:30" json:"event"`
+	OrderID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"order_id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	Recipient     string     `gorm:"column:recipient;not null;size:255" json:"recipient"`
+	Language      string     `gorm:"column:language;not null;size:5" json:"language"`
+	Subject       string     `gorm:"column:subject;size:255" json:"subject"`
+	Status        string     `gorm:"column:status;not null;size:20" json:"status"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	LastError     string     `gorm:"column:last_error;size:1000" json:"last_error"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null;index:idx_email_delivery_models_due,where:status = 'pending'" json:"next_attempt_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at" json:"created_at"`
+	SentAt        *time.Time `gorm:"column:sent_at" json:"sent_at"`
+
+	Order OrderModel `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+	User  UserModel  `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (d *EmailDeliveryModel) ToEntity() *entities.EmailDelivery {
+	return &entities.EmailDelivery{
+		ID:            d.ID,
+		Event:         entities.OrderEvent(d.Event),
+		OrderID:       d.OrderID,
+		UserID:        d.UserID,
+		Recipient:     d.Recipient,
+		Language:      d.Language,
+		Subject:       d.Subject,
+		Status:        entities.EmailDeliveryStatus(d.Status),
+		Attempts:      d.Attempts,
+		LastError:     d.LastError,
+		NextAttemptAt: d.NextAttemptAt,
+		CreatedAt:     d.CreatedAt,
+		SentAt:        d.SentAt,
+	}
+}
+
+func (d *EmailDeliveryModel) FromEntity(entity *entities.EmailDelivery) {
+	d.ID = entity.ID
+	d.Event = string(entity.Event)
+	d.OrderID = entity.OrderID
+	d.UserID = entity.UserID
+	d.Recipient = entity.Recipient
+	d.Language = entity.Language
+	d.Subject = entity.Subject
+	d.Status = string(entity.Status)
+	d.Attempts = entity.Attempts
+	d.LastError = entity.LastError
+	d.NextAttemptAt = entity.NextAttemptAt
+	d.CreatedAt = entity.CreatedAt
+	d.SentAt = entity.SentAt
+}
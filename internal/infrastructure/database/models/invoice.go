@@ -0,0 +1,92 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+type InvoiceModel struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID      uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex" json:"order_id"`
+	Number       string         `gorm:"column:number;not null;size:30;uniqueIndex" json:"number"`
+	Year         int            `gorm:"column:year;not null;uniqueIndex:idx_invoices_year_sequence" json:"year"`
+	Sequence     int            `gorm:"column:sequence;not null;uniqueIndex:idx_invoices_year_sequence" json:"sequence"`
+	SellerName   string         `gorm:"column:seller_name;not null;size:255" json:"seller_name"`
+	CustomerID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"customer_id"`
+	CustomerName string         `gorm:"column:customer_name;not null;size:255" json:"customer_name"`
+	Lines        datatypes.JSON `gorm:"column:lines;type:json;not null" json:"lines"`
+	Total        int64          `gorm:"column:total;not null" json:"total"`
+	Currency     string         `gorm:"column:currency;not null;size:3" json:"currency"`
+	IssuedAt     time.Time      `gorm:"column:issued_at;not null" json:"issued_at"`
+	CreatedAt    time.Time      `gorm:"column:created_at" json:"created_at"`
+
+	Order    OrderModel           `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+	Document InvoiceDocumentModel `gorm:"foreignKey:InvoiceID" json:"document,omitempty"`
+}
+
+// InvoiceDocumentModel хранит отрисованный PDF отдельно, чтобы не читать его вместе со счетом
+type InvoiceDocumentModel struct {
+	InvoiceID   uuid.UUID `gorm:"type:uuid;primary_key" json:"invoice_id"`
+	ContentType string    `gorm:"column:content_type;not null;size:100" json:"content_type"`
+	Content     []byte    `gorm:"column:content;type:bytea;not null" json:"-"`
+	CreatedAt   time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+// InvoiceSequenceModel - счетчик номеров счетов по годам
+type InvoiceSequenceModel struct {
+	Year       int `gorm:"column:year;primary_key;autoIncrement:false" json:"year"`
+	LastNumber int `gorm:"column:last_number;not null" json:"last_number"`
+}
+
+func (i *InvoiceModel) ToEntity() (*entities.Invoice, error) {
+	var lines []entities.InvoiceLine
+	if err := json.Unmarshal(i.Lines, &lines); err != nil {
+		return nil, err
+	}
+
+	return &entities.Invoice{
+		ID:         i.ID,
+		OrderID:    i.OrderID,
+		Number:     i.Number,
+		Year:       i.Year,
+		Sequence:   i.Sequence,
+		SellerName: i.SellerName,
+		Customer: entities.InvoiceParty{
+			ID:       i.CustomerID,
+			FullName: i.CustomerName,
+		},
+		Lines:     lines,
+		Total:     i.Total,
+		Currency:  i.Currency,
+		IssuedAt:  i.IssuedAt,
+		CreatedAt: i.CreatedAt,
+	}, nil
+}
+
+func (i *InvoiceModel) FromEntity(entity *entities.Invoice) error {
+	lines, err := json.Marshal(entity.Lines)
+	if err != nil {
+		return err
+	}
+
+	i.ID = entity.ID
+	i.OrderID = entity.OrderID
+	i.Number = entity.Number
+	i.Year = entity.Year
+	i.Sequence = entity.Sequence
+	i.SellerName = entity.SellerName
+	i.CustomerID = entity.Customer.ID
+	i.CustomerName = entity.Customer.FullName
+	i.Lines = lines
+	i.Total = entity.Total
+	i.Currency = entity.Currency
+	i.IssuedAt = entity.IssuedAt
+	i.CreatedAt = entity.CreatedAt
+
+	return nil
+}
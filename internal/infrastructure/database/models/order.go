@@ -12,13 +12,16 @@ import (
 )
 
 type OrderModel struct {
-	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	UserID    uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
-	Status    string         `gorm:"column:status;not null;size:20;default:'pending'" json:"status"`
-	Total     int64          `gorm:"column:total;not null;default:0" json:"total"`
-	CreatedAt time.Time      `gorm:"column:created_at" json:"created_at"`
-	UpdatedAt time.Time      `gorm:"column:updated_at" json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID         uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	Status     string         `gorm:"column:status;not null;size:20;default:'pending';index:idx_order_models_status_reserved_at,priority:1" json:"status"`
+	Total      int64          `gorm:"column:total;not null;default:0" json:"total"`
+	RiskScore  float64        `gorm:"column:risk_score;not null;default:0" json:"risk_score"`
+	HoldReason string         `gorm:"column:hold_reason;size:500" json:"hold_reason"`
+	ReservedAt time.Time      `gorm:"column:reserved_at;index:idx_order_models_status_reserved_at,priority:2" json:"reserved_at"`
+	CreatedAt  time.Time      `gorm:"column:created_at;index" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
 	User  UserModel        `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Items []OrderItemModel `gorm:"foreignKey:OrderID" json:"items,omitempty"`
@@ -27,9 +30,12 @@ type OrderModel struct {
 type OrderItemModel struct {
 	ID              uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	OrderID         uuid.UUID      `gorm:"type:uuid;not null;index" json:"order_id"`
-	ProductID       uuid.UUID      `gorm:"type:uuid;not null;index" json:"product_id"`
+	ProductID       uuid.UUID      `gorm:"type:uuid;not null;index;index:idx_order_item_models_backordered,where:backordered > 0" json:"product_id"`
+	VariantID       *uuid.UUID     `gorm:"type:uuid;index" json:"variant_id"`
 	ProductSnapshot datatypes.JSON `gorm:"column:product_snapshot;type:json;not null" json:"product_snapshot"`
 	Quantity        int            `gorm:"column:quantity;not null" json:"quantity"`
+	Backordered     int            `gorm:"column:backordered;not null;default:0" json:"backordered"`
+	ExpectedAt      *time.Time     `gorm:"column:expected_at" json:"expected_at"`
 	PricePerItem    int64          `gorm:"column:price_per_item;not null" json:"price_per_item"`
 	Total           int64          `gorm:"column:total;not null" json:"total"`
 	CreatedAt       time.Time      `gorm:"column:created_at" json:"created_at"`
@@ -41,13 +47,16 @@ type OrderItemModel struct {
 
 func (o *OrderModel) ToEntity() (*entities.Order, error) {
 	order := &entities.Order{
-		ID:        o.ID,
-		UserID:    o.UserID,
-		Status:    entities.OrderStatus(o.Status),
-		Total:     o.Total,
-		Items:     make([]entities.OrderItem, 0, len(o.Items)),
-		CreatedAt: o.CreatedAt,
-		UpdatedAt: o.UpdatedAt,
+		ID:         o.ID,
+		UserID:     o.UserID,
+		Status:     entities.OrderStatus(o.Status),
+		Total:      o.Total,
+		RiskScore:  o.RiskScore,
+		HoldReason: o.HoldReason,
+		ReservedAt: o.ReservedAt,
+		Items:      make([]entities.OrderItem, 0, len(o.Items)),
+		CreatedAt:  o.CreatedAt,
+		UpdatedAt:  o.UpdatedAt,
 	}
 
 	for _, item := range o.Items {
@@ -66,6 +75,9 @@ func (o *OrderModel) FromEntity(entity *entities.Order) error {
 	o.UserID = entity.UserID
 	o.Status = string(entity.Status)
 	o.Total = entity.Total
+	o.RiskScore = entity.RiskScore
+	o.HoldReason = entity.HoldReason
+	o.ReservedAt = entity.ReservedAt
 	o.CreatedAt = entity.CreatedAt
 	o.UpdatedAt = entity.UpdatedAt
 
@@ -91,8 +103,11 @@ func (oi *OrderItemModel) ToEntity() (*entities.OrderItem, error) {
 		ID:              oi.ID,
 		OrderID:         oi.OrderID,
 		ProductID:       oi.ProductID,
+		VariantID:       oi.VariantID,
 		ProductSnapshot: snapshot,
 		Quantity:        oi.Quantity,
+		Backordered:     oi.Backordered,
+		ExpectedAt:      oi.ExpectedAt,
 		PricePerItem:    oi.PricePerItem,
 		Total:           oi.Total,
 		CreatedAt:       oi.CreatedAt,
@@ -103,7 +118,10 @@ func (oi *OrderItemModel) FromEntity(entity *entities.OrderItem) error {
 	oi.ID = entity.ID
 	oi.OrderID = entity.OrderID
 	oi.ProductID = entity.ProductID
+	oi.VariantID = entity.VariantID
 	oi.Quantity = entity.Quantity
+	oi.Backordered = entity.Backordered
+	oi.ExpectedAt = entity.ExpectedAt
 	oi.PricePerItem = entity.PricePerItem
 	oi.Total = entity.Total
 	oi.CreatedAt = entity.CreatedAt
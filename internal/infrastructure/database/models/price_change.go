@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// PriceChangeModel - запись истории цен. Частичный индекс по valid_from помогает
+// фоновой задаче быстро находить наступившие, но еще не примененные цены.
+type PriceChangeModel struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ProductID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_price_change_models_product_valid_from" json:"product_id"`
+	Price     int64      `gorm:"column:price;not null" json:"price"`
+	ValidFrom time.Time  `gorm:"column:valid_from;not null;uniqueIndex:idx_price_change_models_product_valid_from;index:idx_price_change_models_pending,where:applied_at IS NULL" json:"valid_from"`
+	ValidTo   *time.Time `gorm:"column:valid_to" json:"valid_to"`
+	AppliedAt *time.Time `gorm:"column:applied_at" json:"applied_at"`
+	CreatedAt time.Time  `gorm:"column:created_at" json:"created_at"`
+
+	Product ProductModel `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+}
+
+func (p *PriceChangeModel) ToEntity() *entities.PriceChange {
+	return &entities.PriceChange{
+		ID:        p.ID,
+		ProductID: p.ProductID,
+		Price:     p.Price,
+		ValidFrom: p.ValidFrom,
+		ValidTo:   p.ValidTo,
+		AppliedAt: p.AppliedAt,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func (p *PriceChangeModel) FromEntity(entity *entities.PriceChange) {
+	p.ID = entity.ID
+	p.ProductID = entity.ProductID
+	p.Price = entity.Price
+	p.ValidFrom = entity.ValidFrom
+	p.ValidTo = entity.ValidTo
+	p.AppliedAt = entity.AppliedAt
+	p.CreatedAt = entity.CreatedAt
+}
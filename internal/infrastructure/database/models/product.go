@@ -12,14 +12,35 @@ import (
 )
 
 type ProductModel struct {
-	ID          uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	Description string         `gorm:"column:description;not null;size:500" json:"description"`
-	Tags        datatypes.JSON `gorm:"column:tags;type:json" json:"tags"`
-	Quantity    int            `gorm:"column:quantity;not null;default:0" json:"quantity"`
-	Price       int64          `gorm:"column:price;not null" json:"price"`
-	CreatedAt   time.Time      `gorm:"column:created_at" json:"created_at"`
-	UpdatedAt   time.Time      `gorm:"column:updated_at" json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID                uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	SKU               *string        `gorm:"column:sku;size:64;uniqueIndex" json:"sku"`
+	Name              string         `gorm:"column:name;not null;size:255;default:''" json:"name"`
+	Description       string         `gorm:"column:description;not null;size:500" json:"description"`
+	Tags              datatypes.JSON `gorm:"column:tags;type:json" json:"tags"`
+	Attributes        datatypes.JSON `gorm:"column:attributes;type:json" json:"attributes"`
+	Quantity          int            `gorm:"column:quantity;not null;default:0;index" json:"quantity"`
+	Price             int64          `gorm:"column:price;not null" json:"price"`
+	StockPolicy       string         `gorm:"column:stock_policy;not null;size:20;default:'in_stock'" json:"stock_policy"`
+	ExpectedAt        *time.Time     `gorm:"column:expected_at" json:"expected_at"`
+	LowStockThreshold int            `gorm:"column:low_stock_threshold;not null;default:0" json:"low_stock_threshold"`
+	CreatedAt         time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	Variants   []ProductVariantModel `gorm:"foreignKey:ProductID" json:"variants,omitempty"`
+	Categories []CategoryModel       `gorm:"many2many:product_category_models;joinForeignKey:ProductID;joinReferences:CategoryID" json:"categories,omitempty"`
+}
+
+type ProductVariantModel struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ProductID  uuid.UUID      `gorm:"type:uuid;not null;index" json:"product_id"`
+	SKU        string         `gorm:"column:sku;not null;size:64;uniqueIndex" json:"sku"`
+	Attributes datatypes.JSON `gorm:"column:attributes;type:json;not null" json:"attributes"`
+	Price      int64          `gorm:"column:price;not null" json:"price"`
+	Quantity   int            `gorm:"column:quantity;not null;default:0;index" json:"quantity"`
+	CreatedAt  time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 func (p *ProductModel) ToEntity() *entities.Product {
@@ -29,25 +50,66 @@ func (p *ProductModel) ToEntity() *entities.Product {
 		_ = json.Unmarshal(p.Tags, &tags)
 	}
 
-	return &entities.Product{
-		ID:          p.ID,
-		Description: p.Description,
-		Tags:        tags,
-		Quantity:    p.Quantity,
-		Price:       p.Price,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+	var attributes map[string]string
+	if p.Attributes != nil {
+		_ = json.Unmarshal(p.Attributes, &attributes)
+	}
+
+	var sku string
+	if p.SKU != nil {
+		sku = *p.SKU
 	}
+
+	product := &entities.Product{
+		ID:                p.ID,
+		SKU:               sku,
+		Name:              p.Name,
+		Description:       p.Description,
+		Tags:              tags,
+		Attributes:        attributes,
+		Quantity:          p.Quantity,
+		Price:             p.Price,
+		StockPolicy:       entities.StockPolicy(p.StockPolicy),
+		ExpectedAt:        p.ExpectedAt,
+		LowStockThreshold: p.LowStockThreshold,
+		CreatedAt:         p.CreatedAt,
+		UpdatedAt:         p.UpdatedAt,
+	}
+
+	for _, variant := range p.Variants {
+		product.Variants = append(product.Variants, *variant.ToEntity())
+	}
+
+	for _, category := range p.Categories {
+		product.Categories = append(product.Categories, category.ToEntity().Ref())
+	}
+
+	return product
 }
 
 func (p *ProductModel) FromEntity(entity *entities.Product) error {
 	p.ID = entity.ID
+	p.Name = entity.Name
 	p.Description = entity.Description
 	p.Quantity = entity.Quantity
 	p.Price = entity.Price
+	p.StockPolicy = string(entity.StockPolicy)
+	p.ExpectedAt = entity.ExpectedAt
+	p.LowStockThreshold = entity.LowStockThreshold
 	p.CreatedAt = entity.CreatedAt
 	p.UpdatedAt = entity.UpdatedAt
 
+	if p.StockPolicy == "" {
+		p.StockPolicy = string(entities.StockPolicyInStock)
+	}
+
+	// Артикул необязателен: пустое значение храним как NULL, чтобы не нарушать уникальный индекс
+	p.SKU = nil
+	if entity.SKU != "" {
+		sku := entity.SKU
+		p.SKU = &sku
+	}
+
 	if entity.Tags != nil {
 		tags, err := json.Marshal(entity.Tags)
 		if err != nil {
@@ -56,5 +118,62 @@ func (p *ProductModel) FromEntity(entity *entities.Product) error {
 		p.Tags = tags
 	}
 
+	if entity.Attributes != nil {
+		attributes, err := json.Marshal(entity.Attributes)
+		if err != nil {
+			return err
+		}
+		p.Attributes = attributes
+	}
+
+	p.Variants = make([]ProductVariantModel, 0, len(entity.Variants))
+	for _, variant := range entity.Variants {
+		variantModel := ProductVariantModel{}
+		if err := variantModel.FromEntity(&variant); err != nil {
+			return err
+		}
+		p.Variants = append(p.Variants, variantModel)
+	}
+
+	// Категории создаются отдельно, здесь нужны только идентификаторы для таблицы связей
+	p.Categories = make([]CategoryModel, 0, len(entity.Categories))
+	for _, category := range entity.Categories {
+		p.Categories = append(p.Categories, CategoryModel{ID: category.ID})
+	}
+
+	return nil
+}
+
+func (v *ProductVariantModel) ToEntity() *entities.ProductVariant {
+	var attributes map[string]string
+	_ = json.Unmarshal(v.Attributes, &attributes)
+
+	return &entities.ProductVariant{
+		ID:         v.ID,
+		ProductID:  v.ProductID,
+		SKU:        v.SKU,
+		Attributes: attributes,
+		Price:      v.Price,
+		Quantity:   v.Quantity,
+		CreatedAt:  v.CreatedAt,
+		UpdatedAt:  v.UpdatedAt,
+	}
+}
+
+func (v *ProductVariantModel) FromEntity(entity *entities.ProductVariant) error {
+	attributes, err := json.Marshal(entity.Attributes)
+	if err != nil {
+		return err
+	}
+
+	v.ID = entity.ID
+	v.ProductID = entity.ProductID
+	v.SKU = entity.SKU
+	v.Attributes = attributes
+	v.Price = entity.Price
+	v.Quantity = entity.Quantity
+	v.CreatedAt = entity.CreatedAt
+	v.UpdatedAt = entity.UpdatedAt
+
 	return nil
 }
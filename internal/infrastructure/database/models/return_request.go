@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReturnRequestModel struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID       uuid.UUID      `gorm:"type:uuid;not null;index" json:"order_id"`
+	UserID        uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	Status        string         `gorm:"column:status;not null;size:20;default:'requested'" json:"status"`
+	RefundAmount  int64          `gorm:"column:refund_amount;not null;default:0" json:"refund_amount"`
+	ReviewComment string         `gorm:"column:review_comment;size:500" json:"review_comment"`
+	ReviewedAt    *time.Time     `gorm:"column:reviewed_at" json:"reviewed_at,omitempty"`
+	ReceivedAt    *time.Time     `gorm:"column:received_at" json:"received_at,omitempty"`
+	CreatedAt     time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	Order OrderModel        `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+	Items []ReturnItemModel `gorm:"foreignKey:ReturnRequestID" json:"items,omitempty"`
+}
+
+type ReturnItemModel struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ReturnRequestID uuid.UUID  `gorm:"type:uuid;not null;index" json:"return_request_id"`
+	OrderItemID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"order_item_id"`
+	ProductID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"product_id"`
+	VariantID       *uuid.UUID `gorm:"type:uuid" json:"variant_id"`
+	Quantity        int        `gorm:"column:quantity;not null" json:"quantity"`
+	Reason          string     `gorm:"column:reason;not null;size:30" json:"reason"`
+	Comment         string     `gorm:"column:comment;size:500" json:"comment"`
+	PricePerItem    int64      `gorm:"column:price_per_item;not null" json:"price_per_item"`
+	RefundAmount    int64      `gorm:"column:refund_amount;not null" json:"refund_amount"`
+
+	OrderItem OrderItemModel `gorm:"foreignKey:OrderItemID" json:"order_item,omitempty"`
+}
+
+func (r *ReturnRequestModel) ToEntity() *entities.ReturnRequest {
+	request := &entities.ReturnRequest{
+		ID:            r.ID,
+		OrderID:       r.OrderID,
+		UserID:        r.UserID,
+		Status:        entities.ReturnStatus(r.Status),
+		Items:         make([]entities.ReturnItem, 0, len(r.Items)),
+		RefundAmount:  r.RefundAmount,
+		ReviewComment: r.ReviewComment,
+		ReviewedAt:    r.ReviewedAt,
+		ReceivedAt:    r.ReceivedAt,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+
+	for _, item := range r.Items {
+		request.Items = append(request.Items, entities.ReturnItem{
+			ID:              item.ID,
+			ReturnRequestID: item.ReturnRequestID,
+			OrderItemID:     item.OrderItemID,
+			ProductID:       item.ProductID,
+			VariantID:       item.VariantID,
+			Quantity:        item.Quantity,
+			Reason:          entities.ReturnReason(item.Reason),
+			Comment:         item.Comment,
+			PricePerItem:    item.PricePerItem,
+			RefundAmount:    item.RefundAmount,
+		})
+	}
+
+	return request
+}
+
+func (r *ReturnRequestModel) FromEntity(entity *entities.ReturnRequest) {
+	r.ID = entity.ID
+	r.OrderID = entity.OrderID
+	r.UserID = entity.UserID
+	r.Status = string(entity.Status)
+	r.RefundAmount = entity.RefundAmount
+	r.ReviewComment = entity.ReviewComment
+	r.ReviewedAt = entity.ReviewedAt
+	r.ReceivedAt = entity.ReceivedAt
+	r.CreatedAt = entity.CreatedAt
+	r.UpdatedAt = entity.UpdatedAt
+
+	r.Items = make([]ReturnItemModel, 0, len(entity.Items))
+	for _, item := range entity.Items {
+		r.Items = append(r.Items, ReturnItemModel{
+			ID:              item.ID,
+			ReturnRequestID: item.ReturnRequestID,
+			OrderItemID:     item.OrderItemID,
+			ProductID:       item.ProductID,
+			VariantID:       item.VariantID,
+			Quantity:        item.Quantity,
+			Reason:          string(item.Reason),
+			Comment:         item.Comment,
+			PricePerItem:    item.PricePerItem,
+			RefundAmount:    item.RefundAmount,
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ShipmentModel struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID        uuid.UUID      `gorm:"type:uuid;not null;index" json:"order_id"`
+	Carrier        string         `gorm:"column:carrier;not null;size:50;uniqueIndex:idx_shipments_carrier_tracking" json:"carrier"`
+	TrackingNumber string         `gorm:"column:tracking_number;not null;size:100;uniqueIndex:idx_shipments_carrier_tracking" json:"tracking_number"`
+	Status         string         `gorm:"column:status;not null;size:20;default:'created'" json:"status"`
+	DeliveredAt    *time.Time     `gorm:"column:delivered_at" json:"delivered_at,omitempty"`
+	CreatedAt      time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	Order  OrderModel           `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+	Items  []ShipmentItemModel  `gorm:"foreignKey:ShipmentID" json:"items,omitempty"`
+	Events []ShipmentEventModel `gorm:"foreignKey:ShipmentID" json:"events,omitempty"`
+}
+
+type ShipmentItemModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ShipmentID  uuid.UUID `gorm:"type:uuid;not null;index" json:"shipment_id"`
+	OrderItemID uuid.UUID `gorm:"type:uuid;not null;index" json:"order_item_id"`
+	Quantity    int       `gorm:"column:quantity;not null" json:"quantity"`
+
+	OrderItem OrderItemModel `gorm:"foreignKey:OrderItemID" json:"order_item,omitempty"`
+}
+
+type ShipmentEventModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ShipmentID  uuid.UUID `gorm:"type:uuid;not null;index" json:"shipment_id"`
+	Status      string    `gorm:"column:status;not null;size:20" json:"status"`
+	Location    string    `gorm:"column:location;size:255" json:"location"`
+	Description string    `gorm:"column:description;size:500" json:"description"`
+	OccurredAt  time.Time `gorm:"column:occurred_at;not null" json:"occurred_at"`
+	CreatedAt   time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+func (s *ShipmentModel) ToEntity() *entities.Shipment {
+	shipment := &entities.Shipment{
+		ID:             s.ID,
+		OrderID:        s.OrderID,
+		Carrier:        s.Carrier,
+		TrackingNumber: s.TrackingNumber,
+		Status:         entities.ShipmentStatus(s.Status),
+		Items:          make([]entities.ShipmentItem, 0, len(s.Items)),
+		Events:         make([]entities.ShipmentEvent, 0, len(s.Events)),
+		DeliveredAt:    s.DeliveredAt,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+
+	for _, item := range s.Items {
+		shipment.Items = append(shipment.Items, entities.ShipmentItem{
+			ID:          item.ID,
+			ShipmentID:  item.ShipmentID,
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+		})
+	}
+
+	for _, event := range s.Events {
+		shipment.Events = append(shipment.Events, entities.ShipmentEvent{
+			ID:          event.ID,
+			ShipmentID:  event.ShipmentID,
+			Status:      entities.ShipmentStatus(event.Status),
+			Location:    event.Location,
+			Description: event.Description,
+			OccurredAt:  event.OccurredAt,
+			CreatedAt:   event.CreatedAt,
+		})
+	}
+
+	return shipment
+}
+
+func (s *ShipmentModel) FromEntity(entity *entities.Shipment) {
+	s.ID = entity.ID
+	s.OrderID = entity.OrderID
+	s.Carrier = entity.Carrier
+	s.TrackingNumber = entity.TrackingNumber
+	s.Status = string(entity.Status)
+	s.DeliveredAt = entity.DeliveredAt
+	s.CreatedAt = entity.CreatedAt
+	s.UpdatedAt = entity.UpdatedAt
+
+	s.Items = make([]ShipmentItemModel, 0, len(entity.Items))
+	for _, item := range entity.Items {
+		s.Items = append(s.Items, ShipmentItemModel{
+			ID:          item.ID,
+			ShipmentID:  item.ShipmentID,
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+		})
+	}
+
+	s.Events = make([]ShipmentEventModel, 0, len(entity.Events))
+	for _, event := range entity.Events {
+		s.Events = append(s.Events, ShipmentEventModel{
+			ID:          event.ID,
+			ShipmentID:  event.ShipmentID,
+			Status:      string(event.Status),
+			Location:    event.Location,
+			Description: event.Description,
+			OccurredAt:  event.OccurredAt,
+			CreatedAt:   event.CreatedAt,
+		})
+	}
+}
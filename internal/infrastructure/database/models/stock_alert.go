@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// StockSubscriptionModel - подписка на поступление товара. Частичный индекс
+// покрывает поиск открытых подписок при пополнении остатка.
+type StockSubscriptionModel struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	ProductID  uuid.UUID  `gorm:"type:uuid;not null;index:idx_stock_subscription_models_open,where:notified_at IS NULL" json:"product_id"`
+	VariantID  *uuid.UUID `gorm:"type:uuid" json:"variant_id"`
+	CreatedAt  time.Time  `gorm:"column:created_at" json:"created_at"`
+	NotifiedAt *time.Time `gorm:"column:notified_at" json:"notified_at"`
+
+	User    UserModel    `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Product ProductModel `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+}
+
+// StockNotificationModel - очередь уведомлений об остатках, разбираемая фоновой задачей
+type StockNotificationModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Kind        string     `gorm:"column:kind;not null;size:20" json:"kind"`
+	UserID      *uuid.UUID `gorm:"type:uuid" json:"user_id"`
+	ProductID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"product_id"`
+	VariantID   *uuid.UUID `gorm:"type:uuid" json:"variant_id"`
+	SKU         string     `gorm:"column:sku;size:64" json:"sku"`
+	ProductName string     `gorm:"column:product_name;not null;size:500" json:"product_name"`
+	Quantity    int        `gorm:"column:quantity;not null" json:"quantity"`
+	Threshold   int        `gorm:"column:threshold;not null;default:0" json:"threshold"`
+	Attempts    int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	LastError   string     `gorm:"column:last_error;size:1000" json:"last_error"`
+	CreatedAt   time.Time  `gorm:"column:created_at;index:idx_stock_notification_models_pending,where:sent_at IS NULL" json:"created_at"`
+	SentAt      *time.Time `gorm:"column:sent_at" json:"sent_at"`
+}
+
+func (s *StockSubscriptionModel) ToEntity() *entities.StockSubscription {
+	return &entities.StockSubscription{
+		ID:         s.ID,
+		UserID:     s.UserID,
+		ProductID:  s.ProductID,
+		VariantID:  s.VariantID,
+		CreatedAt:  s.CreatedAt,
+		NotifiedAt: s.NotifiedAt,
+	}
+}
+
+func (s *StockSubscriptionModel) FromEntity(entity *entities.StockSubscription) {
+	s.ID = entity.ID
+	s.UserID = entity.UserID
+	s.ProductID = entity.ProductID
+	s.VariantID = entity.VariantID
+	s.CreatedAt = entity.CreatedAt
+	s.NotifiedAt = entity.NotifiedAt
+}
+
+func (n *StockNotificationModel) ToEntity() *entities.StockNotification {
+	return &entities.StockNotification{
+		ID:          n.ID,
+		Kind:        entities.StockNotificationKind(n.Kind),
+		UserID:      n.UserID,
+		ProductID:   n.ProductID,
+		VariantID:   n.VariantID,
+		SKU:         n.SKU,
+		ProductName: n.ProductName,
+		Quantity:    n.Quantity,
+		Threshold:   n.Threshold,
+		Attempts:    n.Attempts,
+		LastError:   n.LastError,
+		CreatedAt:   n.CreatedAt,
+		SentAt:      n.SentAt,
+	}
+}
+
+func (n *StockNotificationModel) FromEntity(entity *entities.StockNotification) {
+	n.ID = entity.ID
+	n.Kind = string(entity.Kind)
+	n.UserID = entity.UserID
+	n.ProductID = entity.ProductID
+	n.VariantID = entity.VariantID
+	n.SKU = entity.SKU
+	n.ProductName = entity.ProductName
+	n.Quantity = entity.Quantity
+	n.Threshold = entity.Threshold
+	n.Attempts = entity.Attempts
+	n.LastError = entity.LastError
+	n.CreatedAt = entity.CreatedAt
+	n.SentAt = entity.SentAt
+}
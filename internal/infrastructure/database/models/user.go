@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/AndrivA89/orders/internal/domain/constants"
 	"github.com/AndrivA89/orders/internal/domain/entities"
 
 	"github.com/google/uuid"
@@ -13,6 +14,8 @@ type UserModel struct {
 	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	FirstName string         `gorm:"column:first_name;not null;size:100" json:"first_name"`
 	LastName  string         `gorm:"column:last_name;not null;size:100" json:"last_name"`
+	Email     string         `gorm:"column:email;size:255" json:"email"`
+	Language  string         `gorm:"column:language;not null;size:5;default:'ru'" json:"language"`
 	Age       int            `gorm:"column:age;not null" json:"age"`
 	IsMarried bool           `gorm:"column:is_married;default:false" json:"is_married"`
 	Password  string         `gorm:"column:password;not null;size:255" json:"-"`
@@ -28,6 +31,8 @@ func (u *UserModel) ToEntity() *entities.User {
 		ID:        u.ID,
 		FirstName: u.FirstName,
 		LastName:  u.LastName,
+		Email:     u.Email,
+		Language:  u.Language,
 		Age:       u.Age,
 		IsMarried: u.IsMarried,
 		Password:  u.Password,
@@ -40,6 +45,11 @@ func (u *UserModel) FromEntity(entity *entities.User) {
 	u.ID = entity.ID
 	u.FirstName = entity.FirstName
 	u.LastName = entity.LastName
+	u.Email = entity.Email
+	u.Language = entity.Language
+	if u.Language == "" {
+		u.Language = constants.DefaultLanguage
+	}
 	u.Age = entity.Age
 	u.IsMarried = entity.IsMarried
 	u.Password = entity.Password
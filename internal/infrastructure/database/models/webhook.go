@@ -0,0 +1,109 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+type WebhookSubscriptionModel struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	URL       string         `gorm:"column:url;not null;size:2048" json:"url"`
+	Events    datatypes.JSON `gorm:"column:events;type:json;not null" json:"events"`
+	Secret    string         `gorm:"column:secret;not null;size:255" json:"-"`
+	Active    bool           `gorm:"column:active;not null;default:true" json:"active"`
+	CreatedAt time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// WebhookDeliveryModel - журнал доставок вебхуков. Составной индекс покрывает просмотр журнала
+// подписки, частичный - выборку доставок, ожидающих отправки.
+type WebhookDeliveryModel struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	SubscriptionID uuid.UUID      `gorm:"type:uuid;not null;index:idx_webhook_delivery_models_log,priority:1" json:"subscription_id"`
+	Event          string         `gorm:"column:event;not null;size:30" json:"event"`
+	OrderID        uuid.UUID      `gorm:"type:uuid;not null" json:"order_id"`
+	Payload        datatypes.JSON `gorm:"column:payload;type:jsonb;not null" json:"payload"`
+	Status         string         `gorm:"column:status;not null;size:20" json:"status"`
+	Attempts       int            `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	ResponseStatus int            `gorm:"column:response_status;not null;default:0" json:"response_status"`
+	LastError      string         `gorm:"column:last_error;size:1000" json:"last_error"`
+	NextAttemptAt  time.Time      `gorm:"column:next_attempt_at;not null;index:idx_webhook_delivery_models_due,where:status = 'pending'" json:"next_attempt_at"`
+	ReplayOf       *uuid.UUID     `gorm:"type:uuid" json:"replay_of"`
+	CreatedAt      time.Time      `gorm:"column:created_at;index:idx_webhook_delivery_models_log,priority:2" json:"created_at"`
+	DeliveredAt    *time.Time     `gorm:"column:delivered_at" json:"delivered_at"`
+
+	Subscription WebhookSubscriptionModel `gorm:"foreignKey:SubscriptionID" json:"subscription,omitempty"`
+}
+
+func (s *WebhookSubscriptionModel) ToEntity() *entities.WebhookSubscription {
+	var events []entities.OrderEvent
+	if s.Events != nil {
+		_ = json.Unmarshal(s.Events, &events)
+	}
+
+	return &entities.WebhookSubscription{
+		ID:        s.ID,
+		URL:       s.URL,
+		Events:    events,
+		Secret:    s.Secret,
+		Active:    s.Active,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func (s *WebhookSubscriptionModel) FromEntity(entity *entities.WebhookSubscription) error {
+	s.ID = entity.ID
+	s.URL = entity.URL
+	s.Secret = entity.Secret
+	s.Active = entity.Active
+	s.CreatedAt = entity.CreatedAt
+	s.UpdatedAt = entity.UpdatedAt
+
+	events, err := json.Marshal(entity.Events)
+	if err != nil {
+		return err
+	}
+	s.Events = events
+
+	return nil
+}
+
+func (d *WebhookDeliveryModel) ToEntity() *entities.WebhookDelivery {
+	return &entities.WebhookDelivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		Event:          entities.OrderEvent(d.Event),
+		OrderID:        d.OrderID,
+		Payload:        json.RawMessage(d.Payload),
+		Status:         entities.WebhookDeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt,
+		ReplayOf:       d.ReplayOf,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
+
+func (d *WebhookDeliveryModel) FromEntity(entity *entities.WebhookDelivery) {
+	d.ID = entity.ID
+	d.SubscriptionID = entity.SubscriptionID
+	d.Event = string(entity.Event)
+	d.OrderID = entity.OrderID
+	d.Payload = datatypes.JSON(entity.Payload)
+	d.Status = string(entity.Status)
+	d.Attempts = entity.Attempts
+	d.ResponseStatus = entity.ResponseStatus
+	d.LastError = entity.LastError
+	d.NextAttemptAt = entity.NextAttemptAt
+	d.ReplayOf = entity.ReplayOf
+	d.CreatedAt = entity.CreatedAt
+	d.DeliveredAt = entity.DeliveredAt
+}
@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ReplicaPolicyRoundRobin = "round-robin"
+	ReplicaPolicyRandom     = "random"
+)
+
+// ReadRouter выбирает подключение для чтения: одну из реплик по политике или основную БД.
+// Репозиторий отмечает запись в отметке клиента из ctx (см. WithWriteMarker), и в течение окна
+// read-your-writes чтения этого клиента идут в основную БД: реплика может еще не получить изменение,
+// и пользователь не увидел бы только что созданный заказ. Отметку хранит клиент, поэтому окно
+// действует на любом экземпляре сервиса, а не только на том, который принял запись.
+// Реплика, не ответившая на проверку, пропускается; если недоступны все, чтения идут в основную БД.
+type ReadRouter struct {
+	primary  *gorm.DB
+	replicas []*gorm.DB
+	// down[i] - реплика i не ответила на последнюю проверку (см. Connection.PingReplica)
+	down   []atomic.Bool
+	pick   func(n int) int
+	window time.Duration
+
+	// у маршрутизатора транзакции отметки копятся в pending и обновляются после фиксации
+	parent  *ReadRouter
+	mu      sync.Mutex
+	pending []*WriteMarker
+}
+
+type primaryReadsKey struct{}
+
+// WithPrimaryReads направляет все чтения из ctx в основную БД. Нужен фоновым задачам: у них нет
+// отметки клиента, а обрабатывают они записи, которые реплика могла еще не получить.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
+
+// NewReadRouter без реплик возвращает маршрутизатор, который всегда читает из основной БД
+func NewReadRouter(primary *gorm.DB, replicas []*gorm.DB, policy string, window time.Duration) (*ReadRouter, error) {
+	pick, err := replicaPicker(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReadRouter{
+		primary:  primary,
+		replicas: replicas,
+		down:     make([]atomic.Bool, len(replicas)),
+		pick:     pick,
+		window:   window,
+	}, nil
+}
+
+// SetReplicaHealthy отмечает реплику с номером i (с нуля) доступной или недоступной для чтений;
+// возвращает true, если состояние изменилось
+func (r *ReadRouter) SetReplicaHealthy(i int, healthy bool) bool {
+	return r.down[i].Swap(!healthy) == healthy
+}
+
+// replicaPicker возвращает функцию, выбирающую номер реплики из n по политике
+func replicaPicker(policy string) (func(n int) int, error) {
+	switch policy {
+	case ReplicaPolicyRoundRobin, "":
+		var next atomic.Uint64
+		return func(n int) int { return int((next.Add(1) - 1) % uint64(n)) }, nil
+	case ReplicaPolicyRandom:
+		return rand.IntN, nil
+	default:
+		return nil, fmt.Errorf("unknown replica policy %q: expected %s or %s",
+			policy, ReplicaPolicyRoundRobin, ReplicaPolicyRandom)
+	}
+}
+
+// Reader возвращает подключение для чтения. Если клиент из ctx недавно что-то записал,
+// чтение идет в основную БД.
+func (r *ReadRouter) Reader(ctx context.Context) *gorm.DB {
+	if r.parent != nil || len(r.replicas) == 0 {
+		return r.primary
+	}
+	if primary, _ := ctx.Value(primaryReadsKey{}).(bool); primary {
+		return r.primary
+	}
+	if marker := writeMarkerFrom(ctx); marker != nil && marker.writtenWithin(r.window, time.Now()) {
+		return r.primary
+	}
+
+	// выбранная по политике реплика заменяется следующей доступной
+	n := len(r.replicas)
+	start := r.pick(n)
+	for k := range n {
+		if i := (start + k) % n; !r.down[i].Load() {
+			return r.replicas[i]
+		}
+	}
+
+	return r.primary
+}
+
+// MarkWritten отмечает запись клиента из ctx: его чтения в течение окна идут в основную БД
+func (r *ReadRouter) MarkWritten(ctx context.Context) {
+	marker := writeMarkerFrom(ctx)
+	if marker == nil {
+		return
+	}
+
+	if r.parent != nil {
+		r.mu.Lock()
+		r.pending = append(r.pending, marker)
+		r.mu.Unlock()
+		return
+	}
+
+	marker.touch(time.Now())
+}
+
+// Pin возвращает маршрутизатор транзакции: все чтения идут через tx, а отметки о записи
+// обновляются вызовом commit. Окно read-your-writes отсчитывается от фиксации, а не от
+// записи внутри транзакции, и откатившаяся транзакция отметок не оставляет.
+func (r *ReadRouter) Pin(tx *gorm.DB) (pinned *ReadRouter, commit func()) {
+	pinned = &ReadRouter{primary: tx, parent: r}
+
+	return pinned, func() {
+		pinned.mu.Lock()
+		markers := pinned.pending
+		pinned.pending = nil
+		pinned.mu.Unlock()
+
+		now := time.Now()
+		for _, marker := range markers {
+			marker.touch(now)
+		}
+	}
+}
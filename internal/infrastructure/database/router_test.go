@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestReadRouter_RoundRobin(t *testing.T) {
+	primary, first, second := &gorm.DB{}, &gorm.DB{}, &gorm.DB{}
+	router, err := NewReadRouter(primary, []*gorm.DB{first, second}, ReplicaPolicyRoundRobin, time.Minute)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.Same(t, first, router.Reader(ctx))
+	assert.Same(t, second, router.Reader(ctx))
+	assert.Same(t, first, router.Reader(ctx))
+}
+
+func TestReadRouter_SkipsUnavailableReplicas(t *testing.T) {
+	primary, first, second := &gorm.DB{}, &gorm.DB{}, &gorm.DB{}
+	router, err := NewReadRouter(primary, []*gorm.DB{first, second}, ReplicaPolicyRoundRobin, time.Minute)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.True(t, router.SetReplicaHealthy(0, false))
+	assert.False(t, router.SetReplicaHealthy(0, false))
+	assert.Same(t, second, router.Reader(ctx))
+	assert.Same(t, second, router.Reader(ctx))
+
+	// без доступных реплик чтения идут в основную БД
+	router.SetReplicaHealthy(1, false)
+	assert.Same(t, primary, router.Reader(ctx))
+
+	assert.True(t, router.SetReplicaHealthy(0, true))
+	assert.Same(t, first, router.Reader(ctx))
+}
+
+func TestReadRouter_WithoutReplicasReadsPrimary(t *testing.T) {
+	primary := &gorm.DB{}
+	router, err := NewReadRouter(primary, nil, ReplicaPolicyRandom, time.Minute)
+	require.NoError(t, err)
+
+	ctx := WithWriteMarker(context.Background(), time.Time{}, nil)
+	router.MarkWritten(ctx)
+
+	assert.Same(t, primary, router.Reader(ctx))
+}
+
+func TestReadRouter_ReadYourWrites(t *testing.T) {
+	primary, replica := &gorm.DB{}, &gorm.DB{}
+	router, err := NewReadRouter(primary, []*gorm.DB{replica}, ReplicaPolicyRoundRobin, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	var returned time.Time
+	writer := WithWriteMarker(context.Background(), time.Time{}, func(at time.Time) { returned = at })
+	other := WithWriteMarker(context.Background(), time.Time{}, nil)
+
+	assert.Same(t, replica, router.Reader(writer))
+	router.MarkWritten(writer)
+
+	// записавший клиент читает основную БД и получает время записи, остальные читают реплику
+	assert.Same(t, primary, router.Reader(writer))
+	assert.False(t, returned.IsZero())
+	assert.Same(t, replica, router.Reader(other))
+	assert.Same(t, replica, router.Reader(context.Background()))
+
+	// следующий запрос того же клиента, возможно на другом экземпляре, приносит время с собой
+	next := WithWriteMarker(context.Background(), ParseWriteTime(FormatWriteTime(returned)), nil)
+	assert.Same(t, primary, router.Reader(next))
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Same(t, replica, router.Reader(writer))
+	assert.Same(t, replica, router.Reader(next))
+}
+
+func TestReadRouter_WithPrimaryReads(t *testing.T) {
+	primary, replica := &gorm.DB{}, &gorm.DB{}
+	router, err := NewReadRouter(primary, []*gorm.DB{replica}, ReplicaPolicyRoundRobin, time.Minute)
+	require.NoError(t, err)
+
+	assert.Same(t, replica, router.Reader(context.Background()))
+	assert.Same(t, primary, router.Reader(WithPrimaryReads(context.Background())))
+}
+
+func TestReadRouter_PinDefersMarksUntilCommit(t *testing.T) {
+	primary, replica, tx := &gorm.DB{}, &gorm.DB{}, &gorm.DB{}
+	router, err := NewReadRouter(primary, []*gorm.DB{replica}, ReplicaPolicyRoundRobin, time.Minute)
+	require.NoError(t, err)
+
+	ctx := WithWriteMarker(context.Background(), time.Time{}, nil)
+	pinned, commit := router.Pin(tx)
+	pinned.MarkWritten(ctx)
+
+	// внутри транзакции все чтения идут через нее, а до фиксации остальные запросы читают реплику
+	assert.Same(t, tx, pinned.Reader(ctx))
+	assert.Same(t, replica, router.Reader(ctx))
+
+	commit()
+	assert.Same(t, primary, router.Reader(ctx))
+}
+
+func TestReadRouter_IgnoresFarFutureWriteTime(t *testing.T) {
+	primary, replica := &gorm.DB{}, &gorm.DB{}
+	router, err := NewReadRouter(primary, []*gorm.DB{replica}, ReplicaPolicyRoundRobin, time.Second)
+	require.NoError(t, err)
+
+	ctx := WithWriteMarker(context.Background(), time.Now().Add(time.Hour), nil)
+	assert.Same(t, replica, router.Reader(ctx))
+	assert.True(t, ParseWriteTime("not-a-time").IsZero())
+}
+
+func TestNewReadRouter_UnknownPolicy(t *testing.T) {
+	_, err := NewReadRouter(&gorm.DB{}, nil, "least-lag", time.Second)
+	assert.Error(t, err)
+}
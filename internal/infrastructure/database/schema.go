@@ -0,0 +1,101 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
+
+	"gorm.io/gorm"
+)
+
+// Models - все модели, таблицы которых создают миграции
+var Models = []any{
+	&models.UserModel{},
+	&models.ProductModel{},
+	&models.ProductVariantModel{},
+	&models.PriceChangeModel{},
+	&models.StockSubscriptionModel{},
+	&models.StockNotificationModel{},
+	&models.CategoryModel{},
+	&models.ProductCategoryModel{},
+	&models.OrderModel{},
+	&models.OrderItemModel{},
+	&models.ShipmentModel{},
+	&models.ShipmentItemModel{},
+	&models.ShipmentEventModel{},
+	&models.ReturnRequestModel{},
+	&models.ReturnItemModel{},
+	&models.InvoiceModel{},
+	&models.InvoiceDocumentModel{},
+	&models.InvoiceSequenceModel{},
+	&models.EmailDeliveryModel{},
+	&models.WebhookSubscriptionModel{},
+	&models.WebhookDeliveryModel{},
+}
+
+var ErrSchemaMismatch = errors.New("database schema does not match the models")
+
+// CheckSchema сверяет схему базы с моделями: таблицы, колонки, их обязательность и длину строк,
+// индексы. Лишние колонки и таблицы не считаются ошибкой - их могла добавить более новая версия.
+func (c *Connection) CheckSchema() error {
+	migrator := c.DB.Migrator()
+
+	var problems []string
+	for _, model := range Models {
+		stmt := &gorm.Statement{DB: c.DB}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			problems = append(problems, fmt.Sprintf("table %s is missing", table))
+			continue
+		}
+
+		columnTypes, err := migrator.ColumnTypes(model)
+		if err != nil {
+			return err
+		}
+
+		columns := make(map[string]gorm.ColumnType, len(columnTypes))
+		for _, columnType := range columnTypes {
+			columns[columnType.Name()] = columnType
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+
+			column, ok := columns[field.DBName]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("column %s.%s is missing", table, field.DBName))
+				continue
+			}
+
+			if nullable, ok := column.Nullable(); ok && nullable && field.NotNull {
+				problems = append(problems, fmt.Sprintf("column %s.%s must be NOT NULL", table, field.DBName))
+			}
+
+			if length, ok := column.Length(); ok && field.Size > 0 && field.DataType == "string" && length != int64(field.Size) {
+				problems = append(problems, fmt.Sprintf("column %s.%s has length %d, model expects %d",
+					table, field.DBName, length, field.Size))
+			}
+		}
+
+		for _, index := range stmt.Schema.ParseIndexes() {
+			if !migrator.HasIndex(model, index.Name) {
+				problems = append(problems, fmt.Sprintf("index %s on %s is missing", index.Name, table))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrSchemaMismatch, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/database/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sqlRecorder собирает SQL, который GORM сгенерировал бы для моделей, без обращения к базе
+type sqlRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+func (r *sqlRecorder) LogMode(logger.LogLevel) logger.Interface { return r }
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	statement, _ := fc()
+	r.statements = append(r.statements, statement)
+}
+
+var createTableStatement = regexp.MustCompile(`^CREATE TABLE "(\w+)" \((.*)\)$`)
+
+var addColumnStatement = regexp.MustCompile(`^ALTER TABLE "\w+" ADD COLUMN IF NOT EXISTS (.+);$`)
+
+// Модель, измененная без новой миграции, должна ронять этот тест, а не приложение при старте
+func TestMigrations_CoverModels(t *testing.T) {
+	recorder := &sqlRecorder{}
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=dry_run"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               recorder,
+	})
+	assert.NoError(t, err)
+
+	for _, model := range Models {
+		assert.NoError(t, db.Migrator().CreateTable(model))
+	}
+
+	all, err := migrations.Load()
+	assert.NoError(t, err)
+
+	var script strings.Builder
+	lines := make(map[string]bool)
+	for _, migration := range all {
+		script.WriteString(migration.Up)
+		for _, line := range strings.Split(migration.Up, "\n") {
+			line = strings.TrimSuffix(strings.TrimSpace(line), ",")
+			// колонки, добавленные к существующей таблице, определяются в ALTER TABLE
+			if match := addColumnStatement.FindStringSubmatch(line); match != nil {
+				line = match[1]
+			}
+			lines[line] = true
+		}
+	}
+	applied := script.String()
+
+	for _, statement := range recorder.statements {
+		match := createTableStatement.FindStringSubmatch(statement)
+		if match == nil {
+			// индексы в миграциях пишутся так же, как их создает GORM
+			assert.Contains(t, applied, statement+";")
+			continue
+		}
+
+		assert.Contains(t, applied, `CREATE TABLE IF NOT EXISTS "`+match[1]+`"`)
+		for _, definition := range splitDefinitions(match[2]) {
+			assert.True(t, lines[definition], "table %s: no migration defines %s", match[1], definition)
+		}
+	}
+}
+
+// splitDefinitions делит список колонок и ограничений по запятым верхнего уровня
+func splitDefinitions(list string) []string {
+	var definitions []string
+	depth, start := 0, 0
+	for i, char := range list {
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				definitions = append(definitions, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(definitions, list[start:])
+}
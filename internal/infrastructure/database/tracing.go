@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	otelCodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	tracerName = "github.com/AndrivA89/orders/internal/infrastructure/database"
+
+	// parentContextKey - контекст запроса до открытия span, восстанавливается после выполнения SQL
+	parentContextKey = "tracing:parent_context"
+)
+
+// tracingPlugin пишет каждый SQL-запрос дочерним спаном контекста, переданного в WithContext.
+// В span попадает текст запроса с плейсхолдерами: значения параметров не пишутся,
+// в них бывают email и хеши паролей.
+type tracingPlugin struct {
+	tracer trace.Tracer
+}
+
+// callbackRegistrar - точка регистрации колбэка GORM (db.Callback().Query().Before(...) и т.п.)
+type callbackRegistrar interface {
+	Register(name string, fn func(*gorm.DB)) error
+}
+
+func newTracingPlugin(provider trace.TracerProvider) gorm.Plugin {
+	return &tracingPlugin{tracer: provider.Tracer(tracerName)}
+}
+
+func (p *tracingPlugin) Name() string {
+	return "orders:tracing"
+}
+
+func (p *tracingPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	hooks := []struct {
+		before, after callbackRegistrar
+		operation     string
+	}{
+		{callbacks.Create().Before("gorm:create"), callbacks.Create().After("gorm:create"), "INSERT"},
+		{callbacks.Query().Before("gorm:query"), callbacks.Query().After("gorm:query"), "SELECT"},
+		{callbacks.Update().Before("gorm:update"), callbacks.Update().After("gorm:update"), "UPDATE"},
+		{callbacks.Delete().Before("gorm:delete"), callbacks.Delete().After("gorm:delete"), "DELETE"},
+		{callbacks.Row().Before("gorm:row"), callbacks.Row().After("gorm:row"), ""},
+		{callbacks.Raw().Before("gorm:raw"), callbacks.Raw().After("gorm:raw"), ""},
+	}
+
+	var errs []error
+	for _, hook := range hooks {
+		name := strings.ToLower(hook.operation)
+		if name == "" {
+			name = "raw"
+		}
+		errs = append(errs,
+			hook.before.Register("tracing:before_"+name, p.before),
+			hook.after.Register("tracing:after_"+name, p.after(hook.operation)),
+		)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *tracingPlugin) before(tx *gorm.DB) {
+	parent := tx.Statement.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	// имя уточняется после выполнения, когда известны запрос и таблица
+	ctx, _ := p.tracer.Start(parent, "db", trace.WithSpanKind(trace.SpanKindClient))
+	tx.InstanceSet(parentContextKey, parent)
+	tx.Statement.Context = ctx
+}
+
+// after закрывает span; пустая operation (Raw, Row) берется из первого слова запроса
+func (p *tracingPlugin) after(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		parent, ok := tx.InstanceGet(parentContextKey)
+		if !ok {
+			return
+		}
+		span := trace.SpanFromContext(tx.Statement.Context)
+		tx.Statement.Context = parent.(context.Context)
+		defer span.End()
+
+		query := tx.Statement.SQL.String()
+		op := operation
+		if op == "" {
+			op = queryOperation(query)
+		}
+
+		// имя span по соглашениям OpenTelemetry: операция и таблица
+		name := op
+		if tx.Statement.Table != "" {
+			name += " " + tx.Statement.Table
+			span.SetAttributes(semconv.DBCollectionName(tx.Statement.Table))
+		}
+		span.SetName(name)
+		span.SetAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(op),
+			semconv.DBQueryText(query),
+		)
+
+		if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(tx.Error)
+			span.SetStatus(otelCodes.Error, tx.Error.Error())
+		}
+	}
+}
+
+// queryOperation - первое слово запроса: SELECT, INSERT, WITH и т.п.
+func queryOperation(query string) string {
+	if word, _, _ := strings.Cut(strings.TrimSpace(query), " "); word != "" {
+		return strings.ToUpper(word)
+	}
+
+	return "QUERY"
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestTracingPlugin_RecordsQueriesAsChildSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=dry_run"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Use(newTracingPlugin(provider)))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	var user models.UserModel
+	db.WithContext(ctx).Where("email = ?", "secret@example.com").Find(&user)
+	parent.End()
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+
+	query := spans[0]
+	assert.Equal(t, "SELECT user_models", query.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Contains(t, query.Attributes(), semconv.DBSystemPostgreSQL)
+	for _, attr := range query.Attributes() {
+		if attr.Key == semconv.DBQueryTextKey {
+			assert.Contains(t, attr.Value.AsString(), "email = $1")
+			assert.NotContains(t, attr.Value.AsString(), "secret@example.com")
+		}
+	}
+}
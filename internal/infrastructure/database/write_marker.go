@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WriteMarker - время последней записи клиента. Транспорт восстанавливает его из запроса
+// (cookie, заголовок или метаданные gRPC) и возвращает клиенту обновленным после записи.
+type WriteMarker struct {
+	mu      sync.Mutex
+	at      time.Time
+	onWrite func(at time.Time)
+}
+
+type writeMarkerKey struct{}
+
+// WithWriteMarker кладет в ctx отметку клиента: lastWrite - время его последней записи (нулевое,
+// если клиент ее не прислал), onWrite вызывается при новой записи, чтобы транспорт передал время клиенту
+func WithWriteMarker(ctx context.Context, lastWrite time.Time, onWrite func(at time.Time)) context.Context {
+	return context.WithValue(ctx, writeMarkerKey{}, &WriteMarker{at: lastWrite, onWrite: onWrite})
+}
+
+func writeMarkerFrom(ctx context.Context) *WriteMarker {
+	marker, _ := ctx.Value(writeMarkerKey{}).(*WriteMarker)
+	return marker
+}
+
+func (m *WriteMarker) touch(at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.at = at
+	if m.onWrite != nil {
+		m.onWrite(at)
+	}
+}
+
+func (m *WriteMarker) writtenWithin(window time.Duration, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// время из будущего дальше окна прислано не нами и не должно навсегда привязать клиента к основной БД
+	elapsed := now.Sub(m.at)
+	return !m.at.IsZero() && elapsed < window && elapsed > -window
+}
+
+// FormatWriteTime кодирует время записи для клиента: миллисекунды Unix
+func FormatWriteTime(at time.Time) string {
+	return strconv.FormatInt(at.UnixMilli(), 10)
+}
+
+// ParseWriteTime разбирает присланное клиентом время записи; некорректное значение означает,
+// что записи не было, и чтения идут на реплики как обычно
+func ParseWriteTime(value string) time.Time {
+	millis, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || millis <= 0 {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(millis)
+}
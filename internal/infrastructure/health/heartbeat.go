@@ -0,0 +1,18 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Heartbeat - проверка фоновой задачи: она не прошла, если задача не завершала прогон дольше maxAge.
+// Так обнаруживается задача, которая зависла или вышла из цикла.
+func Heartbeat(lastRun func() time.Time, maxAge time.Duration) CheckFunc {
+	return func(ctx context.Context) error {
+		if since := time.Since(lastRun()); since > maxAge {
+			return fmt.Errorf("no completed run for %s", since.Round(time.Second))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,176 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// CheckFunc проверяет одну зависимость; ошибка означает, что сервис без нее обслуживать запросы не может
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult - результат последнего выполнения проверки
+type CheckResult struct {
+	Name string
+	// Optional - проверка попадает в отчет, но не влияет на готовность
+	Optional  bool
+	Status    Status
+	Error     string
+	Duration  time.Duration
+	CheckedAt time.Time
+}
+
+// Report - итог проверки готовности: сервис готов, только если прошли все обязательные проверки
+type Report struct {
+	Status Status
+	Checks []CheckResult
+}
+
+// Registry хранит проверки компонентов. Каждая проверка выполняется не дольше своего таймаута,
+// а ее результат переиспользуется в течение cacheTTL: частые пробы оркестратора и балансировщика
+// не превращаются в такой же поток запросов к БД.
+type Registry struct {
+	defaultTimeout time.Duration
+	cacheTTL       time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]*check
+}
+
+type check struct {
+	name     string
+	fn       CheckFunc
+	timeout  time.Duration
+	optional bool
+
+	// mu держится на время выполнения: одновременные пробы ждут один запуск, а не запускают свои
+	mu     sync.Mutex
+	result CheckResult
+}
+
+func NewRegistry(defaultTimeout, cacheTTL time.Duration) *Registry {
+	return &Registry{
+		defaultTimeout: defaultTimeout,
+		cacheTTL:       cacheTTL,
+		checks:         make(map[string]*check),
+	}
+}
+
+// Register добавляет проверку; нулевой timeout заменяется таймаутом реестра по умолчанию.
+// Повторная регистрация под тем же именем заменяет проверку.
+func (r *Registry) Register(name string, fn CheckFunc, timeout time.Duration) {
+	r.register(name, fn, timeout, false)
+}
+
+// RegisterOptional добавляет проверку зависимости, без которой сервис продолжает работать
+// (например, реплики для чтения): ее результат виден в подробном отчете, но не выводит сервис из балансировки
+func (r *Registry) RegisterOptional(name string, fn CheckFunc, timeout time.Duration) {
+	r.register(name, fn, timeout, true)
+}
+
+func (r *Registry) register(name string, fn CheckFunc, timeout time.Duration, optional bool) {
+	if timeout <= 0 {
+		timeout = r.defaultTimeout
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checks[name] = &check{name: name, fn: fn, timeout: timeout, optional: optional}
+}
+
+// Check выполняет все проверки параллельно и собирает отчет, проверки в нем отсортированы по имени
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := make([]*check, 0, len(r.checks))
+	for _, c := range r.checks {
+		checks = append(checks, c)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(checks, func(i, j int) bool { return checks[i].name < checks[j].name })
+
+	report := Report{Status: StatusUp, Checks: make([]CheckResult, len(checks))}
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = c.run(ctx, r.cacheTTL)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusUp && !result.Optional {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+func (c *check) run(ctx context.Context, cacheTTL time.Duration) CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.result.CheckedAt.IsZero() && time.Since(c.result.CheckedAt) < cacheTTL {
+		return c.result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := callWithin(ctx, c.fn)
+
+	result := CheckResult{
+		Name:      c.name,
+		Optional:  c.optional,
+		Status:    StatusUp,
+		Duration:  time.Since(started),
+		CheckedAt: time.Now(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	// результат прерванной пробы (клиент отключился) ничего не говорит о зависимости и не кешируется
+	if ctx.Err() == nil || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		c.result = result
+	}
+
+	return result
+}
+
+// callWithin не дает проверке, которая не следит за ctx, задержать ответ дольше таймаута
+func callWithin(ctx context.Context, fn CheckFunc) (err error) {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("check timed out: %w", ctx.Err())
+	}
+}
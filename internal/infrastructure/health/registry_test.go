@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Check_DownWhenAnyCheckFails(t *testing.T) {
+	registry := NewRegistry(time.Second, 0)
+	registry.Register("database", func(ctx context.Context) error { return nil }, 0)
+	registry.Register("broker", func(ctx context.Context) error { return errors.New("connection refused") }, 0)
+
+	report := registry.Check(context.Background())
+
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Len(t, report.Checks, 2)
+	// проверки отсортированы по имени
+	assert.Equal(t, "broker", report.Checks[0].Name)
+	assert.Equal(t, StatusDown, report.Checks[0].Status)
+	assert.Equal(t, "connection refused", report.Checks[0].Error)
+	assert.Equal(t, StatusUp, report.Checks[1].Status)
+}
+
+func TestRegistry_Check_OptionalCheckDoesNotAffectStatus(t *testing.T) {
+	registry := NewRegistry(time.Second, 0)
+	registry.Register("database", func(ctx context.Context) error { return nil }, 0)
+	registry.RegisterOptional("database.replica_1", func(ctx context.Context) error { return errors.New("connection refused") }, 0)
+
+	report := registry.Check(context.Background())
+
+	assert.Equal(t, StatusUp, report.Status)
+	assert.True(t, report.Checks[1].Optional)
+	assert.Equal(t, StatusDown, report.Checks[1].Status)
+}
+
+func TestRegistry_Check_CachesResults(t *testing.T) {
+	var calls atomic.Int32
+	registry := NewRegistry(time.Second, time.Minute)
+	registry.Register("database", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}, 0)
+
+	for range 3 {
+		assert.Equal(t, StatusUp, registry.Check(context.Background()).Status)
+	}
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRegistry_Check_TimesOutCheckIgnoringContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	registry := NewRegistry(time.Second, 0)
+	registry.Register("stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	}, 20*time.Millisecond)
+
+	started := time.Now()
+	report := registry.Check(context.Background())
+
+	assert.Less(t, time.Since(started), time.Second)
+	assert.Equal(t, StatusDown, report.Status)
+	assert.ErrorContains(t, errors.New(report.Checks[0].Error), "timed out")
+}
+
+func TestHeartbeat_FailsWhenStale(t *testing.T) {
+	lastRun := time.Now().Add(-time.Hour)
+	check := Heartbeat(func() time.Time { return lastRun }, 15*time.Minute)
+
+	assert.Error(t, check(context.Background()))
+
+	lastRun = time.Now()
+	assert.NoError(t, check(context.Background()))
+}
@@ -0,0 +1,197 @@
+package invoicing
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+)
+
+const (
+	pageWidth    = 595 // A4 в пунктах
+	pageHeight   = 842
+	marginLeft   = 50
+	marginTop    = 60
+	fontSize     = 10
+	lineHeight   = 14
+	linesPerPage = (pageHeight - 2*marginTop) / lineHeight
+
+	descriptionWidth = 44
+)
+
+// PDFRenderer формирует простой текстовый PDF без внешних зависимостей.
+// Используется моноширинный встроенный шрифт Courier: он не содержит кириллицы,
+// поэтому русский текст транслитерируется. Структурированные JSON/XML версии
+// счета сохраняют исходный текст.
+type PDFRenderer struct{}
+
+func NewPDFRenderer() *PDFRenderer {
+	return &PDFRenderer{}
+}
+
+func (r *PDFRenderer) RenderPDF(invoice *entities.Invoice) ([]byte, error) {
+	return buildPDF(invoiceLines(invoice)), nil
+}
+
+func invoiceLines(invoice *entities.Invoice) []string {
+	lines := []string{
+		fmt.Sprintf("INVOICE %s", invoice.Number),
+		"",
+		fmt.Sprintf("Issued:   %s", invoice.IssuedAt.Format("2006-01-02")),
+		fmt.Sprintf("Seller:   %s", invoice.SellerName),
+		fmt.Sprintf("Customer: %s", invoice.Customer.FullName),
+		fmt.Sprintf("Order:    %s", invoice.OrderID),
+		"",
+		fmt.Sprintf("%-*s %5s %12s %12s", descriptionWidth, "Description", "Qty", "Unit price", "Total"),
+		strings.Repeat("-", descriptionWidth+32),
+	}
+
+	for _, line := range invoice.Lines {
+		description := truncate(transliterate(line.Description), descriptionWidth)
+		lines = append(lines, fmt.Sprintf("%-*s %5d %12s %12s",
+			descriptionWidth, description, line.Quantity, formatAmount(line.UnitPrice), formatAmount(line.Total)))
+	}
+
+	lines = append(lines,
+		strings.Repeat("-", descriptionWidth+32),
+		fmt.Sprintf("%*s %12s", descriptionWidth+19, "Total "+invoice.Currency+":", formatAmount(invoice.Total)),
+	)
+
+	for i := range lines {
+		lines[i] = transliterate(lines[i])
+	}
+
+	return lines
+}
+
+// buildPDF собирает документ PDF 1.4: каталог, дерево страниц, шрифт и по потоку на страницу
+func buildPDF(lines []string) []byte {
+	pages := make([][]string, 0, len(lines)/linesPerPage+1)
+	for start := 0; start < len(lines); start += linesPerPage {
+		end := start + linesPerPage
+		if end > len(lines) {
+			end = len(lines)
+		}
+		pages = append(pages, lines[start:end])
+	}
+	if len(pages) == 0 {
+		pages = append(pages, []string{})
+	}
+
+	// Объекты: 1 - каталог, 2 - дерево страниц, 3 - шрифт, далее пары страница/поток
+	objects := make([]string, 0, 3+2*len(pages))
+
+	kids := make([]string, 0, len(pages))
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+2*i))
+	}
+
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+	)
+
+	for i, pageLines := range pages {
+		contentID := 5 + 2*i
+		objects = append(objects, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, contentID))
+
+		stream := pageContent(pageLines)
+		objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+
+	return buf.Bytes()
+}
+
+func pageContent(lines []string) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", fontSize, lineHeight, marginLeft, pageHeight-marginTop)
+	for _, line := range lines {
+		fmt.Fprintf(&buf, "(%s) Tj T*\n", escapeText(line))
+	}
+	buf.WriteString("ET")
+	return buf.String()
+}
+
+func escapeText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+	return replacer.Replace(text)
+}
+
+func formatAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+func truncate(text string, width int) string {
+	if utf8.RuneCountInString(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:width-3]) + "..."
+}
+
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+// transliterate заменяет кириллицу латиницей, а прочие символы вне ASCII - знаком вопроса
+func transliterate(text string) string {
+	var buf strings.Builder
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			buf.WriteRune(r)
+			continue
+		}
+		if latin, ok := cyrillic[r]; ok {
+			buf.WriteString(latin)
+			continue
+		}
+		if latin, ok := cyrillic[toLower(r)]; ok {
+			if latin != "" {
+				buf.WriteString(strings.ToUpper(latin[:1]) + latin[1:])
+			}
+			continue
+		}
+		buf.WriteRune('?')
+	}
+	return buf.String()
+}
+
+func toLower(r rune) rune {
+	switch {
+	case r >= 'А' && r <= 'Я':
+		return r + ('а' - 'А')
+	case r == 'Ё':
+		return 'ё'
+	}
+	return r
+}
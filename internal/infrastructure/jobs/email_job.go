@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/sirupsen/logrus"
+)
+
+// EmailJob периодически отправляет письма о заказах, время которых наступило,
+// включая повторы ранее не доставленных писем.
+type EmailJob struct {
+	*heartbeat
+
+	emailService services.EmailService
+	interval     time.Duration
+	logger       *logrus.Logger
+}
+
+func NewEmailJob(emailService services.EmailService, interval time.Duration, logger *logrus.Logger) *EmailJob {
+	return &EmailJob{
+		heartbeat:    newHeartbeat(),
+		emailService: emailService,
+		interval:     interval,
+		logger:       logger,
+	}
+}
+
+// Run выполняет задачу сразу и затем с заданным интервалом, пока не отменен ctx
+func (j *EmailJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *EmailJob) runOnce(ctx context.Context) {
+	defer j.beat()
+
+	sent, err := j.emailService.DispatchEmails(ctx, time.Now())
+	if err != nil {
+		j.logger.WithError(err).Error("Failed to send order emails")
+	}
+
+	if sent > 0 {
+		j.logger.WithField("sent", sent).Info("Sent order emails")
+	}
+}
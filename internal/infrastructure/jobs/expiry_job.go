@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ExpiryJob периодически отменяет заказы, которые не подтвердили за reservationTTL или не проверили
+// за holdTTL (0 - отложенные заказы не трогаются), и возвращает их резерв на склад
+type ExpiryJob struct {
+	*heartbeat
+
+	orderService   services.OrderService
+	reservationTTL time.Duration
+	holdTTL        time.Duration
+	interval       time.Duration
+	logger         *logrus.Logger
+}
+
+func NewExpiryJob(
+	orderService services.OrderService,
+	reservationTTL, holdTTL, interval time.Duration,
+	logger *logrus.Logger,
+) *ExpiryJob {
+	return &ExpiryJob{
+		heartbeat:      newHeartbeat(),
+		orderService:   orderService,
+		reservationTTL: reservationTTL,
+		holdTTL:        holdTTL,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+// Run выполняет задачу сразу и затем с заданным интервалом, пока не отменен ctx
+func (j *ExpiryJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *ExpiryJob) runOnce(ctx context.Context) {
+	defer j.beat()
+
+	now := time.Now()
+	var heldBefore time.Time
+	if j.holdTTL > 0 {
+		heldBefore = now.Add(-j.holdTTL)
+	}
+
+	expired, err := j.orderService.ExpireReservations(ctx, now.Add(-j.reservationTTL), heldBefore)
+	if err != nil {
+		j.logger.WithError(err).Error("Failed to expire orders")
+	}
+
+	if expired > 0 {
+		j.logger.WithField("expired", expired).Info("Expired pending and on-hold orders")
+	}
+}
@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// heartbeat запоминает, когда задача последний раз завершила прогон, для проверки готовности.
+// Неудачный прогон тоже считается: об ошибках зависимостей сообщают их собственные проверки.
+type heartbeat struct {
+	lastRun atomic.Int64
+}
+
+// newHeartbeat отсчитывает время от создания задачи, чтобы до первого прогона она не считалась зависшей
+func newHeartbeat() *heartbeat {
+	h := &heartbeat{}
+	h.beat()
+	return h
+}
+
+func (h *heartbeat) beat() {
+	h.lastRun.Store(time.Now().UnixNano())
+}
+
+// LastRun возвращает время завершения последнего прогона
+func (h *heartbeat) LastRun() time.Time {
+	return time.Unix(0, h.lastRun.Load())
+}
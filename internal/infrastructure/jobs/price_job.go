@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PriceJob периодически переносит наступившие запланированные цены в карточки товаров.
+// Заказы не зависят от задержки задачи: цена позиции берется из истории цен на момент заказа.
+type PriceJob struct {
+	*heartbeat
+
+	priceService services.PriceService
+	interval     time.Duration
+	logger       *logrus.Logger
+}
+
+func NewPriceJob(priceService services.PriceService, interval time.Duration, logger *logrus.Logger) *PriceJob {
+	return &PriceJob{
+		heartbeat:    newHeartbeat(),
+		priceService: priceService,
+		interval:     interval,
+		logger:       logger,
+	}
+}
+
+// Run выполняет задачу сразу и затем с заданным интервалом, пока не отменен ctx
+func (j *PriceJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *PriceJob) runOnce(ctx context.Context) {
+	defer j.beat()
+
+	applied, err := j.priceService.ApplyScheduledPrices(ctx, time.Now())
+	if err != nil {
+		j.logger.WithError(err).Error("Failed to apply scheduled prices")
+	}
+
+	if applied > 0 {
+		j.logger.WithField("applied", applied).Info("Applied scheduled prices")
+	}
+}
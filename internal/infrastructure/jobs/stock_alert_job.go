@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/sirupsen/logrus"
+)
+
+// StockAlertJob периодически отправляет накопленные уведомления об остатках.
+// Уведомления попадают в очередь вместе с изменением остатка, поэтому задача только доставляет их.
+type StockAlertJob struct {
+	*heartbeat
+
+	stockAlertService services.StockAlertService
+	interval          time.Duration
+	logger            *logrus.Logger
+}
+
+func NewStockAlertJob(stockAlertService services.StockAlertService, interval time.Duration, logger *logrus.Logger) *StockAlertJob {
+	return &StockAlertJob{
+		heartbeat:         newHeartbeat(),
+		stockAlertService: stockAlertService,
+		interval:          interval,
+		logger:            logger,
+	}
+}
+
+// Run выполняет задачу сразу и затем с заданным интервалом, пока не отменен ctx
+func (j *StockAlertJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *StockAlertJob) runOnce(ctx context.Context) {
+	defer j.beat()
+
+	sent, err := j.stockAlertService.DispatchNotifications(ctx, time.Now())
+	if err != nil {
+		j.logger.WithError(err).Error("Failed to send stock notifications")
+	}
+
+	if sent > 0 {
+		j.logger.WithField("sent", sent).Info("Sent stock notifications")
+	}
+}
@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookJob периодически отправляет доставки вебхуков, время которых наступило,
+// включая повторы ранее не доставленных событий.
+type WebhookJob struct {
+	*heartbeat
+
+	webhookService services.WebhookService
+	interval       time.Duration
+	logger         *logrus.Logger
+}
+
+func NewWebhookJob(webhookService services.WebhookService, interval time.Duration, logger *logrus.Logger) *WebhookJob {
+	return &WebhookJob{
+		heartbeat:      newHeartbeat(),
+		webhookService: webhookService,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+// Run выполняет задачу сразу и затем с заданным интервалом, пока не отменен ctx
+func (j *WebhookJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *WebhookJob) runOnce(ctx context.Context) {
+	defer j.beat()
+
+	delivered, err := j.webhookService.DispatchDeliveries(ctx, time.Now())
+	if err != nil {
+		j.logger.WithError(err).Error("Failed to deliver order webhooks")
+	}
+
+	if delivered > 0 {
+		j.logger.WithField("delivered", delivered).Info("Delivered order webhooks")
+	}
+}
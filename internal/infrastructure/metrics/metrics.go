@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "orders"
+
+// unmatchedRoute - метка для запросов без маршрута: сырой путь сделал бы число рядов неограниченным
+const unmatchedRoute = "unmatched"
+
+// Metrics - метрики сервиса в собственном реестре Prometheus, отдаются по /metrics.
+// Экземпляр создается один раз при запуске и передается компонентам, которые пишут метрики.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.HistogramVec
+	rateLimited  *prometheus.CounterVec
+
+	ordersCreated       prometheus.Counter
+	ordersConfirmed     prometheus.Counter
+	ordersCancelled     *prometheus.CounterVec
+	reservationFailures prometheus.Counter
+	orderValue          prometheus.Histogram
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Время обработки HTTP-запросов по маршруту и статусу ответа.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "rate_limited_requests_total",
+			Help:      "Запросы, отклоненные ограничением частоты.",
+		}, []string{"route"}),
+
+		ordersCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "created_total",
+			Help:      "Созданные заказы.",
+		}),
+		ordersConfirmed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "confirmed_total",
+			Help:      "Подтвержденные заказы.",
+		}),
+		ordersCancelled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cancelled_total",
+			Help:      "Отмененные заказы: по запросу или по истечении резерва.",
+		}, []string{"reason"}),
+		reservationFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "stock_reservation_failures_total",
+			Help:      "Заказы, отклоненные из-за нехватки товара на складе.",
+		}),
+		orderValue: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "value_rubles",
+			Help:      "Сумма созданного заказа в рублях.",
+			Buckets:   []float64{100, 500, 1_000, 2_500, 5_000, 10_000, 25_000, 50_000, 100_000, 250_000},
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.rateLimited,
+		m.ordersCreated,
+		m.ordersConfirmed,
+		m.ordersCancelled,
+		m.reservationFailures,
+		m.orderValue,
+	)
+
+	// ряды с причинами отмены видны с нуля, а не с первой отмены
+	m.ordersCancelled.WithLabelValues(CancelReasonRequest)
+	m.ordersCancelled.WithLabelValues(CancelReasonExpired)
+
+	return m
+}
+
+// RegisterDB добавляет статистику пула соединений (go_sql_*) с меткой db_name
+func (m *Metrics) RegisterDB(name string, db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler отдает метрики в формате Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveHTTPRequest записывает обработанный запрос; route - шаблон маршрута Gin, пустой у ненайденных
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// RateLimited учитывает запрос, отклоненный ограничением частоты
+func (m *Metrics) RateLimited(route string) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+	m.rateLimited.WithLabelValues(route).Inc()
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CancelReasonRequest = "request"
+	CancelReasonExpired = "expired"
+)
+
+// orderService считает события заказов поверх сервиса заказов. Учитываются только завершившиеся
+// операции: в сервисе счетчик увеличился бы и в транзакции, которая затем откатилась.
+type orderService struct {
+	services.OrderService
+	metrics *Metrics
+}
+
+// NewOrderService оборачивает сервис заказов; остальные методы передаются ему без изменений
+func NewOrderService(next services.OrderService, metrics *Metrics) services.OrderService {
+	return &orderService{OrderService: next, metrics: metrics}
+}
+
+func (s *orderService) CreateOrder(ctx context.Context, request *services.OrderRequest) (*entities.Order, error) {
+	order, err := s.OrderService.CreateOrder(ctx, request)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrInsufficientStock) {
+			s.metrics.reservationFailures.Inc()
+		}
+		return nil, err
+	}
+
+	s.metrics.ordersCreated.Inc()
+	s.metrics.orderValue.Observe(float64(order.Total) / 100)
+
+	return order, nil
+}
+
+func (s *orderService) ConfirmOrder(ctx context.Context, orderID uuid.UUID) error {
+	if err := s.OrderService.ConfirmOrder(ctx, orderID); err != nil {
+		return err
+	}
+
+	s.metrics.ordersConfirmed.Inc()
+	return nil
+}
+
+func (s *orderService) CancelOrder(ctx context.Context, orderID uuid.UUID) error {
+	if err := s.OrderService.CancelOrder(ctx, orderID); err != nil {
+		return err
+	}
+
+	s.metrics.ordersCancelled.WithLabelValues(CancelReasonRequest).Inc()
+	return nil
+}
+
+func (s *orderService) ExpireReservations(ctx context.Context, before, heldBefore time.Time) (int, error) {
+	// заказы, отмененные до ошибки, уже зафиксированы и тоже учитываются
+	expired, err := s.OrderService.ExpireReservations(ctx, before, heldBefore)
+	s.metrics.ordersCancelled.WithLabelValues(CancelReasonExpired).Add(float64(expired))
+
+	return expired, err
+}
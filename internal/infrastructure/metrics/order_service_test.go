@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeOrderService возвращает заранее заданные результаты
+type fakeOrderService struct {
+	services.OrderService
+
+	order   *entities.Order
+	err     error
+	expired int
+}
+
+func (f *fakeOrderService) CreateOrder(context.Context, *services.OrderRequest) (*entities.Order, error) {
+	return f.order, f.err
+}
+
+func (f *fakeOrderService) CancelOrder(context.Context, uuid.UUID) error {
+	return f.err
+}
+
+func (f *fakeOrderService) ExpireReservations(context.Context, time.Time, time.Time) (int, error) {
+	return f.expired, f.err
+}
+
+func TestOrderService_CountsCompletedOperations(t *testing.T) {
+	m := New()
+	next := &fakeOrderService{order: &entities.Order{Total: 150_000}}
+	service := NewOrderService(next, m)
+	ctx := context.Background()
+
+	_, err := service.CreateOrder(ctx, &services.OrderRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.ordersCreated))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.orderValue))
+
+	next.err = domainErrors.ErrInsufficientStock
+	_, err = service.CreateOrder(ctx, &services.OrderRequest{})
+	assert.ErrorIs(t, err, domainErrors.ErrInsufficientStock)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.ordersCreated))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.reservationFailures))
+
+	// неудачная отмена не учитывается
+	next.err = errors.New("db is down")
+	assert.Error(t, service.CancelOrder(ctx, uuid.New()))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.ordersCancelled.WithLabelValues(CancelReasonRequest)))
+
+	// отмененные до ошибки заказы уже зафиксированы
+	next.expired = 3
+	_, err = service.ExpireReservations(ctx, time.Now(), time.Time{})
+	assert.Error(t, err)
+	assert.Equal(t, 3.0, testutil.ToFloat64(m.ordersCancelled.WithLabelValues(CancelReasonExpired)))
+}
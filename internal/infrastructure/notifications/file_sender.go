@@ -0,0 +1,40 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// FileSender складывает письма в каталог файлами .eml вместо отправки. Используется при локальной
+// разработке: письмо можно открыть почтовым клиентом и проверить верстку.
+type FileSender struct {
+	dir  string
+	from string
+}
+
+func NewFileSender(dir, from string) *FileSender {
+	return &FileSender{dir: dir, from: from}
+}
+
+func (s *FileSender) Send(_ context.Context, message *entities.EmailMessage) error {
+	now := time.Now()
+
+	data, err := buildMIMEMessage(s.from, message, now)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405"), uuid.New().String()[:8])
+	return os.WriteFile(filepath.Join(s.dir, name), data, 0o644)
+}
@@ -0,0 +1,43 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogNotifier пишет уведомления об остатках в лог. Используется при локальном запуске,
+// пока не подключен реальный канал доставки.
+type LogNotifier struct {
+	logger *logrus.Logger
+}
+
+func NewLogNotifier(logger *logrus.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(_ context.Context, notification *entities.StockNotification) error {
+	fields := logrus.Fields{
+		"kind":       notification.Kind,
+		"product_id": notification.ProductID,
+		"product":    notification.ProductName,
+		"sku":        notification.SKU,
+		"quantity":   notification.Quantity,
+	}
+	if notification.VariantID != nil {
+		fields["variant_id"] = *notification.VariantID
+	}
+
+	switch notification.Kind {
+	case entities.StockNotificationBackInStock:
+		fields["user_id"] = *notification.UserID
+		n.logger.WithFields(fields).Info("Product is back in stock")
+	case entities.StockNotificationLowStock:
+		fields["threshold"] = notification.Threshold
+		n.logger.WithFields(fields).Warn("Product stock is low")
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+)
+
+// buildMIMEMessage собирает письмо multipart/alternative с текстовой и HTML-версией
+func buildMIMEMessage(from string, message *entities.EmailMessage, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=utf-8", content: message.TextBody},
+		{contentType: "text/html; charset=utf-8", content: message.HTMLBody},
+	}
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := quotedprintable.NewWriter(partWriter)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var result bytes.Buffer
+	fmt.Fprintf(&result, "From: %s\r\n", from)
+	fmt.Fprintf(&result, "To: %s\r\n", message.To)
+	fmt.Fprintf(&result, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&result, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	result.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&result, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+	result.Write(body.Bytes())
+
+	return result.Bytes(), nil
+}
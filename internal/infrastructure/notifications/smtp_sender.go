@@ -0,0 +1,121 @@
+package notifications
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+)
+
+// smtpTimeout ограничивает отправку одного письма, если у контекста нет своего срока:
+// зависший сервер не должен останавливать очередь писем
+const smtpTimeout = 30 * time.Second
+
+// SMTPSender отправляет письма через SMTP-сервер. Если сервер поддерживает STARTTLS,
+// соединение шифруется; авторизация выполняется, только если задан логин.
+type SMTPSender struct {
+	host    string
+	address string
+	auth    smtp.Auth
+	from    string
+}
+
+func NewSMTPSender(host string, port int, username, password, from string) *SMTPSender {
+	sender := &SMTPSender{
+		host:    host,
+		address: net.JoinHostPort(host, strconv.Itoa(port)),
+		from:    from,
+	}
+	if username != "" {
+		sender.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return sender
+}
+
+// Send повторяет smtp.SendMail, но соблюдает ctx: соединение устанавливается с его учетом,
+// а при отмене ctx или истечении срока обрывается на любом шаге диалога
+func (s *SMTPSender) Send(ctx context.Context, message *entities.EmailMessage) error {
+	data, err := buildMIMEMessage(s.from, message, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, smtpTimeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if err := s.send(conn, message.To, data); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("send email: %w", ctx.Err())
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *SMTPSender) send(conn net.Conn, to string, data []byte) error {
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("smtp: server doesn't support AUTH")
+		}
+		if err := client.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
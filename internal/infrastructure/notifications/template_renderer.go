@@ -0,0 +1,160 @@
+package notifications
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// templateFuncs доступны и в текстовых, и в HTML-шаблонах
+var templateFuncs = map[string]any{
+	"money": formatMoney,
+	"date":  func(t time.Time) string { return t.Format("02.01.2006") },
+}
+
+// TemplateRenderer собирает письма о заказах из шаблонов templates/<язык>/<событие>.txt и .html.
+// Тема письма задается в текстовом шаблоне блоком "<событие>.subject". Для языка без шаблонов
+// используется язык по умолчанию.
+type TemplateRenderer struct {
+	defaultLanguage string
+	text            map[string]*texttemplate.Template
+	html            map[string]*htmltemplate.Template
+}
+
+// orderEmailData - данные заказа, доступные в шаблонах; суммы в копейках
+type orderEmailData struct {
+	CustomerName string
+	OrderID      uuid.UUID
+	OrderNumber  string
+	CreatedAt    time.Time
+	Items        []orderEmailItem
+	Total        int64
+	OnHold       bool
+}
+
+type orderEmailItem struct {
+	Name         string
+	Quantity     int
+	Backordered  int
+	PricePerItem int64
+	Total        int64
+}
+
+// NewTemplateRenderer разбирает все встроенные шаблоны при запуске, чтобы ошибка в шаблоне
+// обнаружилась сразу, а не при отправке письма
+func NewTemplateRenderer(defaultLanguage string) (*TemplateRenderer, error) {
+	languages, err := fs.ReadDir(templatesFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	renderer := &TemplateRenderer{
+		defaultLanguage: defaultLanguage,
+		text:            make(map[string]*texttemplate.Template),
+		html:            make(map[string]*htmltemplate.Template),
+	}
+
+	for _, language := range languages {
+		if !language.IsDir() {
+			continue
+		}
+		dir := "templates/" + language.Name()
+
+		text, err := texttemplate.New(language.Name()).Funcs(templateFuncs).ParseFS(templatesFS, dir+"/*.txt")
+		if err != nil {
+			return nil, fmt.Errorf("parse %s text templates: %w", language.Name(), err)
+		}
+
+		html, err := htmltemplate.New(language.Name()).Funcs(templateFuncs).ParseFS(templatesFS, dir+"/*.html")
+		if err != nil {
+			return nil, fmt.Errorf("parse %s html templates: %w", language.Name(), err)
+		}
+
+		renderer.text[language.Name()] = text
+		renderer.html[language.Name()] = html
+	}
+
+	if _, ok := renderer.text[defaultLanguage]; !ok {
+		return nil, fmt.Errorf("no email templates for default language %q", defaultLanguage)
+	}
+
+	return renderer, nil
+}
+
+func (r *TemplateRenderer) RenderOrderEmail(
+	delivery *entities.EmailDelivery,
+	order *entities.Order,
+	user *entities.User,
+) (*entities.EmailMessage, error) {
+	language := delivery.Language
+	if _, ok := r.text[language]; !ok {
+		language = r.defaultLanguage
+	}
+
+	text := r.text[language]
+	html := r.html[language]
+	event := string(delivery.Event)
+	data := newOrderEmailData(order, user)
+
+	var subject, textBody, htmlBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, event+".subject", data); err != nil {
+		return nil, fmt.Errorf("render %s subject: %w", event, err)
+	}
+	if err := text.ExecuteTemplate(&textBody, event+".txt", data); err != nil {
+		return nil, fmt.Errorf("render %s text: %w", event, err)
+	}
+	if err := html.ExecuteTemplate(&htmlBody, event+".html", data); err != nil {
+		return nil, fmt.Errorf("render %s html: %w", event, err)
+	}
+
+	return &entities.EmailMessage{
+		To:       delivery.Recipient,
+		Subject:  strings.TrimSpace(subject.String()),
+		TextBody: strings.TrimSpace(textBody.String()) + "\n",
+		HTMLBody: htmlBody.String(),
+	}, nil
+}
+
+func newOrderEmailData(order *entities.Order, user *entities.User) *orderEmailData {
+	items := make([]orderEmailItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = orderEmailItem{
+			Name:         item.ProductSnapshot.DisplayName(),
+			Quantity:     item.Quantity,
+			Backordered:  item.Backordered,
+			PricePerItem: item.PricePerItem,
+			Total:        item.Total,
+		}
+	}
+
+	return &orderEmailData{
+		CustomerName: user.GetFullName(),
+		OrderID:      order.ID,
+		OrderNumber:  strings.ToUpper(order.ID.String()[:8]),
+		CreatedAt:    order.CreatedAt,
+		Items:        items,
+		Total:        order.Total,
+		OnHold:       order.Status == entities.OrderStatusOnHold,
+	}
+}
+
+func formatMoney(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
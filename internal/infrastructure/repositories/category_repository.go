@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) repositories.CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	model := &models.CategoryModel{}
+	model.FromEntity(category)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	category.ID = model.ID
+	category.CreatedAt = model.CreatedAt
+	category.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+func (r *categoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	var model models.CategoryModel
+	if err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *categoryRepository) GetBySlug(ctx context.Context, slug string) (*entities.Category, error) {
+	var model models.CategoryModel
+	if err := r.db.WithContext(ctx).First(&model, "slug = ?", slug).Error; err != nil {
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *categoryRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.Category, error) {
+	var categoryModels []models.CategoryModel
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&categoryModels).Error; err != nil {
+		return nil, err
+	}
+
+	return toCategoryEntities(categoryModels), nil
+}
+
+func (r *categoryRepository) GetAll(ctx context.Context) ([]*entities.Category, error) {
+	var categoryModels []models.CategoryModel
+	if err := r.db.WithContext(ctx).Order("position, name").Find(&categoryModels).Error; err != nil {
+		return nil, err
+	}
+
+	return toCategoryEntities(categoryModels), nil
+}
+
+func toCategoryEntities(categoryModels []models.CategoryModel) []*entities.Category {
+	result := make([]*entities.Category, len(categoryModels))
+	for i, model := range categoryModels {
+		result[i] = model.ToEntity()
+	}
+	return result
+}
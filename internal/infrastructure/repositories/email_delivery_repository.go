@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type emailDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailDeliveryRepository(db *gorm.DB) repositories.EmailDeliveryRepository {
+	return &emailDeliveryRepository{db: db}
+}
+
+func (r *emailDeliveryRepository) Create(ctx context.Context, delivery *entities.EmailDelivery) error {
+	model := &models.EmailDeliveryModel{}
+	model.FromEntity(delivery)
+
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(model).Error
+}
+
+func (r *emailDeliveryRepository) Update(ctx context.Context, delivery *entities.EmailDelivery) error {
+	model := &models.EmailDeliveryModel{}
+	model.FromEntity(delivery)
+
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(model).Error
+}
+
+func (r *emailDeliveryRepository) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]*entities.EmailDelivery, error) {
+	var deliveryModels []models.EmailDeliveryModel
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED: строки, которые сейчас забирает другой экземпляр, пропускаем, а не ждем
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entities.EmailDeliveryStatusPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveryModels).Error; err != nil {
+			return err
+		}
+		if len(deliveryModels) == 0 {
+			return nil
+		}
+
+		leasedUntil := now.Add(lease)
+		ids := make([]uuid.UUID, len(deliveryModels))
+		for i := range deliveryModels {
+			ids[i] = deliveryModels[i].ID
+			deliveryModels[i].NextAttemptAt = leasedUntil
+		}
+
+		return tx.Model(&models.EmailDeliveryModel{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", leasedUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toEmailDeliveries(deliveryModels), nil
+}
+
+func (r *emailDeliveryRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.EmailDelivery, error) {
+	var deliveryModels []models.EmailDeliveryModel
+	if err := r.db.WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&deliveryModels).Error; err != nil {
+		return nil, err
+	}
+
+	return toEmailDeliveries(deliveryModels), nil
+}
+
+func toEmailDeliveries(deliveryModels []models.EmailDeliveryModel) []*entities.EmailDelivery {
+	result := make([]*entities.EmailDelivery, len(deliveryModels))
+	for i := range deliveryModels {
+		result[i] = deliveryModels[i].ToEntity()
+	}
+
+	return result
+}
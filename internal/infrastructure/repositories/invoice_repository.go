@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const pdfContentType = "application/pdf"
+
+type invoiceRepository struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepository(db *gorm.DB) repositories.InvoiceRepository {
+	return &invoiceRepository{db: db}
+}
+
+func (r *invoiceRepository) NextSequence(ctx context.Context, year int) (int, error) {
+	model := &models.InvoiceSequenceModel{Year: year, LastNumber: 1}
+
+	// Upsert блокирует строку года до конца транзакции, поэтому номера выдаются строго по порядку
+	err := r.db.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "year"}},
+			DoUpdates: clause.Set{{
+				Column: clause.Column{Name: "last_number"},
+				Value:  gorm.Expr("? + 1", clause.Column{Table: clause.CurrentTable, Name: "last_number"}),
+			}},
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "last_number"}}},
+	).Create(model).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return model.LastNumber, nil
+}
+
+func (r *invoiceRepository) Create(ctx context.Context, invoice *entities.Invoice, pdf []byte) error {
+	model := &models.InvoiceModel{}
+	if err := model.FromEntity(invoice); err != nil {
+		return err
+	}
+
+	model.Document = models.InvoiceDocumentModel{
+		InvoiceID:   invoice.ID,
+		ContentType: pdfContentType,
+		Content:     pdf,
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	invoice.ID = model.ID
+	invoice.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (r *invoiceRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) (*entities.Invoice, error) {
+	var model models.InvoiceModel
+	if err := r.db.WithContext(ctx).First(&model, "order_id = ?", orderID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+
+	return model.ToEntity()
+}
+
+func (r *invoiceRepository) GetPDF(ctx context.Context, invoiceID uuid.UUID) ([]byte, error) {
+	var document models.InvoiceDocumentModel
+	if err := r.db.WithContext(ctx).First(&document, "invoice_id = ?", invoiceID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+
+	return document.Content, nil
+}
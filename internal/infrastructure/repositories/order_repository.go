@@ -2,21 +2,27 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/AndrivA89/orders/internal/domain/entities"
 	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database"
 	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type orderRepository struct {
 	db *gorm.DB
+	// reads направляет карточку и историю заказов на реплику; записи отмечаются у клиента,
+	// чтобы покупатель сразу видел свой новый или измененный заказ
+	reads *database.ReadRouter
 }
 
-func NewOrderRepository(db *gorm.DB) repositories.OrderRepository {
-	return &orderRepository{db: db}
+func NewOrderRepository(db *gorm.DB, reads *database.ReadRouter) repositories.OrderRepository {
+	return &orderRepository{db: db, reads: reads}
 }
 
 func (r *orderRepository) Create(ctx context.Context, order *entities.Order) error {
@@ -40,13 +46,27 @@ func (r *orderRepository) Create(ctx context.Context, order *entities.Order) err
 	order.ID = model.ID
 	order.CreatedAt = model.CreatedAt
 	order.UpdatedAt = model.UpdatedAt
+	r.reads.MarkWritten(ctx)
 
 	return nil
 }
 
 func (r *orderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
 	var model models.OrderModel
-	if err := r.db.WithContext(ctx).Preload("Items").First(&model, "id = ?", id).Error; err != nil {
+	if err := r.reads.Reader(ctx).WithContext(ctx).Preload("Items").First(&model, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return model.ToEntity()
+}
+
+func (r *orderRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+	var model models.OrderModel
+	// блокируется только строка заказа, позиции подгружаются отдельным запросом
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Items").
+		First(&model, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 
@@ -55,7 +75,7 @@ func (r *orderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.
 
 func (r *orderRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Order, error) {
 	var orderModels []models.OrderModel
-	if err := r.db.WithContext(ctx).
+	if err := r.reads.Reader(ctx).WithContext(ctx).
 		Preload("Items").
 		Where("user_id = ?", userID).
 		Limit(limit).Offset(offset).
@@ -81,9 +101,131 @@ func (r *orderRepository) Update(ctx context.Context, order *entities.Order) err
 		return err
 	}
 
-	return r.db.WithContext(ctx).Save(model).Error
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return err
+	}
+	r.reads.MarkWritten(ctx)
+
+	return nil
 }
 
 func (r *orderRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.OrderModel{}, "id = ?", id).Error
+	if err := r.db.WithContext(ctx).Delete(&models.OrderModel{}, "id = ?", id).Error; err != nil {
+		return err
+	}
+	r.reads.MarkWritten(ctx)
+
+	return nil
+}
+
+func (r *orderRepository) CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.OrderModel{}).
+		Where("user_id = ? AND created_at >= ? AND status <> ?", userID, since, string(entities.OrderStatusCancelled)).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *orderRepository) QuantitiesByUserSince(
+	ctx context.Context,
+	userID uuid.UUID,
+	since time.Time,
+) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		ProductID uuid.UUID
+		Quantity  int
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&models.OrderItemModel{}).
+		Select("order_item_models.product_id, SUM(order_item_models.quantity) AS quantity").
+		Joins("JOIN order_models o ON o.id = order_item_models.order_id AND o.deleted_at IS NULL").
+		Where("o.user_id = ? AND o.created_at >= ? AND o.status <> ?", userID, since, string(entities.OrderStatusCancelled)).
+		Group("order_item_models.product_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	quantities := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		quantities[row.ProductID] = row.Quantity
+	}
+
+	return quantities, nil
+}
+
+func (r *orderRepository) GetBackorderedItemsForUpdate(
+	ctx context.Context,
+	productID uuid.UUID,
+	variantID *uuid.UUID,
+) ([]*entities.OrderItem, error) {
+	query := r.db.WithContext(ctx).
+		Joins("JOIN order_models o ON o.id = order_item_models.order_id AND o.deleted_at IS NULL").
+		Where("order_item_models.product_id = ? AND order_item_models.backordered > 0", productID).
+		Where("o.status <> ?", string(entities.OrderStatusCancelled))
+
+	if variantID != nil {
+		query = query.Where("order_item_models.variant_id = ?", *variantID)
+	} else {
+		query = query.Where("order_item_models.variant_id IS NULL")
+	}
+
+	var itemModels []models.OrderItemModel
+	if err := query.
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "order_item_models"}}).
+		Order("o.created_at, order_item_models.created_at").
+		Find(&itemModels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.OrderItem, len(itemModels))
+	for i := range itemModels {
+		item, err := itemModels[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		result[i] = item
+	}
+
+	return result, nil
+}
+
+func (r *orderRepository) UpdateItem(ctx context.Context, item *entities.OrderItem) error {
+	model := &models.OrderItemModel{}
+	if err := model.FromEntity(item); err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(model).Error; err != nil {
+		return err
+	}
+	r.reads.MarkWritten(ctx)
+
+	return nil
+}
+
+func (r *orderRepository) GetReservedBefore(ctx context.Context, status entities.OrderStatus, before time.Time, limit int) ([]*entities.Order, error) {
+	var orderModels []models.OrderModel
+	if err := r.db.WithContext(ctx).
+		Preload("Items").
+		Where("status = ? AND reserved_at < ?", string(status), before).
+		Order("reserved_at").
+		Limit(limit).
+		Find(&orderModels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.Order, len(orderModels))
+	for i, model := range orderModels {
+		order, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		result[i] = order
+	}
+
+	return result, nil
 }
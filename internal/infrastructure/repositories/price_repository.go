@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type priceRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceRepository(db *gorm.DB) repositories.PriceRepository {
+	return &priceRepository{db: db}
+}
+
+func (r *priceRepository) Create(ctx context.Context, change *entities.PriceChange) error {
+	model := &models.PriceChangeModel{}
+	model.FromEntity(change)
+
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(model).Error; err != nil {
+		return err
+	}
+
+	change.ID = model.ID
+	change.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (r *priceRepository) Update(ctx context.Context, change *entities.PriceChange) error {
+	model := &models.PriceChangeModel{}
+	model.FromEntity(change)
+
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(model).Error
+}
+
+func (r *priceRepository) GetByProductID(ctx context.Context, productID uuid.UUID) (entities.PriceHistory, error) {
+	var changeModels []models.PriceChangeModel
+	if err := r.db.WithContext(ctx).
+		Where("product_id = ?", productID).
+		Order("valid_from").
+		Find(&changeModels).Error; err != nil {
+		return nil, err
+	}
+
+	history := make(entities.PriceHistory, len(changeModels))
+	for i, model := range changeModels {
+		history[i] = model.ToEntity()
+	}
+
+	return history, nil
+}
+
+func (r *priceRepository) GetByProductIDs(
+	ctx context.Context,
+	productIDs []uuid.UUID,
+) (map[uuid.UUID]entities.PriceHistory, error) {
+	var changeModels []models.PriceChangeModel
+	if err := r.db.WithContext(ctx).
+		Where("product_id IN ?", productIDs).
+		Order("product_id, valid_from").
+		Find(&changeModels).Error; err != nil {
+		return nil, err
+	}
+
+	histories := make(map[uuid.UUID]entities.PriceHistory, len(productIDs))
+	for _, model := range changeModels {
+		histories[model.ProductID] = append(histories[model.ProductID], model.ToEntity())
+	}
+
+	return histories, nil
+}
+
+func (r *priceRepository) GetEffective(ctx context.Context, productID uuid.UUID, at time.Time) (*entities.PriceChange, error) {
+	var model models.PriceChangeModel
+	err := r.db.WithContext(ctx).
+		Where("product_id = ? AND valid_from <= ? AND (valid_to IS NULL OR valid_to > ?)", productID, at, at).
+		Order("valid_from DESC").
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrPriceNotFound
+		}
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *priceRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*entities.PriceChange, error) {
+	var changeModels []models.PriceChangeModel
+	if err := r.db.WithContext(ctx).
+		Where("applied_at IS NULL AND valid_from <= ?", now).
+		Order("valid_from").
+		Limit(limit).
+		Find(&changeModels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.PriceChange, len(changeModels))
+	for i, model := range changeModels {
+		result[i] = model.ToEntity()
+	}
+
+	return result, nil
+}
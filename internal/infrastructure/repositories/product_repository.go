@@ -5,18 +5,35 @@ import (
 
 	"github.com/AndrivA89/orders/internal/domain/entities"
 	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database"
 	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// productsInCategoryTreeQuery выбирает товары категории вместе со всеми ее потомками
+const productsInCategoryTreeQuery = `
+WITH RECURSIVE category_tree AS (
+    SELECT id FROM category_models WHERE id = ? AND deleted_at IS NULL
+    UNION ALL
+    SELECT c.id FROM category_models c
+    JOIN category_tree t ON c.parent_id = t.id
+    WHERE c.deleted_at IS NULL
+)
+SELECT pc.product_id FROM product_category_models pc
+WHERE pc.category_id IN (SELECT id FROM category_tree)`
+
 type productRepository struct {
 	db *gorm.DB
+	// reads направляет карточку, списки (в том числе пакетную загрузку GraphQL) и выгрузку каталога
+	// на реплику; остальные чтения, в том числе перед изменением товара, идут через db
+	reads *database.ReadRouter
 }
 
-func NewProductRepository(db *gorm.DB) repositories.ProductRepository {
-	return &productRepository{db: db}
+func NewProductRepository(db *gorm.DB, reads *database.ReadRouter) repositories.ProductRepository {
+	return &productRepository{db: db, reads: reads}
 }
 
 func (r *productRepository) Create(ctx context.Context, product *entities.Product) error {
@@ -25,7 +42,8 @@ func (r *productRepository) Create(ctx context.Context, product *entities.Produc
 		return err
 	}
 
-	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+	// Категории уже существуют - создаем только записи в таблице связей
+	if err := r.db.WithContext(ctx).Omit("Categories.*").Create(model).Error; err != nil {
 		return err
 	}
 
@@ -38,17 +56,36 @@ func (r *productRepository) Create(ctx context.Context, product *entities.Produc
 
 func (r *productRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
 	var model models.ProductModel
-	if err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error; err != nil {
+	if err := r.preloadFrom(ctx, r.reads.Reader(ctx)).First(&model, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 
 	return model.ToEntity(), nil
 }
 
+func (r *productRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.Product, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var productModels []models.ProductModel
+	if err := r.preloadFrom(ctx, r.reads.Reader(ctx)).Where("id IN ?", ids).Find(&productModels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.Product, len(productModels))
+	for i, model := range productModels {
+		result[i] = model.ToEntity()
+	}
+
+	return result, nil
+}
+
 func (r *productRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
 	var model models.ProductModel
-	// SELECT ... FOR UPDATE для предотвращения race conditions
-	if err := r.db.WithContext(ctx).Set("gorm:query_option", "FOR UPDATE").First(&model, "id = ?", id).Error; err != nil {
+	// SELECT ... FOR UPDATE: строка товара заблокирована до конца транзакции, варианты и категории
+	// подгружаются отдельными запросами без блокировки
+	if err := r.preload(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&model, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 
@@ -57,7 +94,29 @@ func (r *productRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID)
 
 func (r *productRepository) GetAll(ctx context.Context, limit, offset int) ([]*entities.Product, error) {
 	var productModels []models.ProductModel
-	if err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&productModels).Error; err != nil {
+	if err := r.preloadFrom(ctx, r.reads.Reader(ctx)).Limit(limit).Offset(offset).Find(&productModels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.Product, len(productModels))
+	for i, model := range productModels {
+		result[i] = model.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (r *productRepository) GetByCategory(
+	ctx context.Context,
+	categoryID uuid.UUID,
+	limit, offset int,
+) ([]*entities.Product, error) {
+	var productModels []models.ProductModel
+	if err := r.preloadFrom(ctx, r.reads.Reader(ctx)).
+		Where("id IN (?)", gorm.Expr(productsInCategoryTreeQuery, categoryID)).
+		Order("created_at, id").
+		Limit(limit).Offset(offset).
+		Find(&productModels).Error; err != nil {
 		return nil, err
 	}
 
@@ -69,11 +128,153 @@ func (r *productRepository) GetAll(ctx context.Context, limit, offset int) ([]*e
 	return result, nil
 }
 
+func (r *productRepository) SetCategories(ctx context.Context, productID uuid.UUID, categoryIDs []uuid.UUID) error {
+	db := r.db.WithContext(ctx)
+
+	if err := db.Where("product_id = ?", productID).Delete(&models.ProductCategoryModel{}).Error; err != nil {
+		return err
+	}
+
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	links := make([]models.ProductCategoryModel, len(categoryIDs))
+	for i, categoryID := range categoryIDs {
+		links[i] = models.ProductCategoryModel{ProductID: productID, CategoryID: categoryID}
+	}
+
+	return db.Create(&links).Error
+}
+
 func (r *productRepository) Update(ctx context.Context, product *entities.Product) error {
 	model := &models.ProductModel{}
 	if err := model.FromEntity(product); err != nil {
 		return err
 	}
 
+	// Варианты сохраняются отдельно через UpdateVariant
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(model).Error
+}
+
+func (r *productRepository) CreateVariant(ctx context.Context, variant *entities.ProductVariant) error {
+	model := &models.ProductVariantModel{}
+	if err := model.FromEntity(variant); err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	variant.ID = model.ID
+	variant.CreatedAt = model.CreatedAt
+	variant.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+func (r *productRepository) GetVariantByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.ProductVariant, error) {
+	var model models.ProductVariantModel
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&model, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *productRepository) UpdateVariant(ctx context.Context, variant *entities.ProductVariant) error {
+	model := &models.ProductVariantModel{}
+	if err := model.FromEntity(variant); err != nil {
+		return err
+	}
+
 	return r.db.WithContext(ctx).Save(model).Error
 }
+
+func (r *productRepository) GetBySKUsForUpdate(ctx context.Context, skus []string) ([]*entities.Product, error) {
+	var productModels []models.ProductModel
+	// единый порядок блокировок не дает двум импортам с пересекающимися пачками взаимно заблокироваться
+	if err := r.preload(ctx).
+		Where("sku IN ?", skus).
+		Order("sku").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Find(&productModels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.Product, len(productModels))
+	for i, model := range productModels {
+		result[i] = model.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (r *productRepository) UpsertBySKU(ctx context.Context, products []*entities.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	productModels := make([]models.ProductModel, len(products))
+	for i, product := range products {
+		if err := productModels[i].FromEntity(product); err != nil {
+			return err
+		}
+	}
+
+	// deleted_at входит в обновляемые колонки: повторный импорт восстанавливает удаленный товар
+	return r.db.WithContext(ctx).Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "sku"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"description", "tags", "quantity", "price", "updated_at", "deleted_at",
+		}),
+	}).Create(&productModels).Error
+}
+
+func (r *productRepository) ForEachBatch(
+	ctx context.Context,
+	batchSize int,
+	fn func(products []*entities.Product) error,
+) error {
+	var productModels []models.ProductModel
+
+	return r.preloadFrom(ctx, r.reads.Reader(ctx)).FindInBatches(&productModels, batchSize, func(tx *gorm.DB, batch int) error {
+		products := make([]*entities.Product, len(productModels))
+		for i, model := range productModels {
+			products[i] = model.ToEntity()
+		}
+		return fn(products)
+	}).Error
+}
+
+func (r *productRepository) GetBackorderedIDs(ctx context.Context) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&models.OrderItemModel{}).
+		Distinct("order_item_models.product_id").
+		Joins("JOIN order_models o ON o.id = order_item_models.order_id AND o.deleted_at IS NULL").
+		Where("order_item_models.backordered > 0 AND o.status <> ?", string(entities.OrderStatusCancelled)).
+		Order("order_item_models.product_id").
+		Pluck("order_item_models.product_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *productRepository) preload(ctx context.Context) *gorm.DB {
+	return r.preloadFrom(ctx, r.db)
+}
+
+func (r *productRepository) preloadFrom(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return db.WithContext(ctx).
+		Preload("Variants", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sku")
+		}).
+		Preload("Categories", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position, name")
+		})
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database"
+)
+
+// Отчеты строятся прямыми агрегирующими запросами: загружать заказы в память ради сумм слишком дорого.
+// Выручка считается по позициям заказов, поэтому совпадает с суммой Total подтвержденных и завершенных заказов.
+const (
+	salesByPeriodQuery = `
+SELECT date_trunc(?, o.created_at) AS period,
+       COUNT(DISTINCT o.id)        AS orders,
+       SUM(oi.quantity)            AS items_sold,
+       SUM(oi.total)               AS revenue
+FROM order_models o
+JOIN order_item_models oi ON oi.order_id = o.id AND oi.deleted_at IS NULL
+WHERE o.deleted_at IS NULL AND o.status IN ? AND o.created_at >= ? AND o.created_at < ?
+GROUP BY 1
+ORDER BY 1`
+
+	salesSummaryQuery = `
+SELECT COUNT(*)                                          AS total_orders,
+       COUNT(*) FILTER (WHERE status IN ?)               AS revenue_orders,
+       COUNT(*) FILTER (WHERE status = ?)                AS cancelled_orders,
+       COALESCE(SUM(total) FILTER (WHERE status IN ?), 0) AS revenue
+FROM order_models
+WHERE deleted_at IS NULL AND created_at >= ? AND created_at < ?`
+
+	topProductsQuery = `
+SELECT oi.product_id                               AS product_id,
+       MAX(oi.product_snapshot->>'description')    AS description,
+       SUM(oi.quantity)                            AS quantity,
+       SUM(oi.total)                               AS revenue
+FROM order_models o
+JOIN order_item_models oi ON oi.order_id = o.id AND oi.deleted_at IS NULL
+WHERE o.deleted_at IS NULL AND o.status IN ? AND o.created_at >= ? AND o.created_at < ?
+GROUP BY oi.product_id
+ORDER BY quantity DESC, revenue DESC
+LIMIT ?`
+
+	// У старых снимков теги могут отсутствовать, а json_array_elements_text падает на не-массивах
+	salesByTagQuery = `
+SELECT tag.value       AS tag,
+       SUM(oi.quantity) AS quantity,
+       SUM(oi.total)    AS revenue
+FROM order_models o
+JOIN order_item_models oi ON oi.order_id = o.id AND oi.deleted_at IS NULL
+CROSS JOIN LATERAL json_array_elements_text(
+    CASE WHEN json_typeof(oi.product_snapshot->'tags') = 'array'
+         THEN oi.product_snapshot->'tags'
+         ELSE '[]'::json END
+) AS tag(value)
+WHERE o.deleted_at IS NULL AND o.status IN ? AND o.created_at >= ? AND o.created_at < ?
+GROUP BY tag.value
+ORDER BY revenue DESC, tag.value`
+
+	// Категории берутся из снимка позиции, поэтому переименование категории не меняет прошлые продажи
+	salesByCategoryQuery = `
+SELECT category.value->>'slug'       AS slug,
+       MAX(category.value->>'name')  AS name,
+       SUM(oi.quantity)              AS quantity,
+       SUM(oi.total)                 AS revenue
+FROM order_models o
+JOIN order_item_models oi ON oi.order_id = o.id AND oi.deleted_at IS NULL
+CROSS JOIN LATERAL json_array_elements(
+    CASE WHEN json_typeof(oi.product_snapshot->'categories') = 'array'
+         THEN oi.product_snapshot->'categories'
+         ELSE '[]'::json END
+) AS category(value)
+WHERE o.deleted_at IS NULL AND o.status IN ? AND o.created_at >= ? AND o.created_at < ?
+GROUP BY category.value->>'slug'
+ORDER BY revenue DESC, slug`
+
+	// У товаров с вариантами остаток ведется по вариантам, поэтому они учитываются построчно
+	lowStockQuery = `
+SELECT p.id AS product_id, NULL::uuid AS variant_id, COALESCE(p.sku, '') AS sku, p.description, p.quantity
+FROM product_models p
+WHERE p.deleted_at IS NULL AND p.quantity <= ?
+  AND NOT EXISTS (
+    SELECT 1 FROM product_variant_models v WHERE v.product_id = p.id AND v.deleted_at IS NULL
+  )
+UNION ALL
+SELECT v.product_id, v.id, v.sku, p.description, v.quantity
+FROM product_variant_models v
+JOIN product_models p ON p.id = v.product_id AND p.deleted_at IS NULL
+WHERE v.deleted_at IS NULL AND v.quantity <= ?
+ORDER BY quantity, description
+LIMIT ?`
+)
+
+// reportRepository читает только с реплик: отчету допустимо отставание, а тяжелые агрегаты не нагружают основную БД
+type reportRepository struct {
+	reads *database.ReadRouter
+}
+
+func NewReportRepository(reads *database.ReadRouter) repositories.ReportRepository {
+	return &reportRepository{reads: reads}
+}
+
+func (r *reportRepository) SalesByPeriod(
+	ctx context.Context,
+	from, to time.Time,
+	period entities.ReportPeriod,
+) ([]entities.SalesPoint, error) {
+	points := make([]entities.SalesPoint, 0)
+	if err := r.reads.Reader(ctx).WithContext(ctx).
+		Raw(salesByPeriodQuery, string(period), revenueStatuses(), from, to).
+		Scan(&points).Error; err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+func (r *reportRepository) SalesSummary(ctx context.Context, from, to time.Time) (*entities.SalesSummary, error) {
+	var summary entities.SalesSummary
+	if err := r.reads.Reader(ctx).WithContext(ctx).
+		Raw(salesSummaryQuery, revenueStatuses(), string(entities.OrderStatusCancelled), revenueStatuses(), from, to).
+		Scan(&summary).Error; err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+func (r *reportRepository) TopProducts(ctx context.Context, from, to time.Time, limit int) ([]entities.ProductSales, error) {
+	products := make([]entities.ProductSales, 0)
+	if err := r.reads.Reader(ctx).WithContext(ctx).
+		Raw(topProductsQuery, revenueStatuses(), from, to, limit).
+		Scan(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (r *reportRepository) SalesByTag(ctx context.Context, from, to time.Time) ([]entities.TagSales, error) {
+	tags := make([]entities.TagSales, 0)
+	if err := r.reads.Reader(ctx).WithContext(ctx).
+		Raw(salesByTagQuery, revenueStatuses(), from, to).
+		Scan(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *reportRepository) SalesByCategory(ctx context.Context, from, to time.Time) ([]entities.CategorySales, error) {
+	categories := make([]entities.CategorySales, 0)
+	if err := r.reads.Reader(ctx).WithContext(ctx).
+		Raw(salesByCategoryQuery, revenueStatuses(), from, to).
+		Scan(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *reportRepository) LowStockProducts(ctx context.Context, threshold, limit int) ([]entities.LowStockProduct, error) {
+	products := make([]entities.LowStockProduct, 0)
+	if err := r.reads.Reader(ctx).WithContext(ctx).
+		Raw(lowStockQuery, threshold, threshold, limit).
+		Scan(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func revenueStatuses() []string {
+	statuses := make([]string, 0, len(entities.RevenueOrderStatuses))
+	for _, status := range entities.RevenueOrderStatuses {
+		statuses = append(statuses, string(status))
+	}
+	return statuses
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type returnRepository struct {
+	db *gorm.DB
+}
+
+func NewReturnRepository(db *gorm.DB) repositories.ReturnRepository {
+	return &returnRepository{db: db}
+}
+
+func (r *returnRepository) Create(ctx context.Context, request *entities.ReturnRequest) error {
+	model := &models.ReturnRequestModel{}
+	model.FromEntity(request)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	request.ID = model.ID
+	request.CreatedAt = model.CreatedAt
+	request.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+func (r *returnRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.ReturnRequest, error) {
+	var model models.ReturnRequestModel
+	if err := r.db.WithContext(ctx).Preload("Items").First(&model, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *returnRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.ReturnRequest, error) {
+	var model models.ReturnRequestModel
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Items").
+		First(&model, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *returnRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.ReturnRequest, error) {
+	var requestModels []models.ReturnRequestModel
+	if err := r.db.WithContext(ctx).
+		Preload("Items").
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&requestModels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.ReturnRequest, len(requestModels))
+	for i, model := range requestModels {
+		result[i] = model.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (r *returnRepository) Update(ctx context.Context, request *entities.ReturnRequest) error {
+	model := &models.ReturnRequestModel{}
+	model.FromEntity(request)
+
+	return r.db.WithContext(ctx).Save(model).Error
+}
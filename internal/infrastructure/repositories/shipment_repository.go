@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type shipmentRepository struct {
+	db *gorm.DB
+}
+
+func NewShipmentRepository(db *gorm.DB) repositories.ShipmentRepository {
+	return &shipmentRepository{db: db}
+}
+
+func (r *shipmentRepository) Create(ctx context.Context, shipment *entities.Shipment) error {
+	model := &models.ShipmentModel{}
+	model.FromEntity(shipment)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	shipment.ID = model.ID
+	shipment.CreatedAt = model.CreatedAt
+	shipment.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+func (r *shipmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Shipment, error) {
+	var model models.ShipmentModel
+	if err := r.preload(ctx).First(&model, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *shipmentRepository) GetByTrackingNumberForUpdate(
+	ctx context.Context,
+	carrier, trackingNumber string,
+) (*entities.Shipment, error) {
+	var model models.ShipmentModel
+	// блокируется только строка отправки, позиции и события подгружаются отдельными запросами
+	if err := r.preload(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&model, "carrier = ? AND tracking_number = ?", carrier, trackingNumber).Error; err != nil {
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *shipmentRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.Shipment, error) {
+	var shipmentModels []models.ShipmentModel
+	if err := r.preload(ctx).
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&shipmentModels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.Shipment, len(shipmentModels))
+	for i, model := range shipmentModels {
+		result[i] = model.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (r *shipmentRepository) Update(ctx context.Context, shipment *entities.Shipment) error {
+	model := &models.ShipmentModel{}
+	model.FromEntity(shipment)
+
+	// Save добавит новые события трекинга, существующие записи останутся без изменений
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+func (r *shipmentRepository) preload(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Preload("Items").
+		Preload("Events", func(db *gorm.DB) *gorm.DB {
+			return db.Order("occurred_at")
+		})
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type stockAlertRepository struct {
+	db *gorm.DB
+}
+
+func NewStockAlertRepository(db *gorm.DB) repositories.StockAlertRepository {
+	return &stockAlertRepository{db: db}
+}
+
+func (r *stockAlertRepository) CreateSubscription(ctx context.Context, subscription *entities.StockSubscription) error {
+	model := &models.StockSubscriptionModel{}
+	model.FromEntity(subscription)
+
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(model).Error; err != nil {
+		return err
+	}
+
+	subscription.ID = model.ID
+	subscription.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (r *stockAlertRepository) GetActiveSubscription(
+	ctx context.Context,
+	userID, productID uuid.UUID,
+	variantID *uuid.UUID,
+) (*entities.StockSubscription, error) {
+	query := r.db.WithContext(ctx).
+		Where("user_id = ? AND product_id = ? AND notified_at IS NULL", userID, productID)
+
+	if variantID != nil {
+		query = query.Where("variant_id = ?", *variantID)
+	} else {
+		query = query.Where("variant_id IS NULL")
+	}
+
+	var model models.StockSubscriptionModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrStockSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *stockAlertRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.StockSubscriptionModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrStockSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (r *stockAlertRepository) GetSubscribersForUpdate(
+	ctx context.Context,
+	productID uuid.UUID,
+	variantID *uuid.UUID,
+) ([]*entities.StockSubscription, error) {
+	query := r.db.WithContext(ctx).
+		Where("product_id = ? AND notified_at IS NULL", productID)
+
+	if variantID != nil {
+		query = query.Where("(variant_id IS NULL OR variant_id = ?)", *variantID)
+	} else {
+		query = query.Where("variant_id IS NULL")
+	}
+
+	var subscriptionModels []models.StockSubscriptionModel
+	if err := query.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Order("created_at").
+		Find(&subscriptionModels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.StockSubscription, len(subscriptionModels))
+	for i := range subscriptionModels {
+		result[i] = subscriptionModels[i].ToEntity()
+	}
+
+	return result, nil
+}
+
+func (r *stockAlertRepository) MarkSubscriptionsNotified(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).
+		Model(&models.StockSubscriptionModel{}).
+		Where("id IN ?", ids).
+		Update("notified_at", at).Error
+}
+
+func (r *stockAlertRepository) CreateNotifications(ctx context.Context, notifications []*entities.StockNotification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	notificationModels := make([]models.StockNotificationModel, len(notifications))
+	for i, notification := range notifications {
+		notificationModels[i].FromEntity(notification)
+	}
+
+	return r.db.WithContext(ctx).Create(&notificationModels).Error
+}
+
+func (r *stockAlertRepository) GetPendingNotificationsForUpdate(ctx context.Context, limit int) ([]*entities.StockNotification, error) {
+	var notificationModels []models.StockNotificationModel
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("sent_at IS NULL AND attempts < ?", entities.MaxStockNotificationAttempts).
+		Order("created_at").
+		Limit(limit).
+		Find(&notificationModels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.StockNotification, len(notificationModels))
+	for i := range notificationModels {
+		result[i] = notificationModels[i].ToEntity()
+	}
+
+	return result, nil
+}
+
+func (r *stockAlertRepository) UpdateNotification(ctx context.Context, notification *entities.StockNotification) error {
+	model := &models.StockNotificationModel{}
+	model.FromEntity(notification)
+
+	return r.db.WithContext(ctx).Save(model).Error
+}
@@ -4,26 +4,49 @@ import (
 	"context"
 
 	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database"
 
 	"gorm.io/gorm"
 )
 
 type transactionManager struct {
-	db *gorm.DB
+	db    *gorm.DB
+	reads *database.ReadRouter
 }
 
-func NewTransactionManager(db *gorm.DB) repositories.TransactionManager {
-	return &transactionManager{db: db}
+func NewTransactionManager(db *gorm.DB, reads *database.ReadRouter) repositories.TransactionManager {
+	return &transactionManager{db: db, reads: reads}
 }
 
 func (tm *transactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context, repos repositories.TransactionalRepositories) error) error {
-	return tm.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	var commit func()
+
+	err := tm.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// внутри транзакции и чтения идут через нее: реплика не видит еще не зафиксированных изменений
+		var reads *database.ReadRouter
+		reads, commit = tm.reads.Pin(tx)
+
 		repos := repositories.TransactionalRepositories{
-			OrderRepository:   NewOrderRepository(tx),
-			ProductRepository: NewProductRepository(tx),
-			UserRepository:    NewUserRepository(tx),
+			OrderRepository:         NewOrderRepository(tx, reads),
+			ProductRepository:       NewProductRepository(tx, reads),
+			UserRepository:          NewUserRepository(tx),
+			ShipmentRepository:      NewShipmentRepository(tx),
+			ReturnRepository:        NewReturnRepository(tx),
+			InvoiceRepository:       NewInvoiceRepository(tx),
+			PriceRepository:         NewPriceRepository(tx),
+			StockAlertRepository:    NewStockAlertRepository(tx),
+			EmailDeliveryRepository: NewEmailDeliveryRepository(tx),
+			WebhookRepository:       NewWebhookRepository(tx),
 		}
 
 		return fn(ctx, repos)
 	})
+	if err != nil {
+		return err
+	}
+
+	// окно read-your-writes для записанных заказов начинается с фиксации
+	commit()
+
+	return nil
 }
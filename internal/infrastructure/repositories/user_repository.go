@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userRepository struct {
@@ -42,3 +43,12 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.U
 
 	return model.ToEntity(), nil
 }
+
+func (r *userRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	var model models.UserModel
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&model, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
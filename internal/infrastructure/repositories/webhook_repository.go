@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) repositories.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	model := &models.WebhookSubscriptionModel{}
+	if err := model.FromEntity(subscription); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Create(model).Error
+}
+
+func (r *webhookRepository) UpdateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	model := &models.WebhookSubscriptionModel{}
+	if err := model.FromEntity(subscription); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.WebhookDeliveryModel{}, "subscription_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&models.WebhookSubscriptionModel{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domainErrors.ErrWebhookSubscriptionNotFound
+		}
+
+		return nil
+	})
+}
+
+func (r *webhookRepository) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	var model models.WebhookSubscriptionModel
+	if err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrWebhookSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *webhookRepository) GetSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	return r.findSubscriptions(r.db.WithContext(ctx))
+}
+
+func (r *webhookRepository) GetActiveSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	return r.findSubscriptions(r.db.WithContext(ctx).Where("active = ?", true))
+}
+
+func (r *webhookRepository) findSubscriptions(query *gorm.DB) ([]*entities.WebhookSubscription, error) {
+	var subscriptionModels []models.WebhookSubscriptionModel
+	if err := query.Order("created_at").Find(&subscriptionModels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.WebhookSubscription, len(subscriptionModels))
+	for i := range subscriptionModels {
+		result[i] = subscriptionModels[i].ToEntity()
+	}
+
+	return result, nil
+}
+
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	deliveryModels := make([]models.WebhookDeliveryModel, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryModels[i].FromEntity(delivery)
+	}
+
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(&deliveryModels).Error
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	model := &models.WebhookDeliveryModel{}
+	model.FromEntity(delivery)
+
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(model).Error
+}
+
+func (r *webhookRepository) GetDeliveryByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	var model models.WebhookDeliveryModel
+	if err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (r *webhookRepository) GetDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	status entities.WebhookDeliveryStatus,
+	limit, offset int,
+) ([]*entities.WebhookDelivery, error) {
+	query := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var deliveryModels []models.WebhookDeliveryModel
+	if err := query.
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&deliveryModels).Error; err != nil {
+		return nil, err
+	}
+
+	return toWebhookDeliveries(deliveryModels), nil
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]*entities.WebhookDelivery, error) {
+	var deliveryModels []models.WebhookDeliveryModel
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED: доставки, которые сейчас забирает другой экземпляр, пропускаем, а не ждем
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entities.WebhookDeliveryStatusPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveryModels).Error; err != nil {
+			return err
+		}
+		if len(deliveryModels) == 0 {
+			return nil
+		}
+
+		leasedUntil := now.Add(lease)
+		ids := make([]uuid.UUID, len(deliveryModels))
+		for i := range deliveryModels {
+			ids[i] = deliveryModels[i].ID
+			deliveryModels[i].NextAttemptAt = leasedUntil
+		}
+
+		return tx.Model(&models.WebhookDeliveryModel{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", leasedUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toWebhookDeliveries(deliveryModels), nil
+}
+
+func toWebhookDeliveries(deliveryModels []models.WebhookDeliveryModel) []*entities.WebhookDelivery {
+	result := make([]*entities.WebhookDelivery, len(deliveryModels))
+	for i := range deliveryModels {
+		result[i] = deliveryModels[i].ToEntity()
+	}
+
+	return result
+}
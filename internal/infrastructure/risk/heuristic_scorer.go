@@ -0,0 +1,59 @@
+package risk
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+)
+
+// Веса признаков риска; итоговая оценка ограничена единицей
+const (
+	newAccountWeight    = 0.4
+	highValueWeight     = 0.4
+	repeatedOrderWeight = 0.3
+)
+
+// HeuristicScorer - простая оценка риска по признакам, типичным для мошеннических заказов.
+// Реализация по умолчанию; внешний антифрод подключается через интерфейс services.RiskScorer.
+type HeuristicScorer struct {
+	// NewAccountAge - аккаунт младше этого возраста считается новым
+	NewAccountAge time.Duration
+	// HighValueTotal - сумма заказа в копейках, начиная с которой заказ считается крупным
+	HighValueTotal int64
+}
+
+func NewHeuristicScorer(newAccountAge time.Duration, highValueTotal int64) *HeuristicScorer {
+	return &HeuristicScorer{
+		NewAccountAge:  newAccountAge,
+		HighValueTotal: highValueTotal,
+	}
+}
+
+func (s *HeuristicScorer) Score(_ context.Context, input *services.RiskInput) (*entities.RiskAssessment, error) {
+	assessment := &entities.RiskAssessment{}
+
+	add := func(weight float64, reason string) {
+		assessment.Score = min(assessment.Score+weight, 1)
+		assessment.Reasons = append(assessment.Reasons, reason)
+	}
+
+	if s.NewAccountAge > 0 && input.Order.CreatedAt.Sub(input.User.CreatedAt) < s.NewAccountAge {
+		add(newAccountWeight, "new account")
+	}
+
+	if s.HighValueTotal > 0 && input.Order.Total >= s.HighValueTotal {
+		add(highValueWeight, "high order value")
+	}
+
+	// Повторная покупка тех же товаров за окно лимитов характерна для перепродажи
+	for productID := range input.Order.QuantitiesByProduct() {
+		if input.History.RecentQuantities[productID] > 0 {
+			add(repeatedOrderWeight, "repeated purchase of the same product")
+			break
+		}
+	}
+
+	return assessment, nil
+}
@@ -0,0 +1,152 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelCodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/AndrivA89/orders/internal/application/services"
+
+// Атрибуты спанов сервиса заказов
+const (
+	attrOrderID          = attribute.Key("order.id")
+	attrOrderStatus      = attribute.Key("order.status")
+	attrOrderTotal       = attribute.Key("order.total")
+	attrOrderItems       = attribute.Key("order.items")
+	attrOrderBackorder   = attribute.Key("order.backordered")
+	attrOrderProductIDs  = attribute.Key("order.product_ids")
+	attrUserID           = attribute.Key("user.id")
+	attrOrdersCount      = attribute.Key("orders.count")
+	attrExpireBefore     = attribute.Key("orders.reserved_before")
+	attrExpireHeldBefore = attribute.Key("orders.held_reserved_before")
+)
+
+// orderService открывает span на каждый вызов сервиса заказов. Запросы к БД внутри вызова
+// получают этот span родителем через ctx.
+type orderService struct {
+	next   services.OrderService
+	tracer trace.Tracer
+}
+
+func NewOrderService(next services.OrderService) services.OrderService {
+	return &orderService{next: next, tracer: otel.Tracer(tracerName)}
+}
+
+func (s *orderService) CreateOrder(ctx context.Context, request *services.OrderRequest) (*entities.Order, error) {
+	productIDs := make([]string, len(request.Items))
+	for i, item := range request.Items {
+		productIDs[i] = item.ProductID.String()
+	}
+
+	ctx, span := s.start(ctx, "CreateOrder",
+		attrUserID.String(request.UserID.String()),
+		attrOrderItems.Int(len(request.Items)),
+		attrOrderProductIDs.StringSlice(productIDs),
+	)
+	defer span.End()
+
+	order, err := s.next.CreateOrder(ctx, request)
+	if err != nil {
+		return nil, fail(span, err)
+	}
+
+	span.SetAttributes(orderAttributes(order)...)
+	return order, nil
+}
+
+func (s *orderService) GetOrderByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+	ctx, span := s.start(ctx, "GetOrderByID", attrOrderID.String(id.String()))
+	defer span.End()
+
+	order, err := s.next.GetOrderByID(ctx, id)
+	if err != nil {
+		return nil, fail(span, err)
+	}
+
+	span.SetAttributes(attrOrderStatus.String(string(order.Status)))
+	return order, nil
+}
+
+func (s *orderService) GetOrdersByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Order, error) {
+	ctx, span := s.start(ctx, "GetOrdersByUserID", attrUserID.String(userID.String()))
+	defer span.End()
+
+	orders, err := s.next.GetOrdersByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fail(span, err)
+	}
+
+	span.SetAttributes(attrOrdersCount.Int(len(orders)))
+	return orders, nil
+}
+
+func (s *orderService) ConfirmOrder(ctx context.Context, orderID uuid.UUID) error {
+	ctx, span := s.start(ctx, "ConfirmOrder", attrOrderID.String(orderID.String()))
+	defer span.End()
+
+	return fail(span, s.next.ConfirmOrder(ctx, orderID))
+}
+
+func (s *orderService) CancelOrder(ctx context.Context, orderID uuid.UUID) error {
+	ctx, span := s.start(ctx, "CancelOrder", attrOrderID.String(orderID.String()))
+	defer span.End()
+
+	return fail(span, s.next.CancelOrder(ctx, orderID))
+}
+
+func (s *orderService) ReleaseOrder(ctx context.Context, orderID uuid.UUID) error {
+	ctx, span := s.start(ctx, "ReleaseOrder", attrOrderID.String(orderID.String()))
+	defer span.End()
+
+	return fail(span, s.next.ReleaseOrder(ctx, orderID))
+}
+
+func (s *orderService) ExpireReservations(ctx context.Context, before, heldBefore time.Time) (int, error) {
+	attrs := []attribute.KeyValue{attrExpireBefore.String(before.Format(time.RFC3339))}
+	if !heldBefore.IsZero() {
+		attrs = append(attrs, attrExpireHeldBefore.String(heldBefore.Format(time.RFC3339)))
+	}
+	ctx, span := s.start(ctx, "ExpireReservations", attrs...)
+	defer span.End()
+
+	expired, err := s.next.ExpireReservations(ctx, before, heldBefore)
+	span.SetAttributes(attrOrdersCount.Int(expired))
+
+	return expired, fail(span, err)
+}
+
+func (s *orderService) start(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "OrderService."+method, trace.WithAttributes(attrs...))
+}
+
+func orderAttributes(order *entities.Order) []attribute.KeyValue {
+	backordered := 0
+	for _, item := range order.Items {
+		backordered += item.Backordered
+	}
+
+	return []attribute.KeyValue{
+		attrOrderID.String(order.ID.String()),
+		attrOrderStatus.String(string(order.Status)),
+		attrOrderTotal.Int64(order.Total),
+		attrOrderBackorder.Int(backordered),
+	}
+}
+
+// fail отмечает span ошибкой и возвращает ее же; nil пропускается
+func fail(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+	}
+	return err
+}
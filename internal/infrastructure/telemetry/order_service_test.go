@@ -0,0 +1,62 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	otelCodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type fakeOrderService struct {
+	services.OrderService
+
+	order *entities.Order
+	err   error
+}
+
+func (f *fakeOrderService) CreateOrder(context.Context, *services.OrderRequest) (*entities.Order, error) {
+	return f.order, f.err
+}
+
+func TestOrderService_CreateOrder_RecordsSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	productID := uuid.New()
+	order := &entities.Order{ID: uuid.New(), Status: entities.OrderStatusPending, Total: 1500}
+	next := &fakeOrderService{order: order}
+	service := NewOrderService(next)
+	request := &services.OrderRequest{UserID: uuid.New(), Items: []services.OrderItemRequest{{ProductID: productID, Quantity: 1}}}
+
+	_, err := service.CreateOrder(context.Background(), request)
+	assert.NoError(t, err)
+
+	next.err = domainErrors.ErrInsufficientStock
+	_, err = service.CreateOrder(context.Background(), request)
+	assert.ErrorIs(t, err, domainErrors.ErrInsufficientStock)
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+
+	assert.Equal(t, "OrderService.CreateOrder", spans[0].Name())
+	attrs := make(map[string]any)
+	for _, attr := range spans[0].Attributes() {
+		attrs[string(attr.Key)] = attr.Value.AsInterface()
+	}
+	assert.Equal(t, order.ID.String(), attrs["order.id"])
+	assert.Equal(t, int64(1500), attrs["order.total"])
+	assert.Equal(t, []string{productID.String()}, attrs["order.product_ids"])
+
+	assert.Equal(t, otelCodes.Error, spans[1].Status().Code)
+}
@@ -2,26 +2,50 @@ package telemetry
 
 import (
 	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/config"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
-func InitTracing(serviceName string) (func(), error) {
-	exporter, err := stdouttrace.New(
-		stdouttrace.WithPrettyPrint(),
-	)
+const (
+	ExporterStdout   = "stdout"
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+	ExporterNone     = "none"
+)
+
+// InitTracing настраивает провайдер трассировки и возвращает функцию остановки: она отправляет
+// накопленные в батчере спаны и должна вызываться при завершении сервиса.
+// Контекст трассы передается в заголовках W3C (traceparent, tracestate) при любом экспортере,
+// в том числе none: так сквозной trace id сохраняется, даже если этот сервис спаны не пишет.
+func InitTracing(ctx context.Context, cfg *config.TracingConfig) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, err := newExporter(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
 
-	res, err := resource.New(context.Background(),
+	res, err := resource.New(ctx,
 		resource.WithAttributes(
-			semconv.ServiceName(serviceName),
-			semconv.ServiceVersion("1.0.0"),
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.ServiceVersion(serviceVersion(cfg.ServiceVersion)),
 		),
 	)
 	if err != nil {
@@ -31,11 +55,57 @@ func InitTracing(serviceName string) (func(), error) {
 	tp := trace.NewTracerProvider(
 		trace.WithBatcher(exporter),
 		trace.WithResource(res),
+		trace.WithSampler(trace.ParentBased(trace.TraceIDRatioBased(cfg.SampleRatio))),
 	)
 
 	otel.SetTracerProvider(tp)
 
-	return func() {
-		_ = tp.Shutdown(context.Background())
-	}, nil
+	return tp.Shutdown, nil
+}
+
+// newExporter возвращает nil для none: провайдер не создается и спаны ничего не стоят
+func newExporter(ctx context.Context, cfg *config.TracingConfig) (trace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterOTLPGRPC:
+		var opts []otlptracegrpc.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(cfg.Endpoint))
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case ExporterOTLPHTTP:
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q: expected %s, %s, %s or %s",
+			cfg.Exporter, ExporterStdout, ExporterOTLPGRPC, ExporterOTLPHTTP, ExporterNone)
+	}
+}
+
+// serviceVersion берет версию из конфигурации, иначе из сборки: версию модуля или ревизию VCS
+func serviceVersion(configured string) string {
+	if configured != "" {
+		return configured
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" && len(setting.Value) >= 12 {
+			return setting.Value[:12]
+		}
+	}
+
+	return "unknown"
 }
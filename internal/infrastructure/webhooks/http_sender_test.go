@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDelivery(t *testing.T, subscription *entities.WebhookSubscription) *entities.WebhookDelivery {
+	order := entities.NewOrder(uuid.New())
+	payload, err := entities.NewOrderEventPayload(entities.OrderEventConfirmed, order, time.Now())
+	assert.NoError(t, err)
+
+	return entities.NewWebhookDelivery(subscription, entities.OrderEventConfirmed, order.ID, payload)
+}
+
+func TestHTTPSender_Send_SignsPayload(t *testing.T) {
+	const secret = "partner-secret-0123456789"
+
+	var received *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	subscription, err := entities.NewWebhookSubscription(receiver.URL, []entities.OrderEvent{entities.OrderEventConfirmed}, secret)
+	assert.NoError(t, err)
+	delivery := newTestDelivery(t, subscription)
+
+	status, err := NewHTTPSender(time.Second).Send(context.Background(), subscription, delivery)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "order_confirmed", received.Header.Get(HeaderEvent))
+	assert.Equal(t, delivery.ID.String(), received.Header.Get(HeaderDelivery))
+	assert.JSONEq(t, string(delivery.Payload), string(body))
+
+	// Подписчик проверяет подпись тем же секретом по полученным заголовкам и телу
+	expected := Sign(secret, received.Header.Get(HeaderTimestamp), body)
+	assert.True(t, hmac.Equal([]byte(expected), []byte(received.Header.Get(HeaderSignature))))
+	assert.NotEqual(t, expected, Sign("another-secret-0123456789", received.Header.Get(HeaderTimestamp), body))
+}
+
+func TestHTTPSender_Send_NonSuccessStatus(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	subscription, err := entities.NewWebhookSubscription(receiver.URL, []entities.OrderEvent{entities.OrderEventConfirmed}, "partner-secret-0123456789")
+	assert.NoError(t, err)
+
+	status, err := NewHTTPSender(time.Second).Send(context.Background(), subscription, newTestDelivery(t, subscription))
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
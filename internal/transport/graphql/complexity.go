@@ -0,0 +1,130 @@
+package graphql
+
+import (
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+const (
+	// defaultListLimit совпадает со значением limit по умолчанию в схеме
+	defaultListLimit = 10
+	// maxListLimit - наибольший размер страницы списка; больший limit урезается
+	maxListLimit = 100
+)
+
+// listFields - поля-списки с аргументом limit: стоимость их выборки умножается на размер страницы
+var listFields = map[string]bool{
+	"products": true,
+	"orders":   true,
+}
+
+// queryComplexity оценивает стоимость операции до выполнения: каждое поле стоит 1, а вложенная
+// выборка списка умножается на его limit. Так запрос пользователей с сотней заказов по сотне позиций
+// отсекается сразу, хотя его глубина укладывается в лимит. Ошибка разбора возвращается как ok=false:
+// ее текст клиенту вернет сама библиотека GraphQL.
+func queryComplexity(query, operationName string, variables map[string]any) (int, bool) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return 0, false
+	}
+
+	operation := doc.Operations.ForName(operationName)
+	if operation == nil {
+		return 0, false
+	}
+
+	estimator := &complexityEstimator{
+		fragments:   doc.Fragments,
+		definitions: operation.VariableDefinitions,
+		variables:   variables,
+		visiting:    make(map[string]bool),
+	}
+	return estimator.selectionSet(operation.SelectionSet), true
+}
+
+type complexityEstimator struct {
+	fragments ast.FragmentDefinitionList
+	// definitions нужны для значений переменных по умолчанию ($n: Int = 50), не переданных в variables
+	definitions ast.VariableDefinitionList
+	variables   map[string]any
+	// visiting защищает от циклов фрагментов: валидация схемы их отклонит, но оценка идет раньше
+	visiting map[string]bool
+}
+
+func (e *complexityEstimator) selectionSet(selections ast.SelectionSet) int {
+	total := 0
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			total += e.field(selection)
+		case *ast.InlineFragment:
+			total += e.selectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			fragment := e.fragments.ForName(selection.Name)
+			if fragment == nil || e.visiting[selection.Name] {
+				continue
+			}
+			e.visiting[selection.Name] = true
+			total += e.selectionSet(fragment.SelectionSet)
+			delete(e.visiting, selection.Name)
+		}
+	}
+
+	return total
+}
+
+func (e *complexityEstimator) field(field *ast.Field) int {
+	children := e.selectionSet(field.SelectionSet)
+	if listFields[field.Name] {
+		children *= e.limit(field)
+	}
+
+	return 1 + children
+}
+
+// limit возвращает размер страницы, с которым резолвер выполнит выборку (см. listLimit)
+func (e *complexityEstimator) limit(field *ast.Field) int {
+	argument := field.Arguments.ForName("limit")
+	if argument == nil {
+		return defaultListLimit
+	}
+
+	node := argument.Value
+	if node.Kind == ast.Variable {
+		if value, ok := e.variables[node.Raw]; ok {
+			return listLimit(intValue(value))
+		}
+
+		definition := e.definitions.ForName(node.Raw)
+		if definition == nil || definition.DefaultValue == nil {
+			return defaultListLimit
+		}
+		node = definition.DefaultValue
+	}
+
+	value, _ := node.Value(e.variables)
+	return listLimit(intValue(value))
+}
+
+// intValue приводит значение аргумента к int; нечисловое значение дает 0 (limit по умолчанию)
+func intValue(value any) int {
+	switch value := value.(type) {
+	case int64:
+		return int(value)
+	case float64:
+		// числа из JSON с переменными приходят как float64
+		return int(value)
+	}
+
+	return 0
+}
+
+// listLimit приводит limit списка к 1..maxListLimit: непереданный или неположительный limit
+// заменяется значением по умолчанию, как в gRPC API
+func listLimit(limit int) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+
+	return min(limit, maxListLimit)
+}
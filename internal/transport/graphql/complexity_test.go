@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryComplexity(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		want      int
+	}{
+		{
+			name:  "fields cost one each",
+			query: `{ product(id: "1") { id description } }`,
+			want:  3,
+		},
+		{
+			name:  "list multiplies its selection by the default limit",
+			query: `{ products { id } }`,
+			want:  1 + defaultListLimit,
+		},
+		{
+			name:  "nested lists multiply",
+			query: `{ user(id: "1") { orders(limit: 5) { items { id } } } }`,
+			want:  1 + 1 + 5*2,
+		},
+		{
+			name:  "negative limit counts as the default, as the resolver runs it",
+			query: `{ products(limit: -1) { id } }`,
+			want:  1 + defaultListLimit,
+		},
+		{
+			name:  "limit above the maximum is clamped",
+			query: `{ products(limit: 100000) { id } }`,
+			want:  1 + maxListLimit,
+		},
+		{
+			name:      "variable from the request",
+			query:     `query($n: Int) { products(limit: $n) { id } }`,
+			variables: map[string]any{"n": float64(20)},
+			want:      1 + 20,
+		},
+		{
+			name:  "variable default from the operation",
+			query: `query($n: Int = 50) { products(limit: $n) { id } }`,
+			want:  1 + 50,
+		},
+		{
+			name:  "fragments are expanded",
+			query: `{ products(limit: 2) { ...fields } } fragment fields on Product { id description }`,
+			want:  1 + 2*2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := queryComplexity(tt.query, "", tt.variables)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQueryComplexity_InvalidQuery(t *testing.T) {
+	_, ok := queryComplexity(`{ products {`, "", nil)
+	assert.False(t, ok)
+
+	_, ok = queryComplexity(`query A { products { id } }`, "B", nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	gqlErrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+// Коды ошибок передаются в extensions.code, по ним клиент отличает типы ошибок без разбора текста
+const (
+	codeNotFound           = "NOT_FOUND"
+	codeBadUserInput       = "BAD_USER_INPUT"
+	codeFailedPrecondition = "FAILED_PRECONDITION"
+	codeTooManyRequests    = "TOO_MANY_REQUESTS"
+	codeQueryTooComplex    = "QUERY_TOO_COMPLEX"
+	codeInternal           = "INTERNAL"
+)
+
+// errorCodes сопоставляет доменные ошибки кодам GraphQL, как errorCodes в gRPC API
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{domainErrors.ErrUserNotFound, codeNotFound},
+	{domainErrors.ErrProductNotFound, codeNotFound},
+	{domainErrors.ErrVariantNotFound, codeNotFound},
+	{domainErrors.ErrOrderNotFound, codeNotFound},
+	{domainErrors.ErrCategoryNotFound, codeNotFound},
+
+	{domainErrors.ErrOrderVelocityLimit, codeTooManyRequests},
+
+	{domainErrors.ErrInsufficientStock, codeFailedPrecondition},
+	{domainErrors.ErrInsufficientQuantity, codeFailedPrecondition},
+	{domainErrors.ErrOrderProductQuantityLimit, codeFailedPrecondition},
+	{domainErrors.ErrUserProductQuantityLimit, codeFailedPrecondition},
+	{domainErrors.ErrOrderTotalLimit, codeFailedPrecondition},
+	{domainErrors.ErrOnlyPendingCanConfirm, codeFailedPrecondition},
+	{domainErrors.ErrCannotConfirmEmptyOrder, codeFailedPrecondition},
+	{domainErrors.ErrCompletedOrdersReadonly, codeFailedPrecondition},
+	{domainErrors.ErrOrderAlreadyCancelled, codeFailedPrecondition},
+	{domainErrors.ErrProductPricedByVariants, codeFailedPrecondition},
+
+	{domainErrors.ErrVariantRequired, codeBadUserInput},
+	{domainErrors.ErrQuantityInvalid, codeBadUserInput},
+	{domainErrors.ErrOrderMustHaveItems, codeBadUserInput},
+	{domainErrors.ErrInvalidOrderID, codeBadUserInput},
+	{domainErrors.ErrInvalidUserID, codeBadUserInput},
+	{domainErrors.ErrInvalidProductID, codeBadUserInput},
+	{domainErrors.ErrInvalidVariantID, codeBadUserInput},
+}
+
+// errorCode возвращает код доменной ошибки; false означает внутреннюю ошибку, текст которой клиенту не отдается
+func errorCode(err error) (string, bool) {
+	for _, mapping := range errorCodes {
+		if errors.Is(err, mapping.err) {
+			return mapping.code, true
+		}
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return codeInternal, true
+	}
+
+	return codeInternal, false
+}
+
+func complexityError(complexity, maxComplexity int) *gqlErrors.QueryError {
+	err := gqlErrors.Errorf("query complexity %d exceeds the maximum allowed complexity of %d", complexity, maxComplexity)
+	err.Extensions = map[string]any{"code": codeQueryTooComplex}
+	return err
+}
@@ -0,0 +1,134 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	gqlErrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/sirupsen/logrus"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+type request struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Handler обслуживает POST /graphql поверх тех же доменных сервисов, что и REST API.
+// Глубина запроса ограничивается библиотекой, сложность - оценкой до выполнения.
+type Handler struct {
+	schema         *graphql.Schema
+	productService services.ProductService
+	maxComplexity  int
+	logger         *logrus.Logger
+}
+
+// NewHandler собирает схему; нулевые maxDepth и maxComplexity отключают соответствующее ограничение
+func NewHandler(
+	orderService services.OrderService,
+	productService services.ProductService,
+	userService services.UserService,
+	maxDepth int,
+	maxComplexity int,
+	logger *logrus.Logger,
+) *Handler {
+	resolver := &rootResolver{
+		orderService:   orderService,
+		productService: productService,
+		userService:    userService,
+	}
+
+	panics := &panicHandler{logger: logger}
+	schema := graphql.MustParseSchema(schemaSDL, resolver,
+		graphql.MaxDepth(maxDepth),
+		graphql.Logger(panics),
+		graphql.PanicHandler(panics),
+	)
+
+	return &Handler{
+		schema:         schema,
+		productService: productService,
+		maxComplexity:  maxComplexity,
+		logger:         logger,
+	}
+}
+
+func (h *Handler) Handle(c *gin.Context) {
+	var req request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, &graphql.Response{
+			Errors: []*gqlErrors.QueryError{{Message: "request body must be JSON with a query field"}},
+		})
+		return
+	}
+
+	if h.maxComplexity > 0 {
+		complexity, ok := queryComplexity(req.Query, req.OperationName, req.Variables)
+		if ok && complexity > h.maxComplexity {
+			c.JSON(http.StatusOK, &graphql.Response{
+				Errors: []*gqlErrors.QueryError{complexityError(complexity, h.maxComplexity)},
+			})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	// загрузчик живет ровно один запрос, чтобы кеш товаров не отдавал устаревшие данные
+	ctx = withProductLoader(ctx, newProductLoader(ctx, h.productService))
+
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	for _, queryError := range response.Errors {
+		h.annotateError(c, queryError)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// annotateError проставляет код доменной ошибки в extensions; текст внутренних ошибок остается только в логе
+func (h *Handler) annotateError(c *gin.Context, queryError *gqlErrors.QueryError) {
+	if queryError.ResolverError == nil {
+		return
+	}
+
+	code, known := errorCode(queryError.ResolverError)
+	if !known {
+		h.logger.WithFields(logrus.Fields{
+			"path":       queryError.Path,
+			"error":      queryError.ResolverError.Error(),
+			"request_id": c.Request.Header.Get("X-Request-ID"),
+		}).Error("GraphQL resolver failed")
+		queryError.Message = "internal error"
+	}
+
+	if queryError.Extensions == nil {
+		queryError.Extensions = make(map[string]any)
+	}
+	queryError.Extensions["code"] = code
+}
+
+// panicHandler пишет панику резолвера в лог приложения, а клиенту отдает внутреннюю ошибку без подробностей
+type panicHandler struct {
+	logger *logrus.Logger
+}
+
+func (h *panicHandler) LogPanic(_ context.Context, value any) {
+	h.logger.WithFields(logrus.Fields{
+		"panic": value,
+		"stack": string(debug.Stack()),
+	}).Error("Panic recovered")
+}
+
+func (h *panicHandler) MakePanicError(_ context.Context, _ any) *gqlErrors.QueryError {
+	err := gqlErrors.Errorf("internal error")
+	err.Extensions = map[string]any{"code": codeInternal}
+	return err
+}
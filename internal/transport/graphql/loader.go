@@ -0,0 +1,138 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// loaderWait - сколько загрузчик ждет остальные запросы той же пачки
+	loaderWait = 2 * time.Millisecond
+	// loaderMaxBatch ограничивает размер IN (...) в одном запросе к базе
+	loaderMaxBatch = 100
+)
+
+type loadersKey struct{}
+
+// productLoader собирает обращения к товарам за время одного запроса GraphQL в пачки и
+// загружает их одним запросом к базе вместо запроса на каждую позицию заказа. Результаты
+// кешируются до конца запроса, поэтому один и тот же товар загружается один раз.
+type productLoader struct {
+	ctx            context.Context
+	productService services.ProductService
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]*productResult
+	batch []uuid.UUID
+}
+
+type productResult struct {
+	product *entities.Product
+	err     error
+	done    chan struct{}
+}
+
+func newProductLoader(ctx context.Context, productService services.ProductService) *productLoader {
+	return &productLoader{
+		ctx:            ctx,
+		productService: productService,
+		cache:          make(map[uuid.UUID]*productResult),
+	}
+}
+
+func withProductLoader(ctx context.Context, loader *productLoader) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loader)
+}
+
+func productLoaderFrom(ctx context.Context) *productLoader {
+	loader, _ := ctx.Value(loadersKey{}).(*productLoader)
+	return loader
+}
+
+// Prime ставит товары в очередь загрузки, не дожидаясь результата. Резолвер списка позиций
+// вызывает его заранее, чтобы все товары списка попали в одну пачку.
+func (l *productLoader) Prime(ids ...uuid.UUID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, id := range ids {
+		l.enqueue(id)
+	}
+}
+
+func (l *productLoader) Load(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
+	l.mu.Lock()
+	result := l.enqueue(id)
+	l.mu.Unlock()
+
+	select {
+	case <-result.done:
+		return result.product, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// enqueue возвращает закешированный результат или добавляет товар в текущую пачку; вызывается под l.mu
+func (l *productLoader) enqueue(id uuid.UUID) *productResult {
+	if result, ok := l.cache[id]; ok {
+		return result
+	}
+
+	result := &productResult{done: make(chan struct{})}
+	l.cache[id] = result
+
+	if len(l.batch) == 0 {
+		time.AfterFunc(loaderWait, l.flush)
+	}
+	l.batch = append(l.batch, id)
+
+	if len(l.batch) >= loaderMaxBatch {
+		go l.dispatch(l.batch)
+		l.batch = nil
+	}
+
+	return result
+}
+
+func (l *productLoader) flush() {
+	l.mu.Lock()
+	batch := l.batch
+	l.batch = nil
+	l.mu.Unlock()
+
+	if len(batch) > 0 {
+		l.dispatch(batch)
+	}
+}
+
+func (l *productLoader) dispatch(ids []uuid.UUID) {
+	products, err := l.productService.GetProductsByIDs(l.ctx, ids)
+
+	l.mu.Lock()
+	results := make([]*productResult, len(ids))
+	for i, id := range ids {
+		results[i] = l.cache[id]
+	}
+	l.mu.Unlock()
+
+	for i, id := range ids {
+		result := results[i]
+		switch {
+		case err != nil:
+			result.err = err
+		case products[id] == nil:
+			result.err = domainErrors.ErrProductNotFound
+		default:
+			result.product = products[id]
+		}
+		close(result.done)
+	}
+}
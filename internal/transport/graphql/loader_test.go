@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchProductService отдает товары из products и запоминает каждую пачку запроса
+type batchProductService struct {
+	services.ProductService
+
+	mu       sync.Mutex
+	products map[uuid.UUID]*entities.Product
+	batches  [][]uuid.UUID
+	err      error
+}
+
+func (s *batchProductService) GetProductsByIDs(_ context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches = append(s.batches, ids)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	result := make(map[uuid.UUID]*entities.Product, len(ids))
+	for _, id := range ids {
+		if product, ok := s.products[id]; ok {
+			result[id] = product
+		}
+	}
+	return result, nil
+}
+
+func TestProductLoader_BatchesConcurrentLoads(t *testing.T) {
+	products := make(map[uuid.UUID]*entities.Product)
+	ids := make([]uuid.UUID, 20)
+	for i := range ids {
+		ids[i] = uuid.New()
+		products[ids[i]] = &entities.Product{ID: ids[i]}
+	}
+	service := &batchProductService{products: products}
+	loader := newProductLoader(context.Background(), service)
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	loaded := make([]*entities.Product, len(ids)*2)
+	errs := make([]error, len(ids)*2)
+	// каждый товар запрашивается дважды: повторное обращение берется из кеша запроса
+	for i := range loaded {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			loaded[i], errs[i] = loader.Load(context.Background(), ids[i%len(ids)])
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	for i := range loaded {
+		require.NoError(t, errs[i])
+		assert.Equal(t, ids[i%len(ids)], loaded[i].ID)
+	}
+	require.Len(t, service.batches, 1)
+	assert.ElementsMatch(t, ids, service.batches[0])
+}
+
+func TestProductLoader_MissingProduct(t *testing.T) {
+	service := &batchProductService{}
+	loader := newProductLoader(context.Background(), service)
+
+	product, err := loader.Load(context.Background(), uuid.New())
+
+	assert.Nil(t, product)
+	assert.ErrorIs(t, err, domainErrors.ErrProductNotFound)
+}
+
+func TestProductLoader_BatchErrorReachesEveryLoad(t *testing.T) {
+	service := &batchProductService{err: errors.New("connection reset")}
+	loader := newProductLoader(context.Background(), service)
+
+	first, second := uuid.New(), uuid.New()
+	loader.Prime(first, second)
+
+	_, err := loader.Load(context.Background(), first)
+	assert.EqualError(t, err, "connection reset")
+	_, err = loader.Load(context.Background(), second)
+	assert.EqualError(t, err, "connection reset")
+	assert.Len(t, service.batches, 1)
+}
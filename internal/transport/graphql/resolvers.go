@@ -0,0 +1,410 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Money - сумма в копейках. Встроенный Int в GraphQL 32-битный, поэтому суммы передаются отдельным скаляром.
+type Money int64
+
+func (Money) ImplementsGraphQLType(name string) bool {
+	return name == "Money"
+}
+
+func (m *Money) UnmarshalGraphQL(input any) error {
+	switch input := input.(type) {
+	case int32:
+		*m = Money(input)
+	case int64:
+		*m = Money(input)
+	case float64:
+		*m = Money(input)
+	default:
+		return errors.New("money must be an integer amount in kopecks")
+	}
+	return nil
+}
+
+type rootResolver struct {
+	orderService   services.OrderService
+	productService services.ProductService
+	userService    services.UserService
+}
+
+type listArgs struct {
+	Limit  int32
+	Offset int32
+}
+
+// listOffset отбрасывает отрицательный offset: он дошел бы до SQL как есть
+func listOffset(offset int32) int {
+	return max(int(offset), 0)
+}
+
+func parseID(id graphql.ID, invalid error) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, invalid
+	}
+	return parsed, nil
+}
+
+func optionalID(id *uuid.UUID) *graphql.ID {
+	if id == nil {
+		return nil
+	}
+
+	value := graphql.ID(id.String())
+	return &value
+}
+
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+func toAttributes(attributes map[string]string) []*attributeResolver {
+	result := make([]*attributeResolver, 0, len(attributes))
+	for name, value := range attributes {
+		result = append(result, &attributeResolver{name: name, value: value})
+	}
+	// порядок ключей map случаен, а ответ должен быть стабильным
+	sort.Slice(result, func(i, j int) bool { return result[i].name < result[j].name })
+
+	return result
+}
+
+func (r *rootResolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	userID, err := parseID(args.ID, domainErrors.ErrInvalidUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	return &userResolver{root: r, user: user}, nil
+}
+
+func (r *rootResolver) Product(ctx context.Context, args struct{ ID graphql.ID }) (*productResolver, error) {
+	productID, err := parseID(args.ID, domainErrors.ErrInvalidProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := r.productService.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, domainErrors.ErrProductNotFound
+	}
+
+	return &productResolver{product: product}, nil
+}
+
+func (r *rootResolver) Products(ctx context.Context, args struct {
+	Category *string
+	Limit    int32
+	Offset   int32
+}) ([]*productResolver, error) {
+	var (
+		products []*entities.Product
+		err      error
+	)
+	if args.Category != nil && *args.Category != "" {
+		products, err = r.productService.GetProductsByCategory(ctx, *args.Category, listLimit(int(args.Limit)), listOffset(args.Offset))
+	} else {
+		products, err = r.productService.GetProducts(ctx, listLimit(int(args.Limit)), listOffset(args.Offset))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*productResolver, len(products))
+	for i, product := range products {
+		result[i] = &productResolver{product: product}
+	}
+
+	return result, nil
+}
+
+func (r *rootResolver) Order(ctx context.Context, args struct{ ID graphql.ID }) (*orderResolver, error) {
+	orderID, err := parseID(args.ID, domainErrors.ErrInvalidOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := r.orderService.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+
+	return r.newOrderResolver(ctx, order), nil
+}
+
+type createOrderInput struct {
+	UserID graphql.ID
+	Items  []struct {
+		ProductID graphql.ID
+		VariantID *graphql.ID
+		Quantity  int32
+	}
+}
+
+func (r *rootResolver) CreateOrder(ctx context.Context, args struct{ Input createOrderInput }) (*orderResolver, error) {
+	userID, err := parseID(args.Input.UserID, domainErrors.ErrInvalidUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]services.OrderItemRequest, len(args.Input.Items))
+	for i, item := range args.Input.Items {
+		productID, err := parseID(item.ProductID, domainErrors.ErrInvalidProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		var variantID *uuid.UUID
+		if item.VariantID != nil {
+			id, err := parseID(*item.VariantID, domainErrors.ErrInvalidVariantID)
+			if err != nil {
+				return nil, err
+			}
+			variantID = &id
+		}
+
+		items[i] = services.OrderItemRequest{
+			ProductID: productID,
+			VariantID: variantID,
+			Quantity:  int(item.Quantity),
+		}
+	}
+
+	order, err := r.orderService.CreateOrder(ctx, &services.OrderRequest{UserID: userID, Items: items})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newOrderResolver(ctx, order), nil
+}
+
+func (r *rootResolver) ConfirmOrder(ctx context.Context, args struct{ ID graphql.ID }) (*orderResolver, error) {
+	return r.changeOrder(ctx, args.ID, r.orderService.ConfirmOrder)
+}
+
+func (r *rootResolver) CancelOrder(ctx context.Context, args struct{ ID graphql.ID }) (*orderResolver, error) {
+	return r.changeOrder(ctx, args.ID, r.orderService.CancelOrder)
+}
+
+// changeOrder выполняет переход статуса и возвращает заказ в новом состоянии, как REST и gRPC API
+func (r *rootResolver) changeOrder(
+	ctx context.Context,
+	id graphql.ID,
+	change func(context.Context, uuid.UUID) error,
+) (*orderResolver, error) {
+	orderID, err := parseID(id, domainErrors.ErrInvalidOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := change(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	order, err := r.orderService.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newOrderResolver(ctx, order), nil
+}
+
+// newOrderResolver сразу ставит товары всех позиций в очередь загрузчика, чтобы они пришли одной пачкой
+func (r *rootResolver) newOrderResolver(ctx context.Context, order *entities.Order) *orderResolver {
+	if loader := productLoaderFrom(ctx); loader != nil {
+		ids := make([]uuid.UUID, len(order.Items))
+		for i, item := range order.Items {
+			ids[i] = item.ProductID
+		}
+		loader.Prime(ids...)
+	}
+
+	return &orderResolver{root: r, order: order}
+}
+
+type userResolver struct {
+	root *rootResolver
+	user *entities.User
+}
+
+func (r *userResolver) ID() graphql.ID          { return graphql.ID(r.user.ID.String()) }
+func (r *userResolver) FirstName() string       { return r.user.FirstName }
+func (r *userResolver) LastName() string        { return r.user.LastName }
+func (r *userResolver) Email() string           { return r.user.Email }
+func (r *userResolver) Language() string        { return r.user.Language }
+func (r *userResolver) Age() int32              { return int32(r.user.Age) }
+func (r *userResolver) IsMarried() bool         { return r.user.IsMarried }
+func (r *userResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.user.CreatedAt} }
+func (r *userResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.user.UpdatedAt} }
+
+func (r *userResolver) Orders(ctx context.Context, args listArgs) ([]*orderResolver, error) {
+	orders, err := r.root.orderService.GetOrdersByUserID(ctx, r.user.ID, listLimit(int(args.Limit)), listOffset(args.Offset))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*orderResolver, len(orders))
+	for i, order := range orders {
+		result[i] = r.root.newOrderResolver(ctx, order)
+	}
+
+	return result, nil
+}
+
+type attributeResolver struct {
+	name  string
+	value string
+}
+
+func (r *attributeResolver) Name() string  { return r.name }
+func (r *attributeResolver) Value() string { return r.value }
+
+type categoryRefResolver struct {
+	category entities.CategoryRef
+}
+
+func (r *categoryRefResolver) ID() graphql.ID { return graphql.ID(r.category.ID.String()) }
+func (r *categoryRefResolver) Slug() string   { return r.category.Slug }
+func (r *categoryRefResolver) Name() string   { return r.category.Name }
+
+type productResolver struct {
+	product *entities.Product
+}
+
+func (r *productResolver) ID() graphql.ID      { return graphql.ID(r.product.ID.String()) }
+func (r *productResolver) SKU() string         { return r.product.SKU }
+func (r *productResolver) Name() string        { return r.product.Name }
+func (r *productResolver) Description() string { return r.product.Description }
+func (r *productResolver) Tags() []string      { return r.product.Tags }
+func (r *productResolver) Attributes() []*attributeResolver {
+	return toAttributes(r.product.Attributes)
+}
+func (r *productResolver) Quantity() int32           { return int32(r.product.Quantity) }
+func (r *productResolver) Price() Money              { return Money(r.product.Price) }
+func (r *productResolver) StockPolicy() string       { return string(r.product.StockPolicy) }
+func (r *productResolver) ExpectedAt() *graphql.Time { return optionalTime(r.product.ExpectedAt) }
+func (r *productResolver) CreatedAt() graphql.Time   { return graphql.Time{Time: r.product.CreatedAt} }
+func (r *productResolver) UpdatedAt() graphql.Time   { return graphql.Time{Time: r.product.UpdatedAt} }
+
+func (r *productResolver) Variants() []*variantResolver {
+	result := make([]*variantResolver, len(r.product.Variants))
+	for i := range r.product.Variants {
+		result[i] = &variantResolver{variant: &r.product.Variants[i]}
+	}
+
+	return result
+}
+
+func (r *productResolver) Categories() []*categoryRefResolver {
+	result := make([]*categoryRefResolver, len(r.product.Categories))
+	for i, category := range r.product.Categories {
+		result[i] = &categoryRefResolver{category: category}
+	}
+
+	return result
+}
+
+type variantResolver struct {
+	variant *entities.ProductVariant
+}
+
+func (r *variantResolver) ID() graphql.ID { return graphql.ID(r.variant.ID.String()) }
+func (r *variantResolver) SKU() string    { return r.variant.SKU }
+func (r *variantResolver) Attributes() []*attributeResolver {
+	return toAttributes(r.variant.Attributes)
+}
+func (r *variantResolver) Price() Money    { return Money(r.variant.Price) }
+func (r *variantResolver) Quantity() int32 { return int32(r.variant.Quantity) }
+
+type orderResolver struct {
+	root  *rootResolver
+	order *entities.Order
+}
+
+func (r *orderResolver) ID() graphql.ID          { return graphql.ID(r.order.ID.String()) }
+func (r *orderResolver) Status() string          { return string(r.order.Status) }
+func (r *orderResolver) Total() Money            { return Money(r.order.Total) }
+func (r *orderResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.order.CreatedAt} }
+func (r *orderResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.order.UpdatedAt} }
+
+// RiskScore и HoldReason заполнены только у заказов, отложенных на проверку
+func (r *orderResolver) RiskScore() *float64 {
+	if r.order.RiskScore == 0 {
+		return nil
+	}
+	return &r.order.RiskScore
+}
+
+func (r *orderResolver) HoldReason() *string {
+	if r.order.HoldReason == "" {
+		return nil
+	}
+	return &r.order.HoldReason
+}
+
+func (r *orderResolver) User(ctx context.Context) (*userResolver, error) {
+	user, err := r.root.userService.GetUserByID(ctx, r.order.UserID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	return &userResolver{root: r.root, user: user}, nil
+}
+
+func (r *orderResolver) Items() []*orderItemResolver {
+	result := make([]*orderItemResolver, len(r.order.Items))
+	for i := range r.order.Items {
+		result[i] = &orderItemResolver{item: &r.order.Items[i]}
+	}
+
+	return result
+}
+
+type orderItemResolver struct {
+	item *entities.OrderItem
+}
+
+func (r *orderItemResolver) ID() graphql.ID            { return graphql.ID(r.item.ID.String()) }
+func (r *orderItemResolver) ProductID() graphql.ID     { return graphql.ID(r.item.ProductID.String()) }
+func (r *orderItemResolver) VariantID() *graphql.ID    { return optionalID(r.item.VariantID) }
+func (r *orderItemResolver) ProductName() string       { return r.item.ProductSnapshot.Name }
+func (r *orderItemResolver) Quantity() int32           { return int32(r.item.Quantity) }
+func (r *orderItemResolver) Backordered() int32        { return int32(r.item.Backordered) }
+func (r *orderItemResolver) ExpectedAt() *graphql.Time { return optionalTime(r.item.ExpectedAt) }
+func (r *orderItemResolver) PricePerItem() Money       { return Money(r.item.PricePerItem) }
+func (r *orderItemResolver) Total() Money              { return Money(r.item.Total) }
+
+// Product загружается через productLoader: товары всех позиций ответа приходят одним запросом к базе
+func (r *orderItemResolver) Product(ctx context.Context) (*productResolver, error) {
+	product, err := productLoaderFrom(ctx).Load(ctx, r.item.ProductID)
+	if errors.Is(err, domainErrors.ErrProductNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &productResolver{product: product}, nil
+}
@@ -0,0 +1,206 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	ordersv1 "github.com/AndrivA89/orders/internal/transport/grpc/proto/orders/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultListLimit совпадает с размером страницы REST API по умолчанию
+const defaultListLimit = 10
+
+func listLimit(limit int32) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	return int(limit)
+}
+
+func parseUUIDs(values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(values))
+	for i, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// parseOptionalUUID разбирает необязательный идентификатор; nil означает, что поле не задано
+func parseOptionalUUID(value *string) (*uuid.UUID, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(*value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func optionalUUID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	value := id.String()
+	return &value
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	t := ts.AsTime()
+	return &t
+}
+
+func toUserMessage(user *entities.User) *ordersv1.User {
+	return &ordersv1.User{
+		Id:        user.ID.String(),
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Language:  user.Language,
+		Age:       int32(user.Age),
+		IsMarried: user.IsMarried,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
+
+func toCategoryRefMessages(categories []entities.CategoryRef) []*ordersv1.CategoryRef {
+	result := make([]*ordersv1.CategoryRef, len(categories))
+	for i, category := range categories {
+		result[i] = &ordersv1.CategoryRef{
+			Id:   category.ID.String(),
+			Slug: category.Slug,
+			Name: category.Name,
+		}
+	}
+
+	return result
+}
+
+func toVariantMessage(variant *entities.ProductVariant) *ordersv1.ProductVariant {
+	return &ordersv1.ProductVariant{
+		Id:         variant.ID.String(),
+		ProductId:  variant.ProductID.String(),
+		Sku:        variant.SKU,
+		Attributes: variant.Attributes,
+		Price:      variant.Price,
+		Quantity:   int32(variant.Quantity),
+		CreatedAt:  timestamppb.New(variant.CreatedAt),
+		UpdatedAt:  timestamppb.New(variant.UpdatedAt),
+	}
+}
+
+func toProductMessage(product *entities.Product) *ordersv1.Product {
+	variants := make([]*ordersv1.ProductVariant, len(product.Variants))
+	for i := range product.Variants {
+		variants[i] = toVariantMessage(&product.Variants[i])
+	}
+
+	return &ordersv1.Product{
+		Id:                product.ID.String(),
+		Sku:               product.SKU,
+		Name:              product.Name,
+		Description:       product.Description,
+		Tags:              product.Tags,
+		Attributes:        product.Attributes,
+		Quantity:          int32(product.Quantity),
+		Price:             product.Price,
+		StockPolicy:       string(product.StockPolicy),
+		ExpectedAt:        optionalTimestamp(product.ExpectedAt),
+		LowStockThreshold: int32(product.LowStockThreshold),
+		Variants:          variants,
+		Categories:        toCategoryRefMessages(product.Categories),
+		CreatedAt:         timestamppb.New(product.CreatedAt),
+		UpdatedAt:         timestamppb.New(product.UpdatedAt),
+	}
+}
+
+func toProductMessages(products []*entities.Product) []*ordersv1.Product {
+	result := make([]*ordersv1.Product, len(products))
+	for i, product := range products {
+		result[i] = toProductMessage(product)
+	}
+
+	return result
+}
+
+func toStockReceiptMessage(receipt *entities.StockReceipt) *ordersv1.StockReceipt {
+	return &ordersv1.StockReceipt{
+		ProductId: receipt.ProductID.String(),
+		VariantId: optionalUUID(receipt.VariantID),
+		Received:  int32(receipt.Received),
+		Allocated: int32(receipt.Allocated),
+		Available: int32(receipt.Available),
+	}
+}
+
+func toOrderMessage(order *entities.Order) *ordersv1.Order {
+	items := make([]*ordersv1.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		snapshot := item.ProductSnapshot
+		items[i] = &ordersv1.OrderItem{
+			Id:        item.ID.String(),
+			ProductId: item.ProductID.String(),
+			VariantId: optionalUUID(item.VariantID),
+			ProductSnapshot: &ordersv1.ProductSnapshot{
+				Id:          snapshot.ID.String(),
+				VariantId:   optionalUUID(snapshot.VariantID),
+				Sku:         snapshot.SKU,
+				Name:        snapshot.Name,
+				Description: snapshot.Description,
+				Tags:        snapshot.Tags,
+				Attributes:  snapshot.Attributes,
+				Categories:  toCategoryRefMessages(snapshot.Categories),
+				Price:       snapshot.Price,
+			},
+			Quantity:     int32(item.Quantity),
+			Backordered:  int32(item.Backordered),
+			ExpectedAt:   optionalTimestamp(item.ExpectedAt),
+			PricePerItem: item.PricePerItem,
+			Total:        item.Total,
+		}
+	}
+
+	return &ordersv1.Order{
+		Id:         order.ID.String(),
+		UserId:     order.UserID.String(),
+		Status:     string(order.Status),
+		Total:      order.Total,
+		Items:      items,
+		RiskScore:  order.RiskScore,
+		HoldReason: order.HoldReason,
+		CreatedAt:  timestamppb.New(order.CreatedAt),
+		UpdatedAt:  timestamppb.New(order.UpdatedAt),
+	}
+}
+
+func toOrderMessages(orders []*entities.Order) []*ordersv1.Order {
+	result := make([]*ordersv1.Order, len(orders))
+	for i, order := range orders {
+		result[i] = toOrderMessage(order)
+	}
+
+	return result
+}
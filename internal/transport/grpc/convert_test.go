@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestParseOptionalUUID(t *testing.T) {
+	id, err := parseOptionalUUID(nil)
+	require.NoError(t, err)
+	assert.Nil(t, id)
+
+	value := uuid.New().String()
+	id, err = parseOptionalUUID(&value)
+	require.NoError(t, err)
+	assert.Equal(t, value, id.String())
+
+	invalid := "not-a-uuid"
+	_, err = parseOptionalUUID(&invalid)
+	assert.Error(t, err)
+}
+
+func TestParseUUIDs_FailsOnInvalidValue(t *testing.T) {
+	_, err := parseUUIDs([]string{uuid.New().String(), "not-a-uuid"})
+
+	assert.Error(t, err)
+}
+
+func TestOptionalTime_RoundTrip(t *testing.T) {
+	assert.Nil(t, optionalTimestamp(nil))
+	assert.Nil(t, optionalTime(nil))
+
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	converted := optionalTime(optionalTimestamp(&at))
+	require.NotNil(t, converted)
+	assert.True(t, at.Equal(*converted))
+}
+
+func TestToOrderMessage(t *testing.T) {
+	variantID := uuid.New()
+	expectedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	order := &entities.Order{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		Status:     entities.OrderStatusOnHold,
+		Total:      3000,
+		RiskScore:  75,
+		HoldReason: "new account",
+		Items: []entities.OrderItem{{
+			ID:        uuid.New(),
+			ProductID: uuid.New(),
+			VariantID: &variantID,
+			ProductSnapshot: entities.ProductSnapshot{
+				SKU:   "TSHIRT-RED-M",
+				Name:  "T-shirt",
+				Price: 1500,
+			},
+			Quantity:     2,
+			Backordered:  1,
+			ExpectedAt:   &expectedAt,
+			PricePerItem: 1500,
+			Total:        3000,
+		}},
+	}
+
+	message := toOrderMessage(order)
+
+	assert.Equal(t, order.ID.String(), message.Id)
+	assert.Equal(t, order.UserID.String(), message.UserId)
+	assert.Equal(t, "on_hold", message.Status)
+	assert.Equal(t, int64(3000), message.Total)
+	assert.Equal(t, "new account", message.HoldReason)
+	require.Len(t, message.Items, 1)
+
+	item := message.Items[0]
+	require.NotNil(t, item.VariantId)
+	assert.Equal(t, variantID.String(), *item.VariantId)
+	assert.Equal(t, "TSHIRT-RED-M", item.ProductSnapshot.Sku)
+	assert.Equal(t, int32(2), item.Quantity)
+	assert.Equal(t, int32(1), item.Backordered)
+	assert.Equal(t, timestamppb.New(expectedAt).AsTime(), item.ExpectedAt.AsTime())
+}
+
+func TestToProductMessage_WithoutVariants(t *testing.T) {
+	product := &entities.Product{
+		ID:          uuid.New(),
+		SKU:         "MUG-01",
+		Quantity:    5,
+		Price:       900,
+		StockPolicy: entities.StockPolicyInStock,
+	}
+
+	message := toProductMessage(product)
+
+	assert.Equal(t, "MUG-01", message.Sku)
+	assert.Equal(t, int32(5), message.Quantity)
+	assert.Equal(t, string(entities.StockPolicyInStock), message.StockPolicy)
+	assert.Nil(t, message.ExpectedAt)
+	assert.Empty(t, message.Variants)
+}
@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// errorCodes сопоставляет доменные ошибки кодам gRPC. Порядок важен только для обернутых ошибок,
+// поэтому список проверяется через errors.Is.
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{domainErrors.ErrUserNotFound, codes.NotFound},
+	{domainErrors.ErrProductNotFound, codes.NotFound},
+	{domainErrors.ErrVariantNotFound, codes.NotFound},
+	{domainErrors.ErrOrderNotFound, codes.NotFound},
+	{domainErrors.ErrCategoryNotFound, codes.NotFound},
+	// Сервисы, читающие сущность без перевода ошибки (например, товар позиции заказа), отдают ее как есть
+	{gorm.ErrRecordNotFound, codes.NotFound},
+
+	{domainErrors.ErrVariantDuplicate, codes.AlreadyExists},
+
+	// Частота заказов - аналог 429 в REST API
+	{domainErrors.ErrOrderVelocityLimit, codes.ResourceExhausted},
+
+	{domainErrors.ErrInsufficientStock, codes.FailedPrecondition},
+	{domainErrors.ErrInsufficientQuantity, codes.FailedPrecondition},
+	{domainErrors.ErrOrderProductQuantityLimit, codes.FailedPrecondition},
+	{domainErrors.ErrUserProductQuantityLimit, codes.FailedPrecondition},
+	{domainErrors.ErrOrderTotalLimit, codes.FailedPrecondition},
+	{domainErrors.ErrOnlyPendingCanConfirm, codes.FailedPrecondition},
+	{domainErrors.ErrCannotConfirmEmptyOrder, codes.FailedPrecondition},
+	{domainErrors.ErrCompletedOrdersReadonly, codes.FailedPrecondition},
+	{domainErrors.ErrOrderAlreadyCancelled, codes.FailedPrecondition},
+	{domainErrors.ErrOnlyPendingCanHold, codes.FailedPrecondition},
+	{domainErrors.ErrOnlyOnHoldCanRelease, codes.FailedPrecondition},
+	{domainErrors.ErrProductPricedByVariants, codes.FailedPrecondition},
+
+	{domainErrors.ErrFirstNameRequired, codes.InvalidArgument},
+	{domainErrors.ErrLastNameRequired, codes.InvalidArgument},
+	{domainErrors.ErrUserTooYoung, codes.InvalidArgument},
+	{domainErrors.ErrPasswordTooShort, codes.InvalidArgument},
+	{domainErrors.ErrLanguageUnsupported, codes.InvalidArgument},
+	{domainErrors.ErrProductDescriptionRequired, codes.InvalidArgument},
+	{domainErrors.ErrProductPriceInvalid, codes.InvalidArgument},
+	{domainErrors.ErrProductQuantityNegative, codes.InvalidArgument},
+	{domainErrors.ErrProductSKURequired, codes.InvalidArgument},
+	{domainErrors.ErrProductSKUTooLong, codes.InvalidArgument},
+	{domainErrors.ErrVariantAttributesRequired, codes.InvalidArgument},
+	{domainErrors.ErrVariantRequired, codes.InvalidArgument},
+	{domainErrors.ErrStockPolicyInvalid, codes.InvalidArgument},
+	{domainErrors.ErrPreorderDateRequired, codes.InvalidArgument},
+	{domainErrors.ErrQuantityInvalid, codes.InvalidArgument},
+	{domainErrors.ErrOrderMustHaveItems, codes.InvalidArgument},
+	{domainErrors.ErrInvalidOrderID, codes.InvalidArgument},
+	{domainErrors.ErrInvalidUserID, codes.InvalidArgument},
+}
+
+// toStatus переводит ошибку сервиса в статус gRPC. Текст неизвестных ошибок клиенту не отдается:
+// он попадает только в лог через интерцептор.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, mapping := range errorCodes {
+		if errors.Is(err, mapping.err) {
+			return status.Error(mapping.code, err.Error())
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"not found", domainErrors.ErrOrderNotFound, codes.NotFound},
+		{"wrapped not found", fmt.Errorf("load order: %w", domainErrors.ErrOrderNotFound), codes.NotFound},
+		{"record not found", gorm.ErrRecordNotFound, codes.NotFound},
+		{"validation", domainErrors.ErrQuantityInvalid, codes.InvalidArgument},
+		{"status transition", domainErrors.ErrOnlyPendingCanConfirm, codes.FailedPrecondition},
+		{"velocity limit", domainErrors.ErrOrderVelocityLimit, codes.ResourceExhausted},
+		{"duplicate", domainErrors.ErrVariantDuplicate, codes.AlreadyExists},
+		{"canceled", context.Canceled, codes.Canceled},
+		{"deadline", context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"already a status", status.Error(codes.PermissionDenied, "denied"), codes.PermissionDenied},
+		{"unknown", errors.New("connection refused"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(toStatus(tt.err)))
+		})
+	}
+}
+
+func TestToStatus_HidesInternalErrorText(t *testing.T) {
+	st, _ := status.FromError(toStatus(errors.New("dial tcp 10.0.0.5:5432: connection refused")))
+
+	assert.Equal(t, "internal error", st.Message())
+}
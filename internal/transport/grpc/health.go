@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/health"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// healthServer отвечает на grpc.health.v1 по тем же проверкам готовности, что и /readyz в REST API.
+// С начала остановки сервер отвечает NOT_SERVING, чтобы балансировщик перестал слать новые вызовы.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	checks       *health.Registry
+	services     map[string]bool
+	shuttingDown atomic.Bool
+}
+
+func newHealthServer(checks *health.Registry, services []string) *healthServer {
+	known := make(map[string]bool, len(services))
+	for _, name := range services {
+		known[name] = true
+	}
+
+	return &healthServer{checks: checks, services: known}
+}
+
+// Check проверяет весь сервер (пустое имя) или один из зарегистрированных сервисов: все они
+// работают поверх одних зависимостей, поэтому статус у них общий
+func (s *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.GetService() != "" && !s.services[req.GetService()] {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+
+	if s.shuttingDown.Load() || s.checks.Check(ctx).Status != health.StatusUp {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// List перечисляет сервисы с их текущим статусом
+func (s *healthServer) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	current, err := s.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]*healthpb.HealthCheckResponse, len(s.services)+1)
+	statuses[""] = current
+	for name := range s.services {
+		statuses[name] = current
+	}
+
+	return &healthpb.HealthListResponse{Statuses: statuses}, nil
+}
+
+func (s *healthServer) shutdown() {
+	s.shuttingDown.Store(true)
+}
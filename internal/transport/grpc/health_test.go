@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/health"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func checkHealth(t *testing.T, server *healthServer, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+
+	resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.Status
+}
+
+func TestHealthServer_FollowsReadinessChecks(t *testing.T) {
+	var dbErr error
+	checks := health.NewRegistry(time.Second, 0)
+	checks.Register("database", func(ctx context.Context) error { return dbErr }, 0)
+	server := newHealthServer(checks, []string{"orders.v1.OrderService"})
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkHealth(t, server, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkHealth(t, server, "orders.v1.OrderService"))
+
+	dbErr = errors.New("connection refused")
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkHealth(t, server, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkHealth(t, server, "orders.v1.OrderService"))
+}
+
+func TestHealthServer_NotServingAfterShutdownStarts(t *testing.T) {
+	server := newHealthServer(health.NewRegistry(time.Second, 0), nil)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, checkHealth(t, server, ""))
+
+	server.shutdown()
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkHealth(t, server, ""))
+}
+
+func TestHealthServer_UnknownService(t *testing.T) {
+	server := newHealthServer(health.NewRegistry(time.Second, 0), nil)
+
+	_, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown.Service"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
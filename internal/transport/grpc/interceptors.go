@@ -0,0 +1,211 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/database"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelCodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const tracerName = "github.com/AndrivA89/orders/internal/transport/grpc"
+
+// lastWriteKey - время последней записи клиента в метаданных, как заголовок X-Last-Write в REST API
+const lastWriteKey = "x-last-write"
+
+// publicMethodPrefixes - служебные сервисы, доступные без токена: health-проверки оркестратора и reflection
+var publicMethodPrefixes = []string{"/grpc.health.v1.", "/grpc.reflection."}
+
+// metadataCarrier позволяет извлечь контекст трассировки W3C из метаданных запроса
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// splitMethod разбирает "/orders.v1.OrderService/CreateOrder" на сервис и метод
+func splitMethod(fullMethod string) (string, string) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service, method
+}
+
+// tracingInterceptor открывает серверный span на вызов, продолжая трассу клиента, если она передана
+func tracingInterceptor() grpc.UnaryServerInterceptor {
+	tracer := otel.Tracer(tracerName)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+		}
+
+		service, method := splitMethod(info.FullMethod)
+		ctx, span := tracer.Start(ctx, strings.TrimPrefix(info.FullMethod, "/"),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.RPCSystemGRPC,
+				semconv.RPCService(service),
+				semconv.RPCMethod(method),
+			),
+		)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		span.SetAttributes(attribute.Int64(string(semconv.RPCGRPCStatusCodeKey), int64(code)))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelCodes.Error, code.String())
+		}
+
+		return resp, err
+	}
+}
+
+func loggingInterceptor(logger *logrus.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		latency := time.Since(start)
+
+		fields := logrus.Fields{
+			"method":     info.FullMethod,
+			"code":       status.Code(err).String(),
+			"latency":    latency,
+			"latency_ms": float64(latency.Nanoseconds()) / 1000000.0,
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			fields["client_ip"] = p.Addr.String()
+		}
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if requestID := md.Get("x-request-id"); len(requestID) > 0 {
+				fields["request_id"] = requestID[0]
+			}
+		}
+
+		logger.WithFields(fields).Info("gRPC Request")
+		return resp, err
+	}
+}
+
+func recoveryInterceptor(logger *logrus.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.WithFields(logrus.Fields{
+					"panic":  r,
+					"method": info.FullMethod,
+					"stack":  string(debug.Stack()),
+				}).Error("Panic recovered")
+
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// authInterceptor требует заголовок "authorization: Bearer <token>". Без token все вызовы,
+// кроме служебных, отклоняются, если проверка не отключена явно через allowUnauthenticated
+func authInterceptor(token string, allowUnauthenticated bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if token == "" {
+			if allowUnauthenticated {
+				return handler(ctx, req)
+			}
+			return nil, status.Error(codes.Unauthenticated, "authorization is not configured")
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing authorization token")
+		}
+
+		provided, found := strings.CutPrefix(values[0], "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization token")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func isPublicMethod(fullMethod string) bool {
+	for _, prefix := range publicMethodPrefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readYourWritesInterceptor восстанавливает из метаданных время последней записи клиента
+// и возвращает обновленное в заголовке ответа, если вызов что-то записал
+func readYourWritesInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		lastWrite := database.ParseWriteTime(metadataCarrier(md).Get(lastWriteKey))
+
+		stream := ctx
+		ctx = database.WithWriteMarker(ctx, lastWrite, func(at time.Time) {
+			_ = grpc.SetHeader(stream, metadata.Pairs(lastWriteKey, database.FormatWriteTime(at)))
+		})
+
+		return handler(ctx, req)
+	}
+}
+
+// errorInterceptor переводит доменные ошибки в статусы gRPC; исходный текст внутренних ошибок остается в логе
+func errorInterceptor(logger *logrus.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		st := toStatus(err)
+		if status.Code(st) == codes.Internal {
+			logger.WithFields(logrus.Fields{
+				"method": info.FullMethod,
+				"error":  err.Error(),
+			}).Error("gRPC request failed")
+		}
+
+		return nil, st
+	}
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func callAuth(t *testing.T, interceptor grpc.UnaryServerInterceptor, method, authorization string) error {
+	t.Helper()
+
+	ctx := context.Background()
+	if authorization != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", authorization))
+	}
+
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	return err
+}
+
+func TestAuthInterceptor_ChecksBearerToken(t *testing.T) {
+	interceptor := authInterceptor("secret", false)
+	method := "/orders.v1.OrderService/GetOrder"
+
+	assert.NoError(t, callAuth(t, interceptor, method, "Bearer secret"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(callAuth(t, interceptor, method, "")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(callAuth(t, interceptor, method, "Bearer wrong")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(callAuth(t, interceptor, method, "secret")))
+}
+
+func TestAuthInterceptor_RejectsEverythingWithoutToken(t *testing.T) {
+	interceptor := authInterceptor("", false)
+
+	err := callAuth(t, interceptor, "/orders.v1.OrderService/GetOrder", "Bearer anything")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// служебные сервисы остаются доступны: по ним оркестратор проверяет готовность
+	assert.NoError(t, callAuth(t, interceptor, "/grpc.health.v1.Health/Check", ""))
+	assert.NoError(t, callAuth(t, interceptor, "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo", ""))
+}
+
+func TestAuthInterceptor_AllowsUnauthenticatedWhenExplicitlyEnabled(t *testing.T) {
+	interceptor := authInterceptor("", true)
+
+	assert.NoError(t, callAuth(t, interceptor, "/orders.v1.OrderService/GetOrder", ""))
+}
@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	ordersv1 "github.com/AndrivA89/orders/internal/transport/grpc/proto/orders/v1"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type orderServer struct {
+	ordersv1.UnimplementedOrderServiceServer
+	orderService services.OrderService
+}
+
+func (s *orderServer) CreateOrder(ctx context.Context, req *ordersv1.CreateOrderRequest) (*ordersv1.Order, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, invalidArgument(domainErrors.ErrInvalidUserID)
+	}
+
+	items := make([]services.OrderItemRequest, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		productID, err := uuid.Parse(item.GetProductId())
+		if err != nil {
+			return nil, invalidArgument(err)
+		}
+
+		variantID, err := parseOptionalUUID(item.VariantId)
+		if err != nil {
+			return nil, invalidArgument(err)
+		}
+
+		items[i] = services.OrderItemRequest{
+			ProductID: productID,
+			VariantID: variantID,
+			Quantity:  int(item.GetQuantity()),
+		}
+	}
+
+	order, err := s.orderService.CreateOrder(ctx, &services.OrderRequest{UserID: userID, Items: items})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrderMessage(order), nil
+}
+
+func (s *orderServer) GetOrder(ctx context.Context, req *ordersv1.GetOrderRequest) (*ordersv1.Order, error) {
+	orderID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument(domainErrors.ErrInvalidOrderID)
+	}
+
+	order, err := s.orderService.GetOrderByID(ctx, orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, toStatus(domainErrors.ErrOrderNotFound)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toOrderMessage(order), nil
+}
+
+func (s *orderServer) ListUserOrders(
+	ctx context.Context,
+	req *ordersv1.ListUserOrdersRequest,
+) (*ordersv1.ListUserOrdersResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, invalidArgument(domainErrors.ErrInvalidUserID)
+	}
+
+	orders, err := s.orderService.GetOrdersByUserID(ctx, userID, listLimit(req.GetLimit()), int(req.GetOffset()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ordersv1.ListUserOrdersResponse{Orders: toOrderMessages(orders)}, nil
+}
+
+func (s *orderServer) ConfirmOrder(ctx context.Context, req *ordersv1.ConfirmOrderRequest) (*ordersv1.Order, error) {
+	return s.changeOrder(ctx, req.GetId(), s.orderService.ConfirmOrder)
+}
+
+func (s *orderServer) CancelOrder(ctx context.Context, req *ordersv1.CancelOrderRequest) (*ordersv1.Order, error) {
+	return s.changeOrder(ctx, req.GetId(), s.orderService.CancelOrder)
+}
+
+func (s *orderServer) ReleaseOrder(ctx context.Context, req *ordersv1.ReleaseOrderRequest) (*ordersv1.Order, error) {
+	return s.changeOrder(ctx, req.GetId(), s.orderService.ReleaseOrder)
+}
+
+// changeOrder выполняет переход статуса и возвращает заказ в новом состоянии, как и REST API
+func (s *orderServer) changeOrder(
+	ctx context.Context,
+	id string,
+	change func(context.Context, uuid.UUID) error,
+) (*ordersv1.Order, error) {
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, invalidArgument(domainErrors.ErrInvalidOrderID)
+	}
+
+	if err := change(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderService.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrderMessage(order), nil
+}
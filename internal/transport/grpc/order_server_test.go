@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	ordersv1 "github.com/AndrivA89/orders/internal/transport/grpc/proto/orders/v1"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// stubOrderService отвечает на GetOrderByID заданной ошибкой; остальные методы не нужны
+type stubOrderService struct {
+	services.OrderService
+	err error
+}
+
+func (s stubOrderService) GetOrderByID(context.Context, uuid.UUID) (*entities.Order, error) {
+	return nil, s.err
+}
+
+func TestOrderServer_GetOrder_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"not found", gorm.ErrRecordNotFound, codes.NotFound},
+		// недоступная БД не должна выглядеть для клиента как отсутствующий заказ
+		{"database unavailable", errors.New("connection refused"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &orderServer{orderService: stubOrderService{err: tt.err}}
+
+			_, err := server.GetOrder(context.Background(), &ordersv1.GetOrderRequest{Id: uuid.NewString()})
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
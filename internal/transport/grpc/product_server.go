@@ -0,0 +1,152 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	ordersv1 "github.com/AndrivA89/orders/internal/transport/grpc/proto/orders/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type productServer struct {
+	ordersv1.UnimplementedProductServiceServer
+	productService services.ProductService
+}
+
+func toVariantRequest(variant *ordersv1.VariantInput) services.CreateVariantRequest {
+	return services.CreateVariantRequest{
+		SKU:        variant.GetSku(),
+		Attributes: variant.GetAttributes(),
+		Price:      variant.GetPrice(),
+		Quantity:   int(variant.GetQuantity()),
+	}
+}
+
+func (s *productServer) CreateProduct(ctx context.Context, req *ordersv1.CreateProductRequest) (*ordersv1.Product, error) {
+	categoryIDs, err := parseUUIDs(req.GetCategoryIds())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	variants := make([]services.CreateVariantRequest, len(req.GetVariants()))
+	for i, variant := range req.GetVariants() {
+		variants[i] = toVariantRequest(variant)
+	}
+
+	product, err := s.productService.CreateProduct(ctx, &services.CreateProductRequest{
+		SKU:         req.GetSku(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Tags:        req.GetTags(),
+		Attributes:  req.GetAttributes(),
+		Quantity:    int(req.GetQuantity()),
+		Price:       req.GetPrice(),
+		StockPolicy: entities.StockPolicy(req.GetStockPolicy()),
+		ExpectedAt:  optionalTime(req.GetExpectedAt()),
+		Variants:    variants,
+		CategoryIDs: categoryIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductMessage(product), nil
+}
+
+func (s *productServer) GetProduct(ctx context.Context, req *ordersv1.GetProductRequest) (*ordersv1.Product, error) {
+	productID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	product, err := s.productService.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, domainErrors.ErrProductNotFound.Error())
+	}
+
+	return toProductMessage(product), nil
+}
+
+func (s *productServer) ListProducts(ctx context.Context, req *ordersv1.ListProductsRequest) (*ordersv1.ListProductsResponse, error) {
+	limit := listLimit(req.GetLimit())
+	offset := int(req.GetOffset())
+
+	var (
+		products []*entities.Product
+		err      error
+	)
+	if req.GetCategory() != "" {
+		products, err = s.productService.GetProductsByCategory(ctx, req.GetCategory(), limit, offset)
+	} else {
+		products, err = s.productService.GetProducts(ctx, limit, offset)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ordersv1.ListProductsResponse{Products: toProductMessages(products)}, nil
+}
+
+func (s *productServer) SetProductCategories(
+	ctx context.Context,
+	req *ordersv1.SetProductCategoriesRequest,
+) (*ordersv1.Product, error) {
+	productID, err := uuid.Parse(req.GetProductId())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	categoryIDs, err := parseUUIDs(req.GetCategoryIds())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	product, err := s.productService.SetProductCategories(ctx, productID, categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductMessage(product), nil
+}
+
+func (s *productServer) AddVariant(ctx context.Context, req *ordersv1.AddVariantRequest) (*ordersv1.ProductVariant, error) {
+	productID, err := uuid.Parse(req.GetProductId())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	variantRequest := toVariantRequest(req.GetVariant())
+	variant, err := s.productService.AddVariant(ctx, productID, &variantRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return toVariantMessage(variant), nil
+}
+
+func (s *productServer) ReceiveStock(ctx context.Context, req *ordersv1.ReceiveStockRequest) (*ordersv1.StockReceipt, error) {
+	productID, err := uuid.Parse(req.GetProductId())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	variantID, err := parseOptionalUUID(req.VariantId)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	receipt, err := s.productService.ReceiveStock(ctx, productID, &services.ReceiveStockRequest{
+		VariantID: variantID,
+		Quantity:  int(req.GetQuantity()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toStockReceiptMessage(receipt), nil
+}
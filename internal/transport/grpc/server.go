@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/infrastructure/health"
+	ordersv1 "github.com/AndrivA89/orders/internal/transport/grpc/proto/orders/v1"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server - gRPC-сервер API вместе со своим grpc.health.v1
+type Server struct {
+	*grpc.Server
+
+	health *healthServer
+}
+
+// NewServer собирает gRPC-сервер поверх тех же доменных сервисов, что и REST API.
+// Кроме сервисов заказов, товаров и пользователей регистрируются reflection и grpc.health.v1,
+// который отвечает по проверкам готовности healthChecks.
+// Без authToken вызовы отклоняются, если не задан allowUnauthenticated.
+func NewServer(
+	orderService services.OrderService,
+	productService services.ProductService,
+	userService services.UserService,
+	healthChecks *health.Registry,
+	authToken string,
+	allowUnauthenticated bool,
+	logger *logrus.Logger,
+) *Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			tracingInterceptor(),
+			loggingInterceptor(logger),
+			recoveryInterceptor(logger),
+			authInterceptor(authToken, allowUnauthenticated),
+			readYourWritesInterceptor(),
+			errorInterceptor(logger),
+		),
+	)
+
+	ordersv1.RegisterOrderServiceServer(server, &orderServer{orderService: orderService})
+	ordersv1.RegisterProductServiceServer(server, &productServer{productService: productService})
+	ordersv1.RegisterUserServiceServer(server, &userServer{userService: userService})
+
+	services := make([]string, 0, len(server.GetServiceInfo()))
+	for name := range server.GetServiceInfo() {
+		services = append(services, name)
+	}
+	healthServer := newHealthServer(healthChecks, services)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	reflection.Register(server)
+
+	return &Server{Server: server, health: healthServer}
+}
+
+// BeginShutdown переводит health-проверку в NOT_SERVING; вызывается до GracefulStop,
+// пока балансировщик еще может опросить сервер и вывести его из ротации
+func (s *Server) BeginShutdown() {
+	s.health.shutdown()
+}
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	ordersv1 "github.com/AndrivA89/orders/internal/transport/grpc/proto/orders/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type userServer struct {
+	ordersv1.UnimplementedUserServiceServer
+	userService services.UserService
+}
+
+func (s *userServer) RegisterUser(ctx context.Context, req *ordersv1.RegisterUserRequest) (*ordersv1.User, error) {
+	user, err := s.userService.RegisterUser(ctx, &services.CreateUserRequest{
+		FirstName: req.GetFirstName(),
+		LastName:  req.GetLastName(),
+		Email:     req.GetEmail(),
+		Language:  req.GetLanguage(),
+		Age:       int(req.GetAge()),
+		IsMarried: req.GetIsMarried(),
+		Password:  req.GetPassword(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserMessage(user), nil
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *ordersv1.GetUserRequest) (*ordersv1.User, error) {
+	userID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument(domainErrors.ErrInvalidUserID)
+	}
+
+	user, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, domainErrors.ErrUserNotFound.Error())
+	}
+
+	return toUserMessage(user), nil
+}
@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+type CreateCategoryRequest struct {
+	ParentID *uuid.UUID `json:"parent_id"`
+	Name     string     `json:"name" binding:"required,max=255"`
+	Slug     string     `json:"slug" binding:"required,max=100"`
+	Position int        `json:"position"`
+}
+
+func (req *CreateCategoryRequest) ToServiceRequest() *services.CreateCategoryRequest {
+	return &services.CreateCategoryRequest{
+		ParentID: req.ParentID,
+		Name:     req.Name,
+		Slug:     req.Slug,
+		Position: req.Position,
+	}
+}
+
+type SetProductCategoriesRequest struct {
+	CategoryIDs []uuid.UUID `json:"category_ids"`
+}
+
+type CategoryResponse struct {
+	ID       uuid.UUID          `json:"id"`
+	ParentID *uuid.UUID         `json:"parent_id,omitempty"`
+	Name     string             `json:"name"`
+	Slug     string             `json:"slug"`
+	Position int                `json:"position"`
+	Children []CategoryResponse `json:"children,omitempty"`
+}
+
+type CategoryListResponse struct {
+	Categories []CategoryResponse `json:"categories"`
+}
+
+type CategoryRefResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Slug string    `json:"slug"`
+	Name string    `json:"name"`
+}
+
+func ToCategoryResponse(category *entities.Category) *CategoryResponse {
+	response := &CategoryResponse{
+		ID:       category.ID,
+		ParentID: category.ParentID,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		Position: category.Position,
+	}
+
+	for _, child := range category.Children {
+		response.Children = append(response.Children, *ToCategoryResponse(child))
+	}
+
+	return response
+}
+
+func ToCategoryRefResponses(refs []entities.CategoryRef) []CategoryRefResponse {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	responses := make([]CategoryRefResponse, len(refs))
+	for i, ref := range refs {
+		responses[i] = CategoryRefResponse{ID: ref.ID, Slug: ref.Slug, Name: ref.Name}
+	}
+	return responses
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type EmailDeliveryResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Event         string     `json:"event"`
+	Recipient     string     `json:"recipient"`
+	Language      string     `json:"language"`
+	Subject       string     `json:"subject,omitempty"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+}
+
+func ToEmailDeliveryResponses(deliveries []*entities.EmailDelivery) []EmailDeliveryResponse {
+	result := make([]EmailDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		result[i] = EmailDeliveryResponse{
+			ID:        delivery.ID,
+			Event:     string(delivery.Event),
+			Recipient: delivery.Recipient,
+			Language:  delivery.Language,
+			Subject:   delivery.Subject,
+			Status:    string(delivery.Status),
+			Attempts:  delivery.Attempts,
+			LastError: delivery.LastError,
+			CreatedAt: delivery.CreatedAt,
+			SentAt:    delivery.SentAt,
+		}
+		// Время следующей попытки имеет смысл только для писем, которые еще будут отправляться
+		if delivery.Status == entities.EmailDeliveryStatusPending {
+			nextAttemptAt := delivery.NextAttemptAt
+			result[i].NextAttemptAt = &nextAttemptAt
+		}
+	}
+
+	return result
+}
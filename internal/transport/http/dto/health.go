@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// HealthResponse - ответ проверок /livez, /readyz и /health. Checks заполняется только в подробном отчете.
+type HealthResponse struct {
+	Status  string                `json:"status"`
+	Service string                `json:"service"`
+	Checks  []HealthCheckResponse `json:"checks,omitempty"`
+}
+
+type HealthCheckResponse struct {
+	Name string `json:"name"`
+	// Optional - проверка не влияет на готовность: сервис работает и без этой зависимости
+	Optional   bool      `json:"optional,omitempty"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
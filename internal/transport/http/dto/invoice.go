@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type InvoiceResponse struct {
+	XMLName    xml.Name              `json:"-" xml:"invoice"`
+	ID         uuid.UUID             `json:"id" xml:"id"`
+	OrderID    uuid.UUID             `json:"order_id" xml:"order_id"`
+	Number     string                `json:"number" xml:"number"`
+	SellerName string                `json:"seller_name" xml:"seller_name"`
+	Customer   InvoiceCustomer       `json:"customer" xml:"customer"`
+	Lines      []InvoiceLineResponse `json:"lines" xml:"lines>line"`
+	Total      int64                 `json:"total" xml:"total"`
+	Currency   string                `json:"currency" xml:"currency"`
+	IssuedAt   time.Time             `json:"issued_at" xml:"issued_at"`
+}
+
+type InvoiceCustomer struct {
+	ID       uuid.UUID `json:"id" xml:"id"`
+	FullName string    `json:"full_name" xml:"full_name"`
+}
+
+type InvoiceLineResponse struct {
+	ProductID   uuid.UUID `json:"product_id" xml:"product_id"`
+	Description string    `json:"description" xml:"description"`
+	Quantity    int       `json:"quantity" xml:"quantity"`
+	UnitPrice   int64     `json:"unit_price" xml:"unit_price"`
+	Total       int64     `json:"total" xml:"total"`
+}
+
+func ToInvoiceResponse(invoice *entities.Invoice) *InvoiceResponse {
+	lines := make([]InvoiceLineResponse, 0, len(invoice.Lines))
+	for _, line := range invoice.Lines {
+		lines = append(lines, InvoiceLineResponse{
+			ProductID:   line.ProductID,
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Total:       line.Total,
+		})
+	}
+
+	return &InvoiceResponse{
+		ID:         invoice.ID,
+		OrderID:    invoice.OrderID,
+		Number:     invoice.Number,
+		SellerName: invoice.SellerName,
+		Customer: InvoiceCustomer{
+			ID:       invoice.Customer.ID,
+			FullName: invoice.Customer.FullName,
+		},
+		Lines:    lines,
+		Total:    invoice.Total,
+		Currency: invoice.Currency,
+		IssuedAt: invoice.IssuedAt,
+	}
+}
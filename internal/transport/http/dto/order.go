@@ -15,8 +15,9 @@ type CreateOrderRequest struct {
 }
 
 type OrderItemRequest struct {
-	ProductID uuid.UUID `json:"product_id" binding:"required"`
-	Quantity  int       `json:"quantity" binding:"required,min=1"`
+	ProductID uuid.UUID  `json:"product_id" binding:"required"`
+	VariantID *uuid.UUID `json:"variant_id"`
+	Quantity  int        `json:"quantity" binding:"required,min=1"`
 }
 
 func (req *CreateOrderRequest) ToServiceRequest() *services.OrderRequest {
@@ -24,6 +25,7 @@ func (req *CreateOrderRequest) ToServiceRequest() *services.OrderRequest {
 	for _, item := range req.Items {
 		items = append(items, services.OrderItemRequest{
 			ProductID: item.ProductID,
+			VariantID: item.VariantID,
 			Quantity:  item.Quantity,
 		})
 	}
@@ -35,30 +37,40 @@ func (req *CreateOrderRequest) ToServiceRequest() *services.OrderRequest {
 }
 
 type OrderResponse struct {
-	ID        uuid.UUID           `json:"id"`
-	UserID    uuid.UUID           `json:"user_id"`
-	Status    string              `json:"status"`
-	Total     int64               `json:"total"`
-	Items     []OrderItemResponse `json:"items"`
-	CreatedAt time.Time           `json:"created_at"`
-	UpdatedAt time.Time           `json:"updated_at"`
+	ID         uuid.UUID           `json:"id"`
+	UserID     uuid.UUID           `json:"user_id"`
+	Status     string              `json:"status"`
+	Total      int64               `json:"total"`
+	RiskScore  float64             `json:"risk_score,omitempty"`
+	HoldReason string              `json:"hold_reason,omitempty"`
+	Items      []OrderItemResponse `json:"items"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
 }
 
 type OrderItemResponse struct {
 	ID              uuid.UUID               `json:"id"`
 	ProductID       uuid.UUID               `json:"product_id"`
+	VariantID       *uuid.UUID              `json:"variant_id,omitempty"`
 	ProductSnapshot ProductSnapshotResponse `json:"product_snapshot"`
 	Quantity        int                     `json:"quantity"`
+	Backordered     int                     `json:"backordered,omitempty"`
+	ExpectedAt      *time.Time              `json:"expected_at,omitempty"`
 	PricePerItem    int64                   `json:"price_per_item"`
 	Total           int64                   `json:"total"`
 	CreatedAt       time.Time               `json:"created_at"`
 }
 
 type ProductSnapshotResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Description string    `json:"description"`
-	Tags        []string  `json:"tags"`
-	Price       int64     `json:"price"`
+	ID          uuid.UUID             `json:"id"`
+	VariantID   *uuid.UUID            `json:"variant_id,omitempty"`
+	SKU         string                `json:"sku,omitempty"`
+	Name        string                `json:"name,omitempty"`
+	Description string                `json:"description"`
+	Tags        []string              `json:"tags"`
+	Attributes  map[string]string     `json:"attributes,omitempty"`
+	Categories  []CategoryRefResponse `json:"categories,omitempty"`
+	Price       int64                 `json:"price"`
 }
 
 type OrderListResponse struct {
@@ -74,13 +86,21 @@ func ToOrderResponse(order *entities.Order) *OrderResponse {
 		items = append(items, OrderItemResponse{
 			ID:        item.ID,
 			ProductID: item.ProductID,
+			VariantID: item.VariantID,
 			ProductSnapshot: ProductSnapshotResponse{
 				ID:          item.ProductSnapshot.ID,
+				VariantID:   item.ProductSnapshot.VariantID,
+				SKU:         item.ProductSnapshot.SKU,
+				Name:        item.ProductSnapshot.Name,
 				Description: item.ProductSnapshot.Description,
 				Tags:        item.ProductSnapshot.Tags,
+				Attributes:  item.ProductSnapshot.Attributes,
+				Categories:  ToCategoryRefResponses(item.ProductSnapshot.Categories),
 				Price:       item.ProductSnapshot.Price,
 			},
 			Quantity:     item.Quantity,
+			Backordered:  item.Backordered,
+			ExpectedAt:   item.ExpectedAt,
 			PricePerItem: item.PricePerItem,
 			Total:        item.Total,
 			CreatedAt:    item.CreatedAt,
@@ -88,13 +108,15 @@ func ToOrderResponse(order *entities.Order) *OrderResponse {
 	}
 
 	return &OrderResponse{
-		ID:        order.ID,
-		UserID:    order.UserID,
-		Status:    string(order.Status),
-		Total:     order.Total,
-		Items:     items,
-		CreatedAt: order.CreatedAt,
-		UpdatedAt: order.UpdatedAt,
+		ID:         order.ID,
+		UserID:     order.UserID,
+		Status:     string(order.Status),
+		Total:      order.Total,
+		RiskScore:  order.RiskScore,
+		HoldReason: order.HoldReason,
+		Items:      items,
+		CreatedAt:  order.CreatedAt,
+		UpdatedAt:  order.UpdatedAt,
 	}
 }
 
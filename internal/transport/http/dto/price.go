@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// SchedulePriceRequest - без effective_from цена меняется немедленно
+type SchedulePriceRequest struct {
+	Price         int64      `json:"price" binding:"required,min=1"`
+	EffectiveFrom *time.Time `json:"effective_from"`
+}
+
+func (req *SchedulePriceRequest) ToServiceRequest() *services.SchedulePriceRequest {
+	return &services.SchedulePriceRequest{
+		Price:         req.Price,
+		EffectiveFrom: req.EffectiveFrom,
+	}
+}
+
+type PriceChangeResponse struct {
+	ID        uuid.UUID            `json:"id"`
+	ProductID uuid.UUID            `json:"product_id"`
+	Price     int64                `json:"price"`
+	Status    entities.PriceStatus `json:"status"`
+	ValidFrom time.Time            `json:"valid_from"`
+	ValidTo   *time.Time           `json:"valid_to,omitempty"`
+	AppliedAt *time.Time           `json:"applied_at,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+type PriceHistoryResponse struct {
+	Prices []*PriceChangeResponse `json:"prices"`
+}
+
+func ToPriceChangeResponse(change *entities.PriceChange, now time.Time) *PriceChangeResponse {
+	return &PriceChangeResponse{
+		ID:        change.ID,
+		ProductID: change.ProductID,
+		Price:     change.Price,
+		Status:    change.Status(now),
+		ValidFrom: change.ValidFrom,
+		ValidTo:   change.ValidTo,
+		AppliedAt: change.AppliedAt,
+		CreatedAt: change.CreatedAt,
+	}
+}
@@ -10,39 +10,133 @@ import (
 )
 
 type CreateProductRequest struct {
-	Description string   `json:"description" binding:"required,min=1,max=500"`
-	Tags        []string `json:"tags"`
-	Quantity    int      `json:"quantity" binding:"required,min=0"`
-	Price       int64    `json:"price" binding:"required,min=1"`
+	SKU         string                 `json:"sku" binding:"max=64"`
+	Name        string                 `json:"name" binding:"max=255"`
+	Description string                 `json:"description" binding:"required,min=1,max=500"`
+	Tags        []string               `json:"tags"`
+	Attributes  map[string]string      `json:"attributes"`
+	Quantity    int                    `json:"quantity" binding:"min=0"`
+	Price       int64                  `json:"price" binding:"min=0"`
+	StockPolicy string                 `json:"stock_policy" binding:"omitempty,oneof=in_stock backorder preorder"`
+	ExpectedAt  *time.Time             `json:"expected_at"`
+	Variants    []CreateVariantRequest `json:"variants" binding:"dive"`
+	CategoryIDs []uuid.UUID            `json:"category_ids"`
+}
+
+type CreateVariantRequest struct {
+	SKU        string            `json:"sku" binding:"required,max=64"`
+	Attributes map[string]string `json:"attributes" binding:"required,min=1"`
+	Price      int64             `json:"price" binding:"required,min=1"`
+	Quantity   int               `json:"quantity" binding:"min=0"`
 }
 
 func (req *CreateProductRequest) ToServiceRequest() *services.CreateProductRequest {
+	variants := make([]services.CreateVariantRequest, 0, len(req.Variants))
+	for _, variant := range req.Variants {
+		variants = append(variants, *variant.ToServiceRequest())
+	}
+
 	return &services.CreateProductRequest{
+		SKU:         req.SKU,
+		Name:        req.Name,
 		Description: req.Description,
 		Tags:        req.Tags,
+		Attributes:  req.Attributes,
 		Quantity:    req.Quantity,
 		Price:       req.Price,
+		StockPolicy: entities.StockPolicy(req.StockPolicy),
+		ExpectedAt:  req.ExpectedAt,
+		Variants:    variants,
+		CategoryIDs: req.CategoryIDs,
+	}
+}
+
+func (req *CreateVariantRequest) ToServiceRequest() *services.CreateVariantRequest {
+	return &services.CreateVariantRequest{
+		SKU:        req.SKU,
+		Attributes: req.Attributes,
+		Price:      req.Price,
+		Quantity:   req.Quantity,
+	}
+}
+
+// ReceiveStockRequest - для товара с вариантами variant_id обязателен
+type ReceiveStockRequest struct {
+	VariantID *uuid.UUID `json:"variant_id"`
+	Quantity  int        `json:"quantity" binding:"required,min=1"`
+}
+
+func (req *ReceiveStockRequest) ToServiceRequest() *services.ReceiveStockRequest {
+	return &services.ReceiveStockRequest{
+		VariantID: req.VariantID,
+		Quantity:  req.Quantity,
 	}
 }
 
 type ProductResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Description string    `json:"description"`
-	Tags        []string  `json:"tags"`
-	Quantity    int       `json:"quantity"`
-	Price       int64     `json:"price"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                uuid.UUID                `json:"id"`
+	SKU               string                   `json:"sku,omitempty"`
+	Name              string                   `json:"name,omitempty"`
+	Description       string                   `json:"description"`
+	Tags              []string                 `json:"tags"`
+	Attributes        map[string]string        `json:"attributes,omitempty"`
+	Quantity          int                      `json:"quantity"`
+	Price             int64                    `json:"price"`
+	StockPolicy       string                   `json:"stock_policy"`
+	ExpectedAt        *time.Time               `json:"expected_at,omitempty"`
+	LowStockThreshold int                      `json:"low_stock_threshold"`
+	Variants          []ProductVariantResponse `json:"variants,omitempty"`
+	Categories        []CategoryRefResponse    `json:"categories,omitempty"`
+	CreatedAt         time.Time                `json:"created_at"`
+	UpdatedAt         time.Time                `json:"updated_at"`
+}
+
+type ProductListResponse struct {
+	Products []*ProductResponse `json:"products"`
+	Total    int                `json:"total"`
+	Limit    int                `json:"limit"`
+	Offset   int                `json:"offset"`
+}
+
+type ProductVariantResponse struct {
+	ID         uuid.UUID         `json:"id"`
+	SKU        string            `json:"sku"`
+	Attributes map[string]string `json:"attributes"`
+	Price      int64             `json:"price"`
+	Quantity   int               `json:"quantity"`
 }
 
 func ToProductResponse(product *entities.Product) *ProductResponse {
+	var variants []ProductVariantResponse
+	for i := range product.Variants {
+		variants = append(variants, *ToProductVariantResponse(&product.Variants[i]))
+	}
+
 	return &ProductResponse{
-		ID:          product.ID,
-		Description: product.Description,
-		Tags:        product.Tags,
-		Quantity:    product.Quantity,
-		Price:       product.Price,
-		CreatedAt:   product.CreatedAt,
-		UpdatedAt:   product.UpdatedAt,
+		ID:                product.ID,
+		SKU:               product.SKU,
+		Name:              product.Name,
+		Description:       product.Description,
+		Tags:              product.Tags,
+		Attributes:        product.Attributes,
+		Quantity:          product.Quantity,
+		Price:             product.Price,
+		StockPolicy:       string(product.StockPolicy),
+		ExpectedAt:        product.ExpectedAt,
+		LowStockThreshold: product.LowStockThreshold,
+		Variants:          variants,
+		Categories:        ToCategoryRefResponses(product.Categories),
+		CreatedAt:         product.CreatedAt,
+		UpdatedAt:         product.UpdatedAt,
+	}
+}
+
+func ToProductVariantResponse(variant *entities.ProductVariant) *ProductVariantResponse {
+	return &ProductVariantResponse{
+		ID:         variant.ID,
+		SKU:        variant.SKU,
+		Attributes: variant.Attributes,
+		Price:      variant.Price,
+		Quantity:   variant.Quantity,
 	}
 }
@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+)
+
+type SalesSummaryResponse struct {
+	From              time.Time `json:"from"`
+	To                time.Time `json:"to"`
+	TotalOrders       int       `json:"total_orders"`
+	RevenueOrders     int       `json:"revenue_orders"`
+	CancelledOrders   int       `json:"cancelled_orders"`
+	Revenue           int64     `json:"revenue"`
+	AverageOrderValue int64     `json:"average_order_value"`
+	CancellationRate  float64   `json:"cancellation_rate"`
+}
+
+func ToSalesSummaryResponse(summary *entities.SalesSummary, from, to time.Time) *SalesSummaryResponse {
+	return &SalesSummaryResponse{
+		From:              from,
+		To:                to,
+		TotalOrders:       summary.TotalOrders,
+		RevenueOrders:     summary.RevenueOrders,
+		CancelledOrders:   summary.CancelledOrders,
+		Revenue:           summary.Revenue,
+		AverageOrderValue: summary.AverageOrderValue(),
+		CancellationRate:  summary.CancellationRate(),
+	}
+}
+
+// CSV-представления отчетов: первая строка - заголовок
+
+func SalesSummaryCSV(summary *SalesSummaryResponse) [][]string {
+	return [][]string{
+		{"from", "to", "total_orders", "revenue_orders", "cancelled_orders", "revenue", "average_order_value", "cancellation_rate"},
+		{
+			summary.From.Format(time.RFC3339),
+			summary.To.Format(time.RFC3339),
+			strconv.Itoa(summary.TotalOrders),
+			strconv.Itoa(summary.RevenueOrders),
+			strconv.Itoa(summary.CancelledOrders),
+			strconv.FormatInt(summary.Revenue, 10),
+			strconv.FormatInt(summary.AverageOrderValue, 10),
+			strconv.FormatFloat(summary.CancellationRate, 'f', 4, 64),
+		},
+	}
+}
+
+func SalesByPeriodCSV(points []entities.SalesPoint) [][]string {
+	records := [][]string{{"period", "orders", "items_sold", "revenue"}}
+	for _, point := range points {
+		records = append(records, []string{
+			point.Period.Format(time.RFC3339),
+			strconv.Itoa(point.Orders),
+			strconv.Itoa(point.ItemsSold),
+			strconv.FormatInt(point.Revenue, 10),
+		})
+	}
+	return records
+}
+
+func ProductSalesCSV(products []entities.ProductSales) [][]string {
+	records := [][]string{{"product_id", "description", "quantity", "revenue"}}
+	for _, product := range products {
+		records = append(records, []string{
+			product.ProductID.String(),
+			product.Description,
+			strconv.Itoa(product.Quantity),
+			strconv.FormatInt(product.Revenue, 10),
+		})
+	}
+	return records
+}
+
+func TagSalesCSV(tags []entities.TagSales) [][]string {
+	records := [][]string{{"tag", "quantity", "revenue"}}
+	for _, tag := range tags {
+		records = append(records, []string{
+			tag.Tag,
+			strconv.Itoa(tag.Quantity),
+			strconv.FormatInt(tag.Revenue, 10),
+		})
+	}
+	return records
+}
+
+func CategorySalesCSV(categories []entities.CategorySales) [][]string {
+	records := [][]string{{"slug", "name", "quantity", "revenue"}}
+	for _, category := range categories {
+		records = append(records, []string{
+			category.Slug,
+			category.Name,
+			strconv.Itoa(category.Quantity),
+			strconv.FormatInt(category.Revenue, 10),
+		})
+	}
+	return records
+}
+
+func LowStockCSV(products []entities.LowStockProduct) [][]string {
+	records := [][]string{{"product_id", "variant_id", "sku", "description", "quantity"}}
+	for _, product := range products {
+		variantID := ""
+		if product.VariantID != nil {
+			variantID = product.VariantID.String()
+		}
+
+		records = append(records, []string{
+			product.ProductID.String(),
+			variantID,
+			product.SKU,
+			product.Description,
+			strconv.Itoa(product.Quantity),
+		})
+	}
+	return records
+}
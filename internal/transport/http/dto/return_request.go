@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+type OpenReturnRequest struct {
+	UserID uuid.UUID           `json:"user_id" binding:"required"`
+	Items  []ReturnItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type ReturnItemRequest struct {
+	OrderItemID uuid.UUID `json:"order_item_id" binding:"required"`
+	Quantity    int       `json:"quantity" binding:"required,min=1"`
+	Reason      string    `json:"reason" binding:"required"`
+	Comment     string    `json:"comment" binding:"max=500"`
+}
+
+func (req *OpenReturnRequest) ToServiceRequest(orderID uuid.UUID) *services.OpenReturnRequest {
+	items := make([]services.ReturnItemRequest, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, services.ReturnItemRequest{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+			Reason:      item.Reason,
+			Comment:     item.Comment,
+		})
+	}
+
+	return &services.OpenReturnRequest{
+		OrderID: orderID,
+		UserID:  req.UserID,
+		Items:   items,
+	}
+}
+
+type ReviewReturnRequest struct {
+	Comment string `json:"comment" binding:"max=500"`
+}
+
+type ReturnResponse struct {
+	ID            uuid.UUID            `json:"id"`
+	OrderID       uuid.UUID            `json:"order_id"`
+	UserID        uuid.UUID            `json:"user_id"`
+	Status        string               `json:"status"`
+	Items         []ReturnItemResponse `json:"items"`
+	RefundAmount  int64                `json:"refund_amount"`
+	ReviewComment string               `json:"review_comment,omitempty"`
+	ReviewedAt    *time.Time           `json:"reviewed_at,omitempty"`
+	ReceivedAt    *time.Time           `json:"received_at,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+type ReturnItemResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	OrderItemID  uuid.UUID  `json:"order_item_id"`
+	ProductID    uuid.UUID  `json:"product_id"`
+	VariantID    *uuid.UUID `json:"variant_id,omitempty"`
+	Quantity     int        `json:"quantity"`
+	Reason       string     `json:"reason"`
+	Comment      string     `json:"comment,omitempty"`
+	PricePerItem int64      `json:"price_per_item"`
+	RefundAmount int64      `json:"refund_amount"`
+}
+
+type ReturnListResponse struct {
+	Returns []ReturnResponse `json:"returns"`
+	Total   int              `json:"total"`
+}
+
+func ToReturnResponse(request *entities.ReturnRequest) *ReturnResponse {
+	items := make([]ReturnItemResponse, 0, len(request.Items))
+	for _, item := range request.Items {
+		items = append(items, ReturnItemResponse{
+			ID:           item.ID,
+			OrderItemID:  item.OrderItemID,
+			ProductID:    item.ProductID,
+			VariantID:    item.VariantID,
+			Quantity:     item.Quantity,
+			Reason:       string(item.Reason),
+			Comment:      item.Comment,
+			PricePerItem: item.PricePerItem,
+			RefundAmount: item.RefundAmount,
+		})
+	}
+
+	return &ReturnResponse{
+		ID:            request.ID,
+		OrderID:       request.OrderID,
+		UserID:        request.UserID,
+		Status:        string(request.Status),
+		Items:         items,
+		RefundAmount:  request.RefundAmount,
+		ReviewComment: request.ReviewComment,
+		ReviewedAt:    request.ReviewedAt,
+		ReceivedAt:    request.ReceivedAt,
+		CreatedAt:     request.CreatedAt,
+		UpdatedAt:     request.UpdatedAt,
+	}
+}
+
+func ToReturnListResponse(requests []*entities.ReturnRequest) *ReturnListResponse {
+	responses := make([]ReturnResponse, 0, len(requests))
+	for _, request := range requests {
+		responses = append(responses, *ToReturnResponse(request))
+	}
+
+	return &ReturnListResponse{
+		Returns: responses,
+		Total:   len(responses),
+	}
+}
@@ -0,0 +1,130 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+type CreateShipmentRequest struct {
+	Carrier string                `json:"carrier" binding:"required"`
+	Items   []ShipmentItemRequest `json:"items" binding:"omitempty,dive"`
+}
+
+type ShipmentItemRequest struct {
+	OrderItemID uuid.UUID `json:"order_item_id" binding:"required"`
+	Quantity    int       `json:"quantity" binding:"required,min=1"`
+}
+
+func (req *CreateShipmentRequest) ToServiceRequest(orderID uuid.UUID) *services.CreateShipmentRequest {
+	items := make([]services.ShipmentItemRequest, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, services.ShipmentItemRequest{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+		})
+	}
+
+	return &services.CreateShipmentRequest{
+		OrderID: orderID,
+		Carrier: req.Carrier,
+		Items:   items,
+	}
+}
+
+type TrackingEventRequest struct {
+	TrackingNumber string    `json:"tracking_number" binding:"required"`
+	Status         string    `json:"status" binding:"required"`
+	Location       string    `json:"location"`
+	Description    string    `json:"description"`
+	OccurredAt     time.Time `json:"occurred_at" binding:"required"`
+}
+
+func (req *TrackingEventRequest) ToServiceRequest(carrier string) *services.TrackingEvent {
+	return &services.TrackingEvent{
+		Carrier:        carrier,
+		TrackingNumber: req.TrackingNumber,
+		Status:         entities.ShipmentStatus(req.Status),
+		Location:       req.Location,
+		Description:    req.Description,
+		OccurredAt:     req.OccurredAt,
+	}
+}
+
+type ShipmentResponse struct {
+	ID             uuid.UUID               `json:"id"`
+	OrderID        uuid.UUID               `json:"order_id"`
+	Carrier        string                  `json:"carrier"`
+	TrackingNumber string                  `json:"tracking_number"`
+	Status         string                  `json:"status"`
+	Items          []ShipmentItemResponse  `json:"items"`
+	Events         []ShipmentEventResponse `json:"events"`
+	DeliveredAt    *time.Time              `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time               `json:"created_at"`
+	UpdatedAt      time.Time               `json:"updated_at"`
+}
+
+type ShipmentItemResponse struct {
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	Quantity    int       `json:"quantity"`
+}
+
+type ShipmentEventResponse struct {
+	Status      string    `json:"status"`
+	Location    string    `json:"location"`
+	Description string    `json:"description"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+type ShipmentListResponse struct {
+	Shipments []ShipmentResponse `json:"shipments"`
+	Total     int                `json:"total"`
+}
+
+func ToShipmentResponse(shipment *entities.Shipment) *ShipmentResponse {
+	items := make([]ShipmentItemResponse, 0, len(shipment.Items))
+	for _, item := range shipment.Items {
+		items = append(items, ShipmentItemResponse{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+		})
+	}
+
+	events := make([]ShipmentEventResponse, 0, len(shipment.Events))
+	for _, event := range shipment.Events {
+		events = append(events, ShipmentEventResponse{
+			Status:      string(event.Status),
+			Location:    event.Location,
+			Description: event.Description,
+			OccurredAt:  event.OccurredAt,
+		})
+	}
+
+	return &ShipmentResponse{
+		ID:             shipment.ID,
+		OrderID:        shipment.OrderID,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		Status:         string(shipment.Status),
+		Items:          items,
+		Events:         events,
+		DeliveredAt:    shipment.DeliveredAt,
+		CreatedAt:      shipment.CreatedAt,
+		UpdatedAt:      shipment.UpdatedAt,
+	}
+}
+
+func ToShipmentListResponse(shipments []*entities.Shipment) *ShipmentListResponse {
+	responses := make([]ShipmentResponse, 0, len(shipments))
+	for _, shipment := range shipments {
+		responses = append(responses, *ToShipmentResponse(shipment))
+	}
+
+	return &ShipmentListResponse{
+		Shipments: responses,
+		Total:     len(responses),
+	}
+}
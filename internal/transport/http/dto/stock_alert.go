@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// StockSubscriptionRequest - без variant_id подписка срабатывает на поступление любого варианта
+type StockSubscriptionRequest struct {
+	UserID    uuid.UUID  `json:"user_id" binding:"required"`
+	VariantID *uuid.UUID `json:"variant_id"`
+}
+
+func (req *StockSubscriptionRequest) ToServiceRequest(productID uuid.UUID) *services.StockSubscriptionRequest {
+	return &services.StockSubscriptionRequest{
+		UserID:    req.UserID,
+		ProductID: productID,
+		VariantID: req.VariantID,
+	}
+}
+
+// LowStockThresholdRequest - threshold = 0 отключает уведомления о низком остатке
+type LowStockThresholdRequest struct {
+	Threshold *int `json:"threshold" binding:"required,min=0"`
+}
+
+type StockSubscriptionResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	ProductID uuid.UUID  `json:"product_id"`
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func ToStockSubscriptionResponse(subscription *entities.StockSubscription) *StockSubscriptionResponse {
+	return &StockSubscriptionResponse{
+		ID:        subscription.ID,
+		UserID:    subscription.UserID,
+		ProductID: subscription.ProductID,
+		VariantID: subscription.VariantID,
+		CreatedAt: subscription.CreatedAt,
+	}
+}
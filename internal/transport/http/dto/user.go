@@ -12,6 +12,8 @@ import (
 type CreateUserRequest struct {
 	FirstName string `json:"first_name" binding:"required,min=1,max=100"`
 	LastName  string `json:"last_name" binding:"required,min=1,max=100"`
+	Email     string `json:"email" binding:"omitempty,email,max=255"`
+	Language  string `json:"language" binding:"omitempty,oneof=ru en"`
 	Age       int    `json:"age" binding:"required,min=18"`
 	IsMarried *bool  `json:"is_married"`
 	Password  string `json:"password" binding:"required,min=8"`
@@ -26,6 +28,8 @@ func (req *CreateUserRequest) ToServiceRequest() *services.CreateUserRequest {
 	return &services.CreateUserRequest{
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
+		Email:     req.Email,
+		Language:  req.Language,
 		Age:       req.Age,
 		IsMarried: isMarried,
 		Password:  req.Password,
@@ -37,6 +41,8 @@ type UserResponse struct {
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
 	FullName  string    `json:"full_name"`
+	Email     string    `json:"email,omitempty"`
+	Language  string    `json:"language"`
 	Age       int       `json:"age"`
 	IsMarried bool      `json:"is_married"`
 	CreatedAt time.Time `json:"created_at"`
@@ -49,6 +55,8 @@ func ToUserResponse(user *entities.User) *UserResponse {
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		FullName:  user.GetFullName(),
+		Email:     user.Email,
+		Language:  user.Language,
 		Age:       user.Age,
 		IsMarried: user.IsMarried,
 		CreatedAt: user.CreatedAt,
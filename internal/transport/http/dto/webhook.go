@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscriptionRequest - при обновлении пустой secret оставляет прежний,
+// без active подписка считается включенной
+type WebhookSubscriptionRequest struct {
+	URL    string                `json:"url" binding:"required,max=2048"`
+	Events []entities.OrderEvent `json:"events" binding:"required,min=1"`
+	Secret string                `json:"secret" binding:"max=255"`
+	Active *bool                 `json:"active"`
+}
+
+func (req *WebhookSubscriptionRequest) ToServiceRequest() *services.WebhookSubscriptionRequest {
+	return &services.WebhookSubscriptionRequest{
+		URL:    req.URL,
+		Events: req.Events,
+		Secret: req.Secret,
+		Active: req.Active,
+	}
+}
+
+// WebhookSubscriptionResponse - секрет подписки наружу не отдается
+type WebhookSubscriptionResponse struct {
+	ID        uuid.UUID             `json:"id"`
+	URL       string                `json:"url"`
+	Events    []entities.OrderEvent `json:"events"`
+	Active    bool                  `json:"active"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+func ToWebhookSubscriptionResponse(subscription *entities.WebhookSubscription) *WebhookSubscriptionResponse {
+	return &WebhookSubscriptionResponse{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Events:    subscription.Events,
+		Active:    subscription.Active,
+		CreatedAt: subscription.CreatedAt,
+		UpdatedAt: subscription.UpdatedAt,
+	}
+}
+
+func ToWebhookSubscriptionResponses(subscriptions []*entities.WebhookSubscription) []*WebhookSubscriptionResponse {
+	result := make([]*WebhookSubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		result[i] = ToWebhookSubscriptionResponse(subscription)
+	}
+
+	return result
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	SubscriptionID uuid.UUID       `json:"subscription_id"`
+	Event          string          `json:"event"`
+	OrderID        uuid.UUID       `json:"order_id"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	ReplayOf       *uuid.UUID      `json:"replay_of,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+func ToWebhookDeliveryResponse(delivery *entities.WebhookDelivery) *WebhookDeliveryResponse {
+	response := &WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		Event:          string(delivery.Event),
+		OrderID:        delivery.OrderID,
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		ReplayOf:       delivery.ReplayOf,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+	// Время следующей попытки имеет смысл только для доставок, которые еще будут отправляться
+	if delivery.Status == entities.WebhookDeliveryStatusPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	return response
+}
+
+func ToWebhookDeliveryResponses(deliveries []*entities.WebhookDelivery) []*WebhookDeliveryResponse {
+	result := make([]*WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		result[i] = ToWebhookDeliveryResponse(delivery)
+	}
+
+	return result
+}
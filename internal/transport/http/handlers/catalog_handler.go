@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/infrastructure/catalog"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Ограничение на размер файла импорта
+	maxImportSize = 32 << 20
+
+	// Как часто сбрасывать буфер клиенту при выгрузке
+	exportFlushEvery = 100
+)
+
+type CatalogHandler struct {
+	catalogService services.CatalogService
+}
+
+func NewCatalogHandler(catalogService services.CatalogService) *CatalogHandler {
+	return &CatalogHandler{
+		catalogService: catalogService,
+	}
+}
+
+// ImportProducts принимает файл каталога в теле запроса. Формат задается параметром format
+// (csv, ndjson) или заголовком Content-Type. Ответ содержит построчный отчет.
+func (h *CatalogHandler) ImportProducts(c *gin.Context) {
+	format, err := importFormat(c)
+	if err != nil {
+		middleware.HandleError(c, http.StatusUnsupportedMediaType, err, middleware.CodeUnsupportedMediaType)
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	rows, err := catalog.Decode(body, format)
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	report, err := h.catalogService.ImportProducts(c.Request.Context(), rows)
+	if err != nil {
+		middleware.HandleInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportProducts выгружает весь каталог потоком, по умолчанию в CSV
+func (h *CatalogHandler) ExportProducts(c *gin.Context) {
+	format, err := catalog.ParseFormat(c.DefaultQuery("format", string(catalog.FormatCSV)))
+	if err != nil {
+		middleware.HandleError(c, http.StatusNotAcceptable, err, middleware.CodeNotAcceptable)
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "products."+string(format)))
+	c.Status(http.StatusOK)
+
+	encoder := catalog.NewEncoder(c.Writer, format)
+	written := 0
+
+	err = h.catalogService.ExportProducts(c.Request.Context(), func(product *entities.Product) error {
+		if err := encoder.Encode(product); err != nil {
+			return err
+		}
+
+		written++
+		if written%exportFlushEvery == 0 {
+			if err := encoder.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = encoder.Flush()
+	}
+
+	// Заголовки уже отправлены, поэтому ошибку можно только залогировать
+	if err != nil {
+		_ = c.Error(err)
+	}
+}
+
+func importFormat(c *gin.Context) (catalog.Format, error) {
+	if value := c.Query("format"); value != "" {
+		return catalog.ParseFormat(value)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(c.ContentType())
+	if err != nil {
+		return catalog.ParseFormat("")
+	}
+
+	switch mediaType {
+	case "text/csv":
+		return catalog.FormatCSV, nil
+	case "application/x-ndjson", "application/jsonl":
+		return catalog.FormatNDJSON, nil
+	}
+
+	return catalog.ParseFormat("")
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryHandler struct {
+	categoryService services.CategoryService
+}
+
+func NewCategoryHandler(categoryService services.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+	}
+}
+
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req dto.CreateCategoryRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(c.Request.Context(), req.ToServiceRequest())
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrCategoryNotFound):
+			middleware.HandleNotFoundError(c, err)
+		case errors.Is(err, domainErrors.ErrCategorySlugTaken):
+			middleware.HandleError(c, http.StatusConflict, err, middleware.CodeConflict)
+		default:
+			middleware.HandleValidationError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToCategoryResponse(category))
+}
+
+// GetCategories возвращает дерево категорий
+func (h *CategoryHandler) GetCategories(c *gin.Context) {
+	tree, err := h.categoryService.GetCategoryTree(c.Request.Context())
+	if err != nil {
+		middleware.HandleInternalError(c, err)
+		return
+	}
+
+	responses := make([]dto.CategoryResponse, 0, len(tree))
+	for _, category := range tree {
+		responses = append(responses, *dto.ToCategoryResponse(category))
+	}
+
+	c.JSON(http.StatusOK, dto.CategoryListResponse{
+		Categories: responses,
+	})
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type EmailHandler struct {
+	emailService services.EmailService
+}
+
+func NewEmailHandler(emailService services.EmailService) *EmailHandler {
+	return &EmailHandler{
+		emailService: emailService,
+	}
+}
+
+// GetOrderEmails возвращает журнал доставки писем покупателю по заказу
+func (h *EmailHandler) GetOrderEmails(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, domainErrors.ErrInvalidOrderID)
+		return
+	}
+
+	deliveries, err := h.emailService.GetOrderEmails(c.Request.Context(), orderID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrOrderNotFound) {
+			middleware.HandleNotFoundError(c, err)
+			return
+		}
+		middleware.HandleInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToEmailDeliveryResponses(deliveries))
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/health"
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	serviceName = "orders"
+
+	healthStatusOK          = "ok"
+	healthStatusUnavailable = "unavailable"
+)
+
+var errDetailedReportDisabled = errors.New("detailed health report is disabled")
+
+type HealthHandler struct {
+	registry *health.Registry
+	// detailedReport разрешает отдавать результаты проверок с текстами ошибок: в них бывают адреса и имена хостов
+	detailedReport bool
+}
+
+func NewHealthHandler(registry *health.Registry, detailedReport bool) *HealthHandler {
+	return &HealthHandler{
+		registry:       registry,
+		detailedReport: detailedReport,
+	}
+}
+
+// Live отвечает, пока процесс обслуживает HTTP. Зависимости не проверяются:
+// недоступная БД - повод вывести экземпляр из балансировки, а не перезапускать его.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.HealthResponse{Status: healthStatusOK, Service: serviceName})
+}
+
+// Ready выполняет зарегистрированные проверки и отвечает 503, если хотя бы одна не прошла
+func (h *HealthHandler) Ready(c *gin.Context) {
+	verbose := false
+	if value, ok := c.GetQuery("verbose"); ok {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			middleware.HandleValidationError(c, err)
+			return
+		}
+		verbose = parsed
+	}
+
+	if verbose && !h.detailedReport {
+		middleware.HandleError(c, http.StatusForbidden, errDetailedReportDisabled, middleware.CodeForbidden)
+		return
+	}
+
+	report := h.registry.Check(c.Request.Context())
+
+	status := http.StatusOK
+	response := dto.HealthResponse{Status: healthStatusOK, Service: serviceName}
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+		response.Status = healthStatusUnavailable
+	}
+
+	if verbose {
+		response.Checks = make([]dto.HealthCheckResponse, len(report.Checks))
+		for i, result := range report.Checks {
+			response.Checks[i] = dto.HealthCheckResponse{
+				Name:       result.Name,
+				Optional:   result.Optional,
+				Status:     string(result.Status),
+				Error:      result.Error,
+				DurationMs: result.Duration.Milliseconds(),
+				CheckedAt:  result.CheckedAt,
+			}
+		}
+	}
+
+	c.JSON(status, response)
+}
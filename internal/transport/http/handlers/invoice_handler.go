@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const mimePDF = "application/pdf"
+
+type InvoiceHandler struct {
+	invoiceService services.InvoiceService
+}
+
+func NewInvoiceHandler(invoiceService services.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceService: invoiceService,
+	}
+}
+
+// GetOrderInvoice отдает счет заказа. Формат выбирается параметром format (json, xml, pdf)
+// или заголовком Accept, по умолчанию - JSON.
+func (h *InvoiceHandler) GetOrderInvoice(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, domainErrors.ErrInvalidOrderID)
+		return
+	}
+
+	format, err := invoiceFormat(c)
+	if err != nil {
+		middleware.HandleError(c, http.StatusNotAcceptable, err, middleware.CodeNotAcceptable)
+		return
+	}
+
+	invoice, err := h.invoiceService.GetOrIssueInvoice(c.Request.Context(), orderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrOrderNotFound):
+			middleware.HandleNotFoundError(c, err)
+		case errors.Is(err, domainErrors.ErrOnlyCompletedCanInvoice):
+			middleware.HandleError(c, http.StatusConflict, err, middleware.CodeConflict)
+		default:
+			middleware.HandleInternalError(c, err)
+		}
+		return
+	}
+
+	switch format {
+	case gin.MIMEXML:
+		c.XML(http.StatusOK, dto.ToInvoiceResponse(invoice))
+	case mimePDF:
+		pdf, err := h.invoiceService.GetInvoicePDF(c.Request.Context(), invoice)
+		if err != nil {
+			middleware.HandleInternalError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", invoice.Number+".pdf"))
+		c.Data(http.StatusOK, mimePDF, pdf)
+	default:
+		c.JSON(http.StatusOK, dto.ToInvoiceResponse(invoice))
+	}
+}
+
+func invoiceFormat(c *gin.Context) (string, error) {
+	switch c.Query("format") {
+	case "json":
+		return gin.MIMEJSON, nil
+	case "xml":
+		return gin.MIMEXML, nil
+	case "pdf":
+		return mimePDF, nil
+	case "":
+	default:
+		return "", domainErrors.ErrInvoiceFormatInvalid
+	}
+
+	if c.GetHeader("Accept") == "" {
+		return gin.MIMEJSON, nil
+	}
+
+	format := c.NegotiateFormat(gin.MIMEJSON, gin.MIMEXML, mimePDF)
+	if format == "" {
+		return "", domainErrors.ErrInvoiceFormatInvalid
+	}
+
+	return format, nil
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -33,7 +34,16 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 
 	order, err := h.orderService.CreateOrder(c.Request.Context(), req.ToServiceRequest())
 	if err != nil {
-		middleware.HandleValidationError(c, err)
+		switch {
+		case errors.Is(err, domainErrors.ErrOrderVelocityLimit):
+			middleware.HandleError(c, http.StatusTooManyRequests, err, middleware.CodeRateLimitExceeded)
+		case errors.Is(err, domainErrors.ErrOrderProductQuantityLimit),
+			errors.Is(err, domainErrors.ErrUserProductQuantityLimit),
+			errors.Is(err, domainErrors.ErrOrderTotalLimit):
+			middleware.HandleError(c, http.StatusUnprocessableEntity, err, middleware.CodeOrderLimitExceeded)
+		default:
+			middleware.HandleValidationError(c, err)
+		}
 		return
 	}
 
@@ -123,3 +133,25 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 
 	c.JSON(http.StatusOK, dto.ToOrderResponse(order))
 }
+
+// ReleaseOrder возвращает в работу заказ, отложенный антифродом; отклонение - через CancelOrder
+func (h *OrderHandler) ReleaseOrder(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, domainErrors.ErrInvalidOrderID)
+		return
+	}
+
+	if err := h.orderService.ReleaseOrder(c.Request.Context(), orderID); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	order, err := h.orderService.GetOrderByID(c.Request.Context(), orderID)
+	if err != nil {
+		middleware.HandleInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToOrderResponse(order))
+}
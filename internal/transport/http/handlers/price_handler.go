@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PriceHandler struct {
+	priceService services.PriceService
+}
+
+func NewPriceHandler(priceService services.PriceService) *PriceHandler {
+	return &PriceHandler{
+		priceService: priceService,
+	}
+}
+
+// SchedulePrice меняет цену товара сразу или планирует ее на будущее
+func (h *PriceHandler) SchedulePrice(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	var req dto.SchedulePriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	change, err := h.priceService.SchedulePrice(c.Request.Context(), productID, req.ToServiceRequest())
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrProductNotFound):
+			middleware.HandleNotFoundError(c, err)
+		case errors.Is(err, domainErrors.ErrPriceAlreadyScheduled):
+			middleware.HandleError(c, http.StatusConflict, err, middleware.CodeConflict)
+		case errors.Is(err, domainErrors.ErrPriceStartInPast),
+			errors.Is(err, domainErrors.ErrProductPricedByVariants),
+			errors.Is(err, domainErrors.ErrProductPriceInvalid):
+			middleware.HandleValidationError(c, err)
+		default:
+			middleware.HandleInternalError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToPriceChangeResponse(change, time.Now()))
+}
+
+// GetPriceHistory возвращает историю цен товара вместе с запланированными ценами
+func (h *PriceHandler) GetPriceHistory(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	history, err := h.priceService.GetPriceHistory(c.Request.Context(), productID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrProductNotFound) {
+			middleware.HandleNotFoundError(c, err)
+			return
+		}
+		middleware.HandleInternalError(c, err)
+		return
+	}
+
+	now := time.Now()
+	responses := make([]*dto.PriceChangeResponse, len(history))
+	for i, change := range history {
+		responses[i] = dto.ToPriceChangeResponse(change, now)
+	}
+
+	c.JSON(http.StatusOK, dto.PriceHistoryResponse{
+		Prices: responses,
+	})
+}
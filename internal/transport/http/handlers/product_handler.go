@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
 	"github.com/AndrivA89/orders/internal/domain/services"
 	"github.com/AndrivA89/orders/internal/transport/http/dto"
 	"github.com/AndrivA89/orders/internal/transport/http/middleware"
@@ -46,8 +49,21 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 	limit, _ := strconv.Atoi(limitStr)
 	offset, _ := strconv.Atoi(offsetStr)
 
-	products, err := h.productService.GetProducts(c.Request.Context(), limit, offset)
+	var (
+		products []*entities.Product
+		err      error
+	)
+	// Фильтр по категории включает товары всех подкатегорий
+	if category := c.Query("category"); category != "" {
+		products, err = h.productService.GetProductsByCategory(c.Request.Context(), category, limit, offset)
+	} else {
+		products, err = h.productService.GetProducts(c.Request.Context(), limit, offset)
+	}
 	if err != nil {
+		if errors.Is(err, domainErrors.ErrCategoryNotFound) {
+			middleware.HandleNotFoundError(c, err)
+			return
+		}
 		middleware.HandleInternalError(c, err)
 		return
 	}
@@ -57,11 +73,11 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 		responses[i] = dto.ToProductResponse(product)
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"products": responses,
-		"total":    len(products),
-		"limit":    limit,
-		"offset":   offset,
+	c.JSON(http.StatusOK, dto.ProductListResponse{
+		Products: responses,
+		Total:    len(products),
+		Limit:    limit,
+		Offset:   offset,
 	})
 }
 
@@ -81,3 +97,87 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 
 	c.JSON(http.StatusOK, dto.ToProductResponse(product))
 }
+
+func (h *ProductHandler) AddVariant(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	var req dto.CreateVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	variant, err := h.productService.AddVariant(c.Request.Context(), productID, req.ToServiceRequest())
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrProductNotFound) {
+			middleware.HandleNotFoundError(c, err)
+			return
+		}
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToProductVariantResponse(variant))
+}
+
+// ReceiveStock принимает поступление товара; единицы сначала уходят заказам, ожидающим поступления
+func (h *ProductHandler) ReceiveStock(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	var req dto.ReceiveStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	receipt, err := h.productService.ReceiveStock(c.Request.Context(), productID, req.ToServiceRequest())
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrProductNotFound), errors.Is(err, domainErrors.ErrVariantNotFound):
+			middleware.HandleNotFoundError(c, err)
+		case errors.Is(err, domainErrors.ErrVariantRequired), errors.Is(err, domainErrors.ErrQuantityInvalid):
+			middleware.HandleValidationError(c, err)
+		default:
+			middleware.HandleInternalError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
+
+// SetProductCategories заменяет список категорий товара
+func (h *ProductHandler) SetProductCategories(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	var req dto.SetProductCategoriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	product, err := h.productService.SetProductCategories(c.Request.Context(), productID, req.CategoryIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrProductNotFound), errors.Is(err, domainErrors.ErrCategoryNotFound):
+			middleware.HandleNotFoundError(c, err)
+		default:
+			middleware.HandleInternalError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToProductResponse(product))
+}
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	mimeCSV = "text/csv"
+
+	// По умолчанию отчеты строятся за последние 30 дней
+	defaultReportRange = 30 * 24 * time.Hour
+
+	defaultLowStockThreshold = 5
+)
+
+type ReportHandler struct {
+	reportService services.ReportService
+}
+
+func NewReportHandler(reportService services.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+func (h *ReportHandler) GetSalesSummary(c *gin.Context) {
+	req, format, ok := h.parseSalesRequest(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.reportService.GetSalesSummary(c.Request.Context(), req)
+	if err != nil {
+		handleReportError(c, err)
+		return
+	}
+
+	response := dto.ToSalesSummaryResponse(summary, req.From, req.To)
+	if format == mimeCSV {
+		writeCSV(c, "sales-summary.csv", dto.SalesSummaryCSV(response))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ReportHandler) GetSalesByPeriod(c *gin.Context) {
+	req, format, ok := h.parseSalesRequest(c)
+	if !ok {
+		return
+	}
+	req.GroupBy = entities.ReportPeriod(c.DefaultQuery("group_by", string(entities.ReportPeriodDay)))
+
+	points, err := h.reportService.GetSalesByPeriod(c.Request.Context(), req)
+	if err != nil {
+		handleReportError(c, err)
+		return
+	}
+
+	if format == mimeCSV {
+		writeCSV(c, "sales.csv", dto.SalesByPeriodCSV(points))
+		return
+	}
+
+	c.JSON(http.StatusOK, points)
+}
+
+func (h *ReportHandler) GetTopProducts(c *gin.Context) {
+	req, format, ok := h.parseSalesRequest(c)
+	if !ok {
+		return
+	}
+
+	products, err := h.reportService.GetTopProducts(c.Request.Context(), req)
+	if err != nil {
+		handleReportError(c, err)
+		return
+	}
+
+	if format == mimeCSV {
+		writeCSV(c, "top-products.csv", dto.ProductSalesCSV(products))
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
+func (h *ReportHandler) GetSalesByTag(c *gin.Context) {
+	req, format, ok := h.parseSalesRequest(c)
+	if !ok {
+		return
+	}
+
+	tags, err := h.reportService.GetSalesByTag(c.Request.Context(), req)
+	if err != nil {
+		handleReportError(c, err)
+		return
+	}
+
+	if format == mimeCSV {
+		writeCSV(c, "sales-by-tag.csv", dto.TagSalesCSV(tags))
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+func (h *ReportHandler) GetSalesByCategory(c *gin.Context) {
+	req, format, ok := h.parseSalesRequest(c)
+	if !ok {
+		return
+	}
+
+	categories, err := h.reportService.GetSalesByCategory(c.Request.Context(), req)
+	if err != nil {
+		handleReportError(c, err)
+		return
+	}
+
+	if format == mimeCSV {
+		writeCSV(c, "sales-by-category.csv", dto.CategorySalesCSV(categories))
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+func (h *ReportHandler) GetLowStock(c *gin.Context) {
+	format, err := reportFormat(c)
+	if err != nil {
+		middleware.HandleError(c, http.StatusNotAcceptable, err, middleware.CodeNotAcceptable)
+		return
+	}
+
+	threshold, err := strconv.Atoi(c.DefaultQuery("threshold", strconv.Itoa(defaultLowStockThreshold)))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	products, err := h.reportService.GetLowStockProducts(c.Request.Context(), threshold, limit)
+	if err != nil {
+		handleReportError(c, err)
+		return
+	}
+
+	if format == mimeCSV {
+		writeCSV(c, "low-stock.csv", dto.LowStockCSV(products))
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
+// parseSalesRequest разбирает общие параметры отчетов: from, to, limit и format.
+// При ошибке ответ уже записан и возвращается ok = false.
+func (h *ReportHandler) parseSalesRequest(c *gin.Context) (*services.SalesReportRequest, string, bool) {
+	format, err := reportFormat(c)
+	if err != nil {
+		middleware.HandleError(c, http.StatusNotAcceptable, err, middleware.CodeNotAcceptable)
+		return nil, "", false
+	}
+
+	to := time.Now().UTC()
+	if value := c.Query("to"); value != "" {
+		if to, err = parseReportDate(value); err != nil {
+			middleware.HandleValidationError(c, err)
+			return nil, "", false
+		}
+	}
+
+	from := to.Add(-defaultReportRange)
+	if value := c.Query("from"); value != "" {
+		if from, err = parseReportDate(value); err != nil {
+			middleware.HandleValidationError(c, err)
+			return nil, "", false
+		}
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return nil, "", false
+	}
+
+	return &services.SalesReportRequest{From: from, To: to, Limit: limit}, format, true
+}
+
+// parseReportDate принимает дату (YYYY-MM-DD, полночь UTC) или полную метку времени RFC3339
+func parseReportDate(value string) (time.Time, error) {
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, nil
+	}
+	return time.Time{}, domainErrors.ErrReportDateInvalid
+}
+
+func reportFormat(c *gin.Context) (string, error) {
+	switch c.Query("format") {
+	case "json":
+		return gin.MIMEJSON, nil
+	case "csv":
+		return mimeCSV, nil
+	case "":
+	default:
+		return "", domainErrors.ErrReportFormatInvalid
+	}
+
+	if c.GetHeader("Accept") == "" {
+		return gin.MIMEJSON, nil
+	}
+
+	format := c.NegotiateFormat(gin.MIMEJSON, mimeCSV)
+	if format == "" {
+		return "", domainErrors.ErrReportFormatInvalid
+	}
+
+	return format, nil
+}
+
+func writeCSV(c *gin.Context, filename string, records [][]string) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Content-Type", mimeCSV+"; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	if err := writer.WriteAll(records); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+func handleReportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domainErrors.ErrReportRangeInvalid),
+		errors.Is(err, domainErrors.ErrReportRangeTooLarge),
+		errors.Is(err, domainErrors.ErrReportPeriodInvalid),
+		errors.Is(err, domainErrors.ErrProductQuantityNegative):
+		middleware.HandleValidationError(c, err)
+	default:
+		middleware.HandleInternalError(c, err)
+	}
+}
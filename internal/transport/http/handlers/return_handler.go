@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReturnHandler struct {
+	returnService services.ReturnService
+}
+
+func NewReturnHandler(returnService services.ReturnService) *ReturnHandler {
+	return &ReturnHandler{
+		returnService: returnService,
+	}
+}
+
+func (h *ReturnHandler) OpenReturn(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, domainErrors.ErrInvalidOrderID)
+		return
+	}
+
+	var req dto.OpenReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	request, err := h.returnService.OpenReturn(c.Request.Context(), req.ToServiceRequest(orderID))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToReturnResponse(request))
+}
+
+func (h *ReturnHandler) GetOrderReturns(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, domainErrors.ErrInvalidOrderID)
+		return
+	}
+
+	requests, err := h.returnService.GetReturnsByOrderID(c.Request.Context(), orderID)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReturnListResponse(requests))
+}
+
+func (h *ReturnHandler) GetReturn(c *gin.Context) {
+	returnID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, domainErrors.ErrInvalidReturnID)
+		return
+	}
+
+	request, err := h.returnService.GetReturnByID(c.Request.Context(), returnID)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReturnResponse(request))
+}
+
+func (h *ReturnHandler) ApproveReturn(c *gin.Context) {
+	h.review(c, h.returnService.ApproveReturn)
+}
+
+func (h *ReturnHandler) RejectReturn(c *gin.Context) {
+	h.review(c, h.returnService.RejectReturn)
+}
+
+func (h *ReturnHandler) ReceiveReturn(c *gin.Context) {
+	returnID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, domainErrors.ErrInvalidReturnID)
+		return
+	}
+
+	request, err := h.returnService.ReceiveReturn(c.Request.Context(), returnID)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReturnResponse(request))
+}
+
+type reviewFunc func(ctx context.Context, id uuid.UUID, comment string) (*entities.ReturnRequest, error)
+
+func (h *ReturnHandler) review(c *gin.Context, action reviewFunc) {
+	returnID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, domainErrors.ErrInvalidReturnID)
+		return
+	}
+
+	var req dto.ReviewReturnRequest
+	// Тело запроса необязательно - комментарий может отсутствовать
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.HandleValidationError(c, err)
+			return
+		}
+	}
+
+	request, err := action(c.Request.Context(), returnID, req.Comment)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReturnResponse(request))
+}
+
+func (h *ReturnHandler) handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domainErrors.ErrReturnNotFound), errors.Is(err, domainErrors.ErrOrderNotFound):
+		middleware.HandleNotFoundError(c, err)
+	case errors.Is(err, domainErrors.ErrReturnNotOwner):
+		middleware.HandleError(c, http.StatusForbidden, err, middleware.CodeForbidden)
+	default:
+		middleware.HandleValidationError(c, err)
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const carrierTokenHeader = "X-Carrier-Token"
+
+type ShipmentHandler struct {
+	shipmentService services.ShipmentService
+	webhookToken    string
+	// allowUnauthenticated пропускает вебхуки без токена; без этого флага пустой токен закрывает вебхук
+	allowUnauthenticated bool
+}
+
+func NewShipmentHandler(shipmentService services.ShipmentService, webhookToken string, allowUnauthenticated bool) *ShipmentHandler {
+	return &ShipmentHandler{
+		shipmentService:      shipmentService,
+		webhookToken:         webhookToken,
+		allowUnauthenticated: allowUnauthenticated,
+	}
+}
+
+func (h *ShipmentHandler) CreateShipment(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, domainErrors.ErrInvalidOrderID)
+		return
+	}
+
+	var req dto.CreateShipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	shipment, err := h.shipmentService.CreateShipment(c.Request.Context(), req.ToServiceRequest(orderID))
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrOrderNotFound) {
+			middleware.HandleNotFoundError(c, err)
+			return
+		}
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToShipmentResponse(shipment))
+}
+
+func (h *ShipmentHandler) GetOrderShipments(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, domainErrors.ErrInvalidOrderID)
+		return
+	}
+
+	shipments, err := h.shipmentService.GetShipmentsByOrderID(c.Request.Context(), orderID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrOrderNotFound) {
+			middleware.HandleNotFoundError(c, err)
+			return
+		}
+		middleware.HandleInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToShipmentListResponse(shipments))
+}
+
+// TrackingWebhook принимает события трекинга от перевозчика
+func (h *ShipmentHandler) TrackingWebhook(c *gin.Context) {
+	if !h.webhookAuthorized(c.GetHeader(carrierTokenHeader)) {
+		middleware.HandleError(c, http.StatusUnauthorized, domainErrors.ErrCarrierWebhookUnauthorized, middleware.CodeUnauthorized)
+		return
+	}
+
+	var req dto.TrackingEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	shipment, err := h.shipmentService.ProcessTrackingEvent(c.Request.Context(), req.ToServiceRequest(c.Param("carrier")))
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrShipmentNotFound):
+			middleware.HandleNotFoundError(c, err)
+		case errors.Is(err, domainErrors.ErrShipmentStatusInvalid), errors.Is(err, domainErrors.ErrShipmentAlreadyDelivered):
+			middleware.HandleValidationError(c, err)
+		default:
+			// сбой на нашей стороне: перевозчик должен повторить событие, а не отбросить его как некорректное
+			middleware.HandleInternalError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToShipmentResponse(shipment))
+}
+
+// webhookAuthorized сравнивает токен за постоянное время; пустой токен в конфигурации
+// пропускает запросы, только если это явно разрешено
+func (h *ShipmentHandler) webhookAuthorized(token string) bool {
+	if h.webhookToken == "" {
+		return h.allowUnauthenticated
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.webhookToken)) == 1
+}
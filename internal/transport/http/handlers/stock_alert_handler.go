@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StockAlertHandler struct {
+	stockAlertService services.StockAlertService
+}
+
+func NewStockAlertHandler(stockAlertService services.StockAlertService) *StockAlertHandler {
+	return &StockAlertHandler{
+		stockAlertService: stockAlertService,
+	}
+}
+
+// Subscribe подписывает пользователя на поступление товара, которого нет в наличии
+func (h *StockAlertHandler) Subscribe(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	var req dto.StockSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	subscription, err := h.stockAlertService.Subscribe(c.Request.Context(), req.ToServiceRequest(productID))
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrProductNotFound),
+			errors.Is(err, domainErrors.ErrVariantNotFound),
+			errors.Is(err, domainErrors.ErrUserNotFound):
+			middleware.HandleNotFoundError(c, err)
+		case errors.Is(err, domainErrors.ErrProductInStock):
+			middleware.HandleError(c, http.StatusConflict, err, middleware.CodeConflict)
+		default:
+			middleware.HandleInternalError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToStockSubscriptionResponse(subscription))
+}
+
+func (h *StockAlertHandler) Unsubscribe(c *gin.Context) {
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	if err := h.stockAlertService.Unsubscribe(c.Request.Context(), subscriptionID); err != nil {
+		if errors.Is(err, domainErrors.ErrStockSubscriptionNotFound) {
+			middleware.HandleNotFoundError(c, err)
+			return
+		}
+		middleware.HandleInternalError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetLowStockThreshold задает порог, при достижении которого сотрудники получают уведомление
+func (h *StockAlertHandler) SetLowStockThreshold(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	var req dto.LowStockThresholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	product, err := h.stockAlertService.SetLowStockThreshold(c.Request.Context(), productID, *req.Threshold)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrProductNotFound):
+			middleware.HandleNotFoundError(c, err)
+		case errors.Is(err, domainErrors.ErrLowStockThresholdInvalid):
+			middleware.HandleValidationError(c, err)
+		default:
+			middleware.HandleInternalError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToProductResponse(product))
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	"github.com/AndrivA89/orders/internal/domain/services"
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WebhookHandler struct {
+	webhookService services.WebhookService
+}
+
+func NewWebhookHandler(webhookService services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	var req dto.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(c.Request.Context(), req.ToServiceRequest())
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToWebhookSubscriptionResponse(subscription))
+}
+
+func (h *WebhookHandler) GetSubscriptions(c *gin.Context) {
+	subscriptions, err := h.webhookService.GetSubscriptions(c.Request.Context())
+	if err != nil {
+		middleware.HandleInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToWebhookSubscriptionResponses(subscriptions))
+}
+
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	subscription, err := h.webhookService.GetSubscription(c.Request.Context(), subscriptionID)
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToWebhookSubscriptionResponse(subscription))
+}
+
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	var req dto.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	subscription, err := h.webhookService.UpdateSubscription(c.Request.Context(), subscriptionID, req.ToServiceRequest())
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToWebhookSubscriptionResponse(subscription))
+}
+
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), subscriptionID); err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetDeliveries возвращает журнал доставок подписки, новые записи первыми
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 {
+		middleware.HandleValidationError(c, errors.New("limit must be a positive integer"))
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		middleware.HandleValidationError(c, errors.New("offset must be a non-negative integer"))
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(c.Request.Context(), subscriptionID, &services.WebhookDeliveryFilter{
+		Status: entities.WebhookDeliveryStatus(c.Query("status")),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToWebhookDeliveryResponses(deliveries))
+}
+
+// ReplayDelivery ставит в очередь повторную отправку доставки, в том числе ушедшей в dead
+func (h *WebhookHandler) ReplayDelivery(c *gin.Context) {
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		middleware.HandleValidationError(c, err)
+		return
+	}
+
+	delivery, err := h.webhookService.ReplayDelivery(c.Request.Context(), subscriptionID, deliveryID)
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.ToWebhookDeliveryResponse(delivery))
+}
+
+func handleWebhookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domainErrors.ErrWebhookSubscriptionNotFound),
+		errors.Is(err, domainErrors.ErrWebhookDeliveryNotFound):
+		middleware.HandleNotFoundError(c, err)
+	case errors.Is(err, domainErrors.ErrWebhookURLInvalid),
+		errors.Is(err, domainErrors.ErrWebhookEventsRequired),
+		errors.Is(err, domainErrors.ErrWebhookEventUnknown),
+		errors.Is(err, domainErrors.ErrWebhookSecretTooShort),
+		errors.Is(err, domainErrors.ErrWebhookDeliveryStatusInvalid):
+		middleware.HandleValidationError(c, err)
+	default:
+		middleware.HandleInternalError(c, err)
+	}
+}
@@ -7,10 +7,47 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// Коды ошибок в поле code ответа: по ним клиент различает ошибки без разбора текста
+const (
+	CodeValidationError      = "VALIDATION_ERROR"
+	CodeNotFound             = "NOT_FOUND"
+	CodeConflict             = "CONFLICT"
+	CodeForbidden            = "FORBIDDEN"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeNotAcceptable        = "NOT_ACCEPTABLE"
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	CodeRateLimitExceeded    = "RATE_LIMIT_EXCEEDED"
+	CodeOrderLimitExceeded   = "ORDER_LIMIT_EXCEEDED"
+	CodeInternalError        = "INTERNAL_ERROR"
+)
+
+// ErrorCodes перечисляет все коды ошибок REST API, спецификация OpenAPI берет их отсюда
+var ErrorCodes = []string{
+	CodeValidationError,
+	CodeNotFound,
+	CodeConflict,
+	CodeForbidden,
+	CodeUnauthorized,
+	CodeNotAcceptable,
+	CodeUnsupportedMediaType,
+	CodeRateLimitExceeded,
+	CodeOrderLimitExceeded,
+	CodeInternalError,
+}
+
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-	Code    string `json:"code,omitempty"`
+	Error   string       `json:"error"`
+	Message string       `json:"message,omitempty"`
+	Code    string       `json:"code,omitempty"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError - ошибка одного поля запроса. Для тела Field - JSON pointer (/items/0/quantity),
+// для параметров пути, запроса и заголовков - имя параметра.
+type FieldError struct {
+	In      string `json:"in"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 func ErrorHandler(logger *logrus.Logger) gin.HandlerFunc {
@@ -26,7 +63,7 @@ func ErrorHandler(logger *logrus.Logger) gin.HandlerFunc {
 				c.JSON(http.StatusInternalServerError, ErrorResponse{
 					Error:   "Internal Server Error",
 					Message: "An unexpected error occurred",
-					Code:    "INTERNAL_ERROR",
+					Code:    CodeInternalError,
 				})
 
 				c.Abort()
@@ -59,13 +96,13 @@ func HandleError(c *gin.Context, statusCode int, err error, code string) {
 }
 
 func HandleValidationError(c *gin.Context, err error) {
-	HandleError(c, http.StatusBadRequest, err, "VALIDATION_ERROR")
+	HandleError(c, http.StatusBadRequest, err, CodeValidationError)
 }
 
 func HandleNotFoundError(c *gin.Context, err error) {
-	HandleError(c, http.StatusNotFound, err, "NOT_FOUND")
+	HandleError(c, http.StatusNotFound, err, CodeNotFound)
 }
 
 func HandleInternalError(c *gin.Context, err error) {
-	HandleError(c, http.StatusInternalServerError, err, "INTERNAL_ERROR")
+	HandleError(c, http.StatusInternalServerError, err, CodeInternalError)
 }
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics записывает время обработки запроса по шаблону маршрута и статусу ответа.
+// Подключается первым, чтобы учесть и ответы других middleware: отказ валидации, 429, восстановленную панику.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		started := time.Now()
+		c.Next()
+		m.ObserveHTTPRequest(c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(started))
+	}
+}
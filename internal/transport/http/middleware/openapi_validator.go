@@ -0,0 +1,274 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const contractViolationMessage = "request does not match the API contract"
+
+func init() {
+	// формат uuid в kin-openapi по умолчанию не проверяется; правила совпадают с разбором в обработчиках
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewCallbackValidator(func(value string) error {
+		_, err := uuid.Parse(value)
+		return err
+	}))
+}
+
+// OpenAPIValidator проверяет запросы по спецификации OpenAPI до вызова обработчика и отвечает 400
+// с ошибками по полям. Проверяются параметры и JSON-тела; файлы импорта и другие форматы
+// разбирает сам обработчик. Тело другого типа там, где спецификация принимает только JSON,
+// отклоняется с 415: обработчик все равно разобрал бы его как JSON, минуя проверку схемы.
+// Маршруты вне спецификации (Swagger UI) пропускаются.
+//
+// С validateResponses ответы буферизуются и тоже сверяются со спецификацией: расхождение
+// превращается в 500, чтобы тесты падали на нарушении контракта. Включается только в тестах.
+func OpenAPIValidator(doc *openapi3.T, validateResponses bool, logger *logrus.Logger) (gin.HandlerFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		jsonBody := isJSON(c.ContentType())
+		if hasBody(c.Request) && !jsonBody && acceptsOnlyJSON(route.Operation.RequestBody) {
+			err := fmt.Errorf("unsupported Content-Type %q, expected %s", c.ContentType(), gin.MIMEJSON)
+			HandleError(c, http.StatusUnsupportedMediaType, err, CodeUnsupportedMediaType)
+			c.Abort()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				// пустое тело проверяется всегда, чтобы обязательное тело не пропустить без Content-Type
+				ExcludeRequestBody:  hasBody(c.Request) && !jsonBody,
+				MultiError:          true,
+				SkipSettingDefaults: true,
+			},
+		}
+
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			handleContractError(c, err)
+			c.Abort()
+			return
+		}
+
+		if !validateResponses {
+			c.Next()
+			return
+		}
+
+		validateResponse(c, input, route, logger)
+	}, nil
+}
+
+func validateResponse(c *gin.Context, input *openapi3filter.RequestValidationInput, route *routers.Route, logger *logrus.Logger) {
+	writer := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+	c.Writer = writer
+	c.Next()
+	c.Writer = writer.ResponseWriter
+
+	body := writer.body.Bytes()
+	options := &openapi3filter.Options{
+		ExcludeResponseBody:   !isJSON(writer.Header().Get("Content-Type")),
+		IncludeResponseStatus: true,
+		MultiError:            true,
+	}
+	// без этого в текст ошибки попадают схема и значение целиком
+	options.WithCustomSchemaErrorFunc(func(err *openapi3.SchemaError) string {
+		return jsonPointer(err.JSONPointer()) + ": " + err.Reason
+	})
+
+	err := openapi3filter.ValidateResponse(c.Request.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 writer.status,
+		Header:                 writer.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(body)),
+		Options:                options,
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"method": c.Request.Method,
+			"path":   route.Path,
+			"status": writer.status,
+			"error":  err.Error(),
+		}).Error("Response does not match the API contract")
+
+		writer.Header().Del("Content-Type")
+		writer.Header().Del("Content-Disposition")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   http.StatusText(http.StatusInternalServerError),
+			Message: "response does not match the API contract: " + err.Error(),
+			Code:    CodeInternalError,
+		})
+		return
+	}
+
+	c.Writer.WriteHeader(writer.status)
+	if len(body) > 0 {
+		_, _ = c.Writer.Write(body)
+	} else {
+		c.Writer.WriteHeaderNow()
+	}
+}
+
+// bufferedWriter придерживает ответ обработчика, пока он не сверен со спецификацией
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(data string) (int, error) {
+	w.written = true
+	return w.body.WriteString(data)
+}
+
+func (w *bufferedWriter) Status() int   { return w.status }
+func (w *bufferedWriter) Size() int     { return w.body.Len() }
+func (w *bufferedWriter) Written() bool { return w.written }
+func (w *bufferedWriter) Flush()        {}
+
+func handleContractError(c *gin.Context, err error) {
+	logger, exists := c.Get("logger")
+	if exists {
+		if requestLogger, ok := logger.(*logrus.Entry); ok {
+			requestLogger.WithFields(logrus.Fields{
+				"error":       err.Error(),
+				"status_code": http.StatusBadRequest,
+				"error_code":  CodeValidationError,
+			}).Error("Request error")
+		}
+	}
+
+	c.JSON(http.StatusBadRequest, ErrorResponse{
+		Error:   http.StatusText(http.StatusBadRequest),
+		Message: contractViolationMessage,
+		Code:    CodeValidationError,
+		Details: fieldErrors(err, ""),
+	})
+}
+
+// fieldErrors раскладывает ошибку kin-openapi на ошибки отдельных полей. Сначала проверяется сам тип
+// ошибки, а не цепочка Unwrap: RequestError оборачивает MultiError с ошибками схемы.
+func fieldErrors(err error, in string) []FieldError {
+	switch err := err.(type) {
+	case openapi3.MultiError:
+		var details []FieldError
+		for _, item := range err {
+			details = append(details, fieldErrors(item, in)...)
+		}
+		return details
+	case *openapi3filter.RequestError:
+		switch {
+		case err.Parameter != nil:
+			return parameterErrors(err)
+		case err.RequestBody != nil && err.Err != nil:
+			return fieldErrors(err.Err, "body")
+		case err.RequestBody != nil:
+			return []FieldError{{In: "body", Message: err.Reason}}
+		}
+		return []FieldError{{In: in, Message: err.Error()}}
+	case *openapi3.SchemaError:
+		return []FieldError{{In: in, Field: jsonPointer(err.JSONPointer()), Message: err.Reason}}
+	case *openapi3filter.ParseError:
+		path := make([]string, len(err.Path()))
+		for i, segment := range err.Path() {
+			path[i] = fmt.Sprint(segment)
+		}
+		return []FieldError{{In: in, Field: jsonPointer(path), Message: err.Error()}}
+	}
+
+	return []FieldError{{In: in, Message: err.Error()}}
+}
+
+// parameterErrors - для параметров Field содержит имя параметра, а JSON pointer внутри значения не нужен
+func parameterErrors(requestErr *openapi3filter.RequestError) []FieldError {
+	parameter := requestErr.Parameter
+
+	if requestErr.Err == nil {
+		return []FieldError{{In: parameter.In, Field: parameter.Name, Message: requestErr.Reason}}
+	}
+
+	var messages []string
+	for _, detail := range fieldErrors(requestErr.Err, parameter.In) {
+		messages = append(messages, detail.Message)
+	}
+
+	return []FieldError{{In: parameter.In, Field: parameter.Name, Message: strings.Join(messages, "; ")}}
+}
+
+// jsonPointer собирает RFC 6901 указатель на поле тела, например /items/0/quantity
+func jsonPointer(path []string) string {
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+
+	var builder strings.Builder
+	for _, segment := range path {
+		builder.WriteByte('/')
+		builder.WriteString(escaper.Replace(segment))
+	}
+	return builder.String()
+}
+
+// hasBody - у запроса есть тело; длина -1 означает, что она неизвестна заранее (chunked)
+func hasBody(req *http.Request) bool {
+	return req.Body != nil && req.Body != http.NoBody && req.ContentLength != 0
+}
+
+// acceptsOnlyJSON - операция принимает тело и все объявленные для него типы - JSON
+func acceptsOnlyJSON(body *openapi3.RequestBodyRef) bool {
+	if body == nil || body.Value == nil || len(body.Value.Content) == 0 {
+		return false
+	}
+	for contentType := range body.Value.Content {
+		if !isJSON(contentType) {
+			return false
+		}
+	}
+	return true
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == gin.MIMEJSON || strings.HasSuffix(mediaType, "+json")
+}
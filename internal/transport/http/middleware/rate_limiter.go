@@ -5,6 +5,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/AndrivA89/orders/internal/infrastructure/metrics"
+
 	"github.com/gin-gonic/gin"
 	"golang.org/x/time/rate"
 )
@@ -37,7 +39,8 @@ func (rl *RateLimiter) GetLimiter(ip string) *rate.Limiter {
 	return limiter
 }
 
-func RateLimitMiddleware(rps rate.Limit, burst int) gin.HandlerFunc {
+// RateLimitMiddleware ограничивает частоту запросов с одного IP; отклоненные запросы учитываются в метриках
+func RateLimitMiddleware(rps rate.Limit, burst int, m *metrics.Metrics) gin.HandlerFunc {
 	limiter := NewRateLimiter(rps, burst)
 
 	return func(c *gin.Context) {
@@ -45,10 +48,11 @@ func RateLimitMiddleware(rps rate.Limit, burst int) gin.HandlerFunc {
 		l := limiter.GetLimiter(ip)
 
 		if !l.Allow() {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error":   "Too Many Requests",
-				"message": "Rate limit exceeded. Please try again later.",
-				"code":    "RATE_LIMIT_EXCEEDED",
+			m.RateLimited(c.FullPath())
+			c.JSON(http.StatusTooManyRequests, ErrorResponse{
+				Error:   "Too Many Requests",
+				Message: "Rate limit exceeded. Please try again later.",
+				Code:    CodeRateLimitExceeded,
 			})
 			c.Abort()
 			return
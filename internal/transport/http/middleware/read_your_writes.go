@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// LastWriteHeader - время последней записи клиента (миллисекунды Unix); API-клиенты возвращают его
+	// в следующих запросах, браузеры получают то же значение в cookie
+	LastWriteHeader = "X-Last-Write"
+	lastWriteCookie = "last_write"
+)
+
+// ReadYourWrites восстанавливает из заголовка или cookie время последней записи клиента
+// и возвращает обновленное время, если запрос что-то записал. По нему репозитории читают
+// данные этого клиента из основной БД, пока реплики могут отставать, на любом экземпляре сервиса.
+func ReadYourWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value := c.GetHeader(LastWriteHeader)
+		if value == "" {
+			value, _ = c.Cookie(lastWriteCookie)
+		}
+
+		// время выставляется при фиксации записи, до того как обработчик начнет писать ответ
+		ctx := database.WithWriteMarker(c.Request.Context(), database.ParseWriteTime(value), func(at time.Time) {
+			formatted := database.FormatWriteTime(at)
+			c.Header(LastWriteHeader, formatted)
+			http.SetCookie(c.Writer, &http.Cookie{
+				Name:     lastWriteCookie,
+				Value:    formatted,
+				Path:     "/",
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/database"
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestReadYourWrites_CarriesWriteTimeBetweenRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	primary, replica := &gorm.DB{}, &gorm.DB{}
+	reads, err := database.NewReadRouter(primary, []*gorm.DB{replica}, database.ReplicaPolicyRoundRobin, time.Minute)
+	require.NoError(t, err)
+
+	engine := gin.New()
+	engine.Use(middleware.ReadYourWrites())
+	engine.POST("/orders", func(c *gin.Context) {
+		reads.MarkWritten(c.Request.Context())
+		c.Status(http.StatusCreated)
+	})
+	engine.GET("/orders", func(c *gin.Context) {
+		if reads.Reader(c.Request.Context()) == primary {
+			c.String(http.StatusOK, "primary")
+			return
+		}
+		c.String(http.StatusOK, "replica")
+	})
+
+	get := func(setup func(req *http.Request)) string {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		setup(req)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders", nil))
+	lastWrite := w.Header().Get(middleware.LastWriteHeader)
+	require.NotEmpty(t, lastWrite)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+
+	assert.Equal(t, "replica", get(func(req *http.Request) {}))
+	assert.Equal(t, "primary", get(func(req *http.Request) { req.Header.Set(middleware.LastWriteHeader, lastWrite) }))
+	assert.Equal(t, "primary", get(func(req *http.Request) { req.AddCookie(cookies[0]) }))
+}
@@ -0,0 +1,48 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+//go:embed swagger/index.html
+var swaggerIndex []byte
+
+var specJSON = sync.OnceValues(func() ([]byte, error) {
+	doc, err := Spec()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+})
+
+var swaggerAssets = http.FileServer(swaggerFiles.HTTP)
+
+// ServeSpec отдает спецификацию по GET /openapi.json
+func ServeSpec(c *gin.Context) {
+	body, err := specJSON()
+	if err != nil {
+		middleware.HandleInternalError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, gin.MIMEJSON, body)
+}
+
+// SwaggerUI обслуживает GET /swagger/*any: страницу, настроенную на /openapi.json, и статику Swagger UI
+func SwaggerUI(c *gin.Context) {
+	switch file := c.Param("any"); file {
+	case "", "/", "/index.html":
+		c.Data(http.StatusOK, gin.MIMEHTML+"; charset=utf-8", swaggerIndex)
+	default:
+		c.Request.URL.Path = file
+		swaggerAssets.ServeHTTP(c.Writer, c.Request)
+	}
+}
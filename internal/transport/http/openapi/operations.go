@@ -0,0 +1,473 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	mimeCSV    = "text/csv"
+	mimeNDJSON = "application/x-ndjson"
+	mimeXML    = "application/xml"
+	mimePDF    = "application/pdf"
+	mimeText   = "text/plain"
+)
+
+var (
+	fileSchema   = openapi3.NewStringSchema()
+	binarySchema = openapi3.NewStringSchema().WithFormat("binary")
+
+	graphqlRequestSchema = openapi3.NewObjectSchema().
+				WithProperty("query", openapi3.NewStringSchema()).
+				WithProperty("operationName", openapi3.NewStringSchema()).
+				WithProperty("variables", openapi3.NewObjectSchema().WithAnyAdditionalProperties().WithNullable()).
+				WithRequired([]string{"query"})
+
+	graphqlResponseSchema = openapi3.NewObjectSchema().
+				WithProperty("data", &openapi3.Schema{Description: "Результат запроса", Nullable: true}).
+				WithProperty("errors", openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema().WithAnyAdditionalProperties()))
+
+	openapiSchema = &openapi3.Schema{Description: "Документ OpenAPI 3"}
+)
+
+func query(name, description string, schema *openapi3.Schema) *openapi3.Parameter {
+	return openapi3.NewQueryParameter(name).WithDescription(description).WithSchema(schema)
+}
+
+func limitParam(defaultLimit int) *openapi3.Parameter {
+	return query("limit", "Размер страницы", openapi3.NewIntegerSchema().WithDefault(defaultLimit))
+}
+
+var offsetParam = query("offset", "Смещение от начала списка", openapi3.NewIntegerSchema().WithMin(0).WithDefault(0))
+
+func formatParam(description string, formats ...any) *openapi3.Parameter {
+	return query("format", description, openapi3.NewStringSchema().WithEnum(formats...))
+}
+
+// reportParams - общие параметры отчетов о продажах
+var reportParams = []*openapi3.Parameter{
+	query("from", "Начало периода: YYYY-MM-DD или RFC3339, по умолчанию за 30 дней до to", openapi3.NewStringSchema()),
+	query("to", "Конец периода: YYYY-MM-DD или RFC3339, по умолчанию текущий момент", openapi3.NewStringSchema()),
+	query("limit", "Ограничение числа строк, 0 - без ограничения", openapi3.NewIntegerSchema().WithDefault(0)),
+	formatParam("Формат отчета; без параметра выбирается по заголовку Accept", "json", "csv"),
+}
+
+// report - отчет в JSON или CSV
+func report(body any) []response {
+	return []response{ok(body), {status: http.StatusOK, body: fileSchema, content: []string{mimeCSV}}}
+}
+
+// verboseParam и readinessResponses - общие для /readyz и /health
+var (
+	verboseParam = query("verbose", "Подробный отчет по проверкам; доступен, если включен HEALTH_DETAILED_REPORT", openapi3.NewBoolSchema().WithDefault(false))
+
+	readinessResponses = []response{
+		ok(dto.HealthResponse{}),
+		{status: http.StatusServiceUnavailable, body: dto.HealthResponse{}},
+	}
+)
+
+// operations - все маршруты router.SetupRoutes, кроме самого Swagger UI.
+// Тест роутера сверяет таблицу с зарегистрированными в Gin маршрутами.
+var operations = []operation{
+	{
+		method: http.MethodGet, path: "/livez", tag: "system",
+		summary:   "Проба живости: процесс обслуживает HTTP, зависимости не проверяются",
+		responses: []response{ok(dto.HealthResponse{})},
+	},
+	{
+		method: http.MethodGet, path: "/readyz", tag: "system",
+		summary:    "Проба готовности: БД и фоновые задачи",
+		parameters: []*openapi3.Parameter{verboseParam},
+		responses:  readinessResponses,
+		errors:     []int{http.StatusBadRequest, http.StatusForbidden},
+	},
+	{
+		method: http.MethodGet, path: "/health", tag: "system",
+		summary:    "Устаревший синоним /readyz",
+		parameters: []*openapi3.Parameter{verboseParam},
+		responses:  readinessResponses,
+		errors:     []int{http.StatusBadRequest, http.StatusForbidden},
+	},
+	{
+		method: http.MethodGet, path: "/metrics", tag: "system",
+		summary:   "Метрики в текстовом формате Prometheus",
+		responses: []response{{status: http.StatusOK, body: fileSchema, content: []string{mimeText}}},
+	},
+	{
+		method: http.MethodGet, path: "/openapi.json", tag: "system",
+		summary:   "Эта спецификация",
+		responses: []response{ok(openapiSchema)},
+	},
+	{
+		method: http.MethodPost, path: "/graphql", tag: "graphql",
+		summary: "Запрос GraphQL: пользователи, товары и заказы одним запросом",
+		request: graphqlRequestSchema,
+		responses: []response{
+			ok(graphqlResponseSchema),
+			{status: http.StatusBadRequest, body: graphqlResponseSchema},
+		},
+	},
+
+	// Пользователи
+	{
+		method: http.MethodPost, path: "/api/v1/users", tag: "users",
+		summary:   "Регистрация пользователя",
+		request:   dto.CreateUserRequest{},
+		responses: []response{created(dto.UserResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusTooManyRequests},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/users/:id", tag: "users",
+		summary:   "Пользователь по ID",
+		responses: []response{ok(dto.UserResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/users/:id/orders", tag: "users",
+		summary:    "Заказы пользователя",
+		parameters: []*openapi3.Parameter{limitParam(10), offsetParam},
+		responses:  []response{ok(dto.OrderListResponse{})},
+		errors:     []int{http.StatusBadRequest},
+	},
+
+	// Товары
+	{
+		method: http.MethodPost, path: "/api/v1/products", tag: "products",
+		summary:   "Создание товара",
+		request:   dto.CreateProductRequest{},
+		responses: []response{created(dto.ProductResponse{})},
+		errors:    []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/products", tag: "products",
+		summary: "Список товаров",
+		parameters: []*openapi3.Parameter{
+			limitParam(10),
+			offsetParam,
+			query("category", "Slug категории: товары категории и всех ее подкатегорий", openapi3.NewStringSchema()),
+		},
+		responses: []response{ok(dto.ProductListResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/products/import", tag: "products",
+		summary:        "Импорт каталога из CSV или NDJSON с построчным отчетом",
+		parameters:     []*openapi3.Parameter{formatParam("Формат файла; без параметра определяется по Content-Type", "csv", "ndjson")},
+		request:        fileSchema,
+		requestContent: []string{mimeCSV, mimeNDJSON},
+		responses:      []response{ok(entities.ProductImportReport{})},
+		errors:         []int{http.StatusBadRequest, http.StatusUnsupportedMediaType},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/products/export", tag: "products",
+		summary:    "Выгрузка каталога потоком",
+		parameters: []*openapi3.Parameter{formatParam("Формат файла", "csv", "ndjson")},
+		responses:  []response{{status: http.StatusOK, body: fileSchema, content: []string{mimeCSV, mimeNDJSON}}},
+		errors:     []int{http.StatusNotAcceptable},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/products/:id", tag: "products",
+		summary:   "Товар по ID",
+		responses: []response{ok(dto.ProductResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/products/:id/variants", tag: "products",
+		summary:   "Добавление варианта товара",
+		request:   dto.CreateVariantRequest{},
+		responses: []response{created(dto.ProductVariantResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/products/:id/stock", tag: "products",
+		summary:   "Приемка товара на склад",
+		request:   dto.ReceiveStockRequest{},
+		responses: []response{ok(entities.StockReceipt{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPut, path: "/api/v1/products/:id/categories", tag: "products",
+		summary:   "Замена категорий товара",
+		request:   dto.SetProductCategoriesRequest{},
+		responses: []response{ok(dto.ProductResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/products/:id/prices", tag: "prices",
+		summary:   "История цен товара",
+		responses: []response{ok(dto.PriceHistoryResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/products/:id/prices", tag: "prices",
+		summary:   "Планирование новой цены",
+		request:   dto.SchedulePriceRequest{},
+		responses: []response{created(dto.PriceChangeResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/products/:id/subscriptions", tag: "stock-alerts",
+		summary:   "Подписка на поступление товара",
+		request:   dto.StockSubscriptionRequest{},
+		responses: []response{created(dto.StockSubscriptionResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		method: http.MethodPut, path: "/api/v1/products/:id/low-stock-threshold", tag: "stock-alerts",
+		summary:   "Порог низкого остатка товара",
+		request:   dto.LowStockThresholdRequest{},
+		responses: []response{ok(dto.ProductResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodDelete, path: "/api/v1/stock-subscriptions/:id", tag: "stock-alerts",
+		summary:   "Отмена подписки на поступление",
+		responses: []response{noContent},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+
+	// Категории
+	{
+		method: http.MethodPost, path: "/api/v1/categories", tag: "categories",
+		summary:   "Создание категории",
+		request:   dto.CreateCategoryRequest{},
+		responses: []response{created(dto.CategoryResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/categories", tag: "categories",
+		summary:   "Дерево категорий",
+		responses: []response{ok(dto.CategoryListResponse{})},
+	},
+
+	// Заказы
+	{
+		method: http.MethodPost, path: "/api/v1/orders", tag: "orders",
+		summary:   "Создание заказа",
+		request:   dto.CreateOrderRequest{},
+		responses: []response{created(dto.OrderResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusTooManyRequests},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/orders/:id", tag: "orders",
+		summary:   "Заказ по ID",
+		responses: []response{ok(dto.OrderResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPatch, path: "/api/v1/orders/:id/confirm", tag: "orders",
+		summary:   "Подтверждение заказа",
+		responses: []response{ok(dto.OrderResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPatch, path: "/api/v1/orders/:id/cancel", tag: "orders",
+		summary:   "Отмена заказа",
+		responses: []response{ok(dto.OrderResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPatch, path: "/api/v1/orders/:id/release", tag: "orders",
+		summary:   "Снятие заказа с удержания антифрода",
+		responses: []response{ok(dto.OrderResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/orders/:id/shipments", tag: "shipments",
+		summary:   "Создание отгрузки",
+		request:   dto.CreateShipmentRequest{},
+		responses: []response{created(dto.ShipmentResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/orders/:id/shipments", tag: "shipments",
+		summary:   "Отгрузки заказа",
+		responses: []response{ok(dto.ShipmentListResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/orders/:id/returns", tag: "returns",
+		summary:   "Заявка на возврат",
+		request:   dto.OpenReturnRequest{},
+		responses: []response{created(dto.ReturnResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/orders/:id/returns", tag: "returns",
+		summary:   "Возвраты заказа",
+		responses: []response{ok(dto.ReturnListResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/orders/:id/invoice", tag: "invoices",
+		summary:    "Счет по заказу",
+		parameters: []*openapi3.Parameter{formatParam("Формат счета; без параметра выбирается по заголовку Accept", "json", "xml", "pdf")},
+		responses: []response{
+			ok(dto.InvoiceResponse{}),
+			{status: http.StatusOK, body: dto.InvoiceResponse{}, content: []string{mimeXML}},
+			{status: http.StatusOK, body: binarySchema, content: []string{mimePDF}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusNotAcceptable, http.StatusConflict},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/orders/:id/emails", tag: "emails",
+		summary:   "Журнал писем по заказу",
+		responses: []response{ok([]dto.EmailDeliveryResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+
+	// Возвраты
+	{
+		method: http.MethodGet, path: "/api/v1/returns/:id", tag: "returns",
+		summary:   "Возврат по ID",
+		responses: []response{ok(dto.ReturnResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPatch, path: "/api/v1/returns/:id/approve", tag: "returns",
+		summary:      "Одобрение возврата",
+		request:      dto.ReviewReturnRequest{},
+		optionalBody: true,
+		responses:    []response{ok(dto.ReturnResponse{})},
+		errors:       []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPatch, path: "/api/v1/returns/:id/reject", tag: "returns",
+		summary:      "Отклонение возврата",
+		request:      dto.ReviewReturnRequest{},
+		optionalBody: true,
+		responses:    []response{ok(dto.ReturnResponse{})},
+		errors:       []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPatch, path: "/api/v1/returns/:id/receive", tag: "returns",
+		summary:   "Приемка возвращенного товара на склад",
+		responses: []response{ok(dto.ReturnResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+
+	// Отчеты
+	{
+		method: http.MethodGet, path: "/api/v1/reports/sales", tag: "reports",
+		summary: "Выручка по периодам",
+		parameters: append([]*openapi3.Parameter{
+			query("group_by", "Период группировки", openapi3.NewStringSchema().
+				WithEnum(string(entities.ReportPeriodDay), string(entities.ReportPeriodWeek), string(entities.ReportPeriodMonth)).
+				WithDefault(string(entities.ReportPeriodDay))),
+		}, reportParams...),
+		responses: report([]entities.SalesPoint{}),
+		errors:    []int{http.StatusBadRequest, http.StatusNotAcceptable},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/reports/sales/summary", tag: "reports",
+		summary:    "Сводка продаж за период",
+		parameters: reportParams,
+		responses:  report(dto.SalesSummaryResponse{}),
+		errors:     []int{http.StatusBadRequest, http.StatusNotAcceptable},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/reports/sales/by-product", tag: "reports",
+		summary:    "Самые продаваемые товары",
+		parameters: reportParams,
+		responses:  report([]entities.ProductSales{}),
+		errors:     []int{http.StatusBadRequest, http.StatusNotAcceptable},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/reports/sales/by-tag", tag: "reports",
+		summary:    "Продажи по тегам",
+		parameters: reportParams,
+		responses:  report([]entities.TagSales{}),
+		errors:     []int{http.StatusBadRequest, http.StatusNotAcceptable},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/reports/sales/by-category", tag: "reports",
+		summary:    "Продажи по категориям",
+		parameters: reportParams,
+		responses:  report([]entities.CategorySales{}),
+		errors:     []int{http.StatusBadRequest, http.StatusNotAcceptable},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/reports/inventory/low-stock", tag: "reports",
+		summary: "Товары с низким остатком",
+		parameters: []*openapi3.Parameter{
+			query("threshold", "Остаток, начиная с которого товар считается заканчивающимся", openapi3.NewIntegerSchema().WithDefault(5)),
+			query("limit", "Ограничение числа строк, 0 - без ограничения", openapi3.NewIntegerSchema().WithDefault(0)),
+			formatParam("Формат отчета; без параметра выбирается по заголовку Accept", "json", "csv"),
+		},
+		responses: report([]entities.LowStockProduct{}),
+		errors:    []int{http.StatusBadRequest, http.StatusNotAcceptable},
+	},
+
+	// Вебхуки
+	{
+		method: http.MethodPost, path: "/api/v1/webhooks", tag: "webhooks",
+		summary:   "Подписка на события заказов",
+		request:   dto.WebhookSubscriptionRequest{},
+		responses: []response{created(dto.WebhookSubscriptionResponse{})},
+		errors:    []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/webhooks", tag: "webhooks",
+		summary:   "Список подписок",
+		responses: []response{ok([]dto.WebhookSubscriptionResponse{})},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/webhooks/:id", tag: "webhooks",
+		summary:   "Подписка по ID",
+		responses: []response{ok(dto.WebhookSubscriptionResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPut, path: "/api/v1/webhooks/:id", tag: "webhooks",
+		summary:   "Изменение подписки",
+		request:   dto.WebhookSubscriptionRequest{},
+		responses: []response{ok(dto.WebhookSubscriptionResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodDelete, path: "/api/v1/webhooks/:id", tag: "webhooks",
+		summary:   "Удаление подписки",
+		responses: []response{noContent},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/webhooks/:id/deliveries", tag: "webhooks",
+		summary: "Журнал доставок подписки",
+		parameters: []*openapi3.Parameter{
+			limitParam(50),
+			offsetParam,
+			query("status", "Статус доставки", openapi3.NewStringSchema().WithEnum(
+				string(entities.WebhookDeliveryStatusPending),
+				string(entities.WebhookDeliveryStatusDelivered),
+				string(entities.WebhookDeliveryStatusDead),
+			)),
+		},
+		responses: []response{ok([]dto.WebhookDeliveryResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/webhooks/:id/deliveries/:delivery_id/replay", tag: "webhooks",
+		summary:   "Повторная отправка доставки",
+		responses: []response{{status: http.StatusAccepted, body: dto.WebhookDeliveryResponse{}}},
+		errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+
+	// Перевозчики
+	{
+		method: http.MethodPost, path: "/api/v1/carriers/:carrier/tracking", tag: "shipments",
+		summary: "Событие трекинга от перевозчика",
+		parameters: []*openapi3.Parameter{
+			openapi3.NewHeaderParameter("X-Carrier-Token").
+				WithDescription("Секрет перевозчика, если он задан в конфигурации").
+				WithSchema(openapi3.NewStringSchema()),
+		},
+		request:   dto.TrackingEventRequest{},
+		responses: []response{ok(dto.ShipmentResponse{})},
+		errors:    []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound},
+	},
+}
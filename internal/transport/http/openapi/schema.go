@@ -0,0 +1,304 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AndrivA89/orders/internal/domain/entities"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// enums - допустимые значения строковых типов домена, которые в Go не выводятся из типа
+var enums = map[reflect.Type][]any{
+	reflect.TypeOf(entities.OrderEvent("")): {
+		string(entities.OrderEventCreated),
+		string(entities.OrderEventConfirmed),
+		string(entities.OrderEventCancelled),
+		string(entities.OrderEventCompleted),
+	},
+	reflect.TypeOf(entities.PriceStatus("")): {
+		string(entities.PriceStatusScheduled),
+		string(entities.PriceStatusActive),
+		string(entities.PriceStatusExpired),
+	},
+	reflect.TypeOf(entities.ProductImportAction("")): {
+		string(entities.ProductImportCreated),
+		string(entities.ProductImportUpdated),
+		string(entities.ProductImportFailed),
+	},
+}
+
+// schemaGenerator строит схемы по Go-типам DTO и сущностей. Структуры выносятся в components
+// под своим именем, ограничения полей берутся из тегов binding, по которым их проверяет Gin.
+type schemaGenerator struct {
+	components openapi3.Schemas
+	types      map[string]reflect.Type
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		components: make(openapi3.Schemas),
+		types:      make(map[string]reflect.Type),
+	}
+}
+
+// schemaRef возвращает схему значения. В ответе обязательны все поля без omitempty - так их
+// сериализует encoding/json, в запросе - только поля с binding:"required".
+func (g *schemaGenerator) schemaRef(value any, response bool) (*openapi3.SchemaRef, error) {
+	if schema, ok := value.(*openapi3.Schema); ok {
+		return schema.NewRef(), nil
+	}
+	return g.typeRef(reflect.TypeOf(value), response)
+}
+
+func (g *schemaGenerator) typeRef(t reflect.Type, response bool) (*openapi3.SchemaRef, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return openapi3.NewDateTimeSchema().NewRef(), nil
+	case uuidType:
+		return openapi3.NewUUIDSchema().NewRef(), nil
+	case rawMessageType:
+		return (&openapi3.Schema{Description: "Произвольный JSON"}).NewRef(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return openapi3.NewBoolSchema().NewRef(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return openapi3.NewInt32Schema().NewRef(), nil
+	case reflect.Int64, reflect.Uint64:
+		return openapi3.NewInt64Schema().NewRef(), nil
+	case reflect.Float32, reflect.Float64:
+		return openapi3.NewFloat64Schema().NewRef(), nil
+	case reflect.String:
+		schema := openapi3.NewStringSchema()
+		if values, ok := enums[t]; ok {
+			schema.Enum = values
+		}
+		return schema.NewRef(), nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.typeRef(t.Elem(), response)
+		if err != nil {
+			return nil, err
+		}
+		schema := openapi3.NewArraySchema()
+		schema.Items = items
+		return schema.NewRef(), nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("openapi: map key of %s must be a string", t)
+		}
+		values, err := g.typeRef(t.Elem(), response)
+		if err != nil {
+			return nil, err
+		}
+		schema := openapi3.NewObjectSchema()
+		schema.AdditionalProperties = openapi3.AdditionalProperties{Schema: values}
+		return schema.NewRef(), nil
+	case reflect.Struct:
+		return g.componentRef(t, response)
+	}
+
+	return nil, fmt.Errorf("openapi: unsupported type %s", t)
+}
+
+func (g *schemaGenerator) componentRef(t reflect.Type, response bool) (*openapi3.SchemaRef, error) {
+	name := t.Name()
+	ref := "#/components/schemas/" + name
+
+	if known, ok := g.types[name]; ok {
+		if known != t {
+			return nil, fmt.Errorf("openapi: schema name %s is used by both %s and %s", name, known, t)
+		}
+		return openapi3.NewSchemaRef(ref, g.components[name].Value), nil
+	}
+
+	// компонент регистрируется до обхода полей, чтобы рекурсивные типы (дерево категорий) ссылались на себя
+	schema := openapi3.NewObjectSchema()
+	g.types[name] = t
+	g.components[name] = schema.NewRef()
+
+	if err := g.structFields(schema, t, response); err != nil {
+		return nil, err
+	}
+
+	return openapi3.NewSchemaRef(ref, schema), nil
+}
+
+func (g *schemaGenerator) structFields(schema *openapi3.Schema, t reflect.Type, response bool) error {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty, skip := jsonName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := g.structFields(schema, field.Type, response); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := g.typeRef(field.Type, response)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		required, err := applyBinding(property, field)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		// nil-указатели, срезы и карты без omitempty сериализуются как null
+		if !omitEmpty && !required && isNilable(field.Type) {
+			property = nullable(property)
+		}
+
+		schema.Properties[name] = property
+		if required || (response && !omitEmpty) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return nil
+}
+
+// applyBinding переносит правила validator из тега binding в схему поля и сообщает, обязательно ли поле
+func applyBinding(property *openapi3.SchemaRef, field reflect.StructField) (bool, error) {
+	tag := field.Tag.Get("binding")
+	if tag == "" {
+		return false, nil
+	}
+
+	// у ссылки на компонент ограничения задавать нельзя: правила после dive относятся к элементам
+	schema := property.Value
+	required := false
+	optional := false
+
+	for rule := range strings.SplitSeq(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			return required, nil
+		case "required":
+			required = true
+		case "omitempty":
+			optional = true
+		case "email":
+			schema.Format = "email"
+		case "oneof":
+			for value := range strings.FieldsSeq(param) {
+				schema.Enum = append(schema.Enum, value)
+			}
+			if optional {
+				schema.Enum = append(schema.Enum, "")
+			}
+		case "min", "max":
+			if property.Ref != "" {
+				return false, fmt.Errorf("binding %s on a component reference", rule)
+			}
+			limit, err := strconv.ParseUint(param, 10, 64)
+			if err != nil {
+				return false, fmt.Errorf("binding %s: %w", rule, err)
+			}
+			applyLimit(schema, name == "min", limit, optional)
+		}
+	}
+
+	return required, nil
+}
+
+func applyLimit(schema *openapi3.Schema, isMin bool, limit uint64, optional bool) {
+	switch {
+	case schema.Type.Is(openapi3.TypeString):
+		if isMin {
+			// omitempty пропускает пустую строку мимо остальных правил
+			if !optional {
+				schema.MinLength = limit
+			}
+		} else {
+			schema.MaxLength = &limit
+		}
+	case schema.Type.Is(openapi3.TypeInteger), schema.Type.Is(openapi3.TypeNumber):
+		value := float64(limit)
+		if isMin {
+			schema.Min = &value
+		} else {
+			schema.Max = &value
+		}
+	case schema.Type.Is(openapi3.TypeArray):
+		if isMin {
+			schema.MinItems = limit
+		} else {
+			schema.MaxItems = &limit
+		}
+	case schema.Type.Is(openapi3.TypeObject):
+		if isMin {
+			schema.MinProps = limit
+		} else {
+			schema.MaxProps = &limit
+		}
+	}
+}
+
+func jsonName(field reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return "", false, false
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	for option := range strings.SplitSeq(options, ",") {
+		if option == "omitempty" || option == "omitzero" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+func isNilable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return t != rawMessageType
+	}
+	return false
+}
+
+// nullable разрешает null; ссылку на компонент в OpenAPI 3.0 нельзя дополнить, поэтому она оборачивается в allOf
+func nullable(property *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if property.Ref == "" {
+		property.Value.Nullable = true
+		return property
+	}
+
+	schema := openapi3.NewAllOfSchema(property.Value)
+	schema.AllOf[0] = property
+	schema.Nullable = true
+	return schema.NewRef()
+}
@@ -0,0 +1,199 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const mimeJSON = "application/json"
+
+// operation описывает один маршрут router.SetupRoutes. Тела запросов и ответов задаются
+// значениями DTO, схемы строятся по их типам; готовая схема передается как *openapi3.Schema.
+type operation struct {
+	method  string
+	path    string // путь в нотации Gin: /orders/:id
+	tag     string
+	summary string
+
+	parameters []*openapi3.Parameter
+	request    any
+	// requestContent - типы содержимого тела запроса, по умолчанию application/json
+	requestContent []string
+	// optionalBody - тело можно не передавать (комментарий к решению по возврату)
+	optionalBody bool
+
+	responses []response
+	// errors - статусы ответов ErrorResponse; 415 добавляется к каждой операции с телом, 500 - к каждой операции
+	errors []int
+}
+
+type response struct {
+	status  int
+	body    any
+	content []string
+}
+
+func ok(body any) response      { return response{status: http.StatusOK, body: body} }
+func created(body any) response { return response{status: http.StatusCreated, body: body} }
+
+var noContent = response{status: http.StatusNoContent}
+
+// errorResponses - общие ответы с ошибкой в components.responses и коды, которые в них встречаются
+var errorResponses = map[int]struct {
+	name  string
+	codes []string
+}{
+	http.StatusBadRequest:           {"BadRequest", []string{middleware.CodeValidationError}},
+	http.StatusUnauthorized:         {"Unauthorized", []string{middleware.CodeUnauthorized}},
+	http.StatusForbidden:            {"Forbidden", []string{middleware.CodeForbidden}},
+	http.StatusNotFound:             {"NotFound", []string{middleware.CodeNotFound}},
+	http.StatusNotAcceptable:        {"NotAcceptable", []string{middleware.CodeNotAcceptable}},
+	http.StatusConflict:             {"Conflict", []string{middleware.CodeConflict}},
+	http.StatusUnsupportedMediaType: {"UnsupportedMediaType", []string{middleware.CodeUnsupportedMediaType}},
+	http.StatusUnprocessableEntity:  {"UnprocessableEntity", []string{middleware.CodeOrderLimitExceeded}},
+	http.StatusTooManyRequests:      {"TooManyRequests", []string{middleware.CodeRateLimitExceeded}},
+	http.StatusInternalServerError:  {"InternalError", []string{middleware.CodeInternalError}},
+}
+
+var pathParam = regexp.MustCompile(`[:*]([A-Za-z_]+)`)
+
+// Spec возвращает спецификацию REST API. Документ строится один раз и не должен изменяться вызывающим.
+var Spec = sync.OnceValues(build)
+
+func build() (*openapi3.T, error) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:       "Orders API",
+			Description: "REST API сервиса заказов: пользователи, каталог, заказы, отгрузки, возвраты, отчеты и вебхуки.",
+			Version:     "1.0.0",
+		},
+		Paths: openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Responses: make(openapi3.ResponseBodies),
+		},
+	}
+
+	generator := newSchemaGenerator()
+
+	errorSchema, err := generator.schemaRef(middleware.ErrorResponse{}, true)
+	if err != nil {
+		return nil, err
+	}
+	codes := make([]any, len(middleware.ErrorCodes))
+	for i, code := range middleware.ErrorCodes {
+		codes[i] = code
+	}
+	errorSchema.Value.Properties["code"].Value.Enum = codes
+
+	for status, errorResponse := range errorResponses {
+		description := fmt.Sprintf("%s (code: %s)", http.StatusText(status), strings.Join(errorResponse.codes, ", "))
+		doc.Components.Responses[errorResponse.name] = &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription(description).
+				WithJSONSchemaRef(errorSchema),
+		}
+	}
+
+	for _, op := range operations {
+		operation, err := op.build(generator, doc.Components.Responses)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", op.method, op.path, err)
+		}
+		doc.AddOperation(SpecPath(op.path), op.method, operation)
+	}
+
+	doc.Components.Schemas = generator.components
+	return doc, nil
+}
+
+// SpecPath переводит путь Gin (/orders/:id) в шаблон OpenAPI (/orders/{id})
+func SpecPath(ginPath string) string {
+	return pathParam.ReplaceAllString(ginPath, "{$1}")
+}
+
+func (op operation) build(generator *schemaGenerator, responses openapi3.ResponseBodies) (*openapi3.Operation, error) {
+	operation := openapi3.NewOperation()
+	operation.Tags = []string{op.tag}
+	operation.Summary = op.summary
+	operation.Responses = openapi3.NewResponsesWithCapacity(len(op.responses) + len(op.errors) + 1)
+
+	for _, match := range pathParam.FindAllStringSubmatch(op.path, -1) {
+		schema := openapi3.NewStringSchema()
+		if match[1] == "id" || strings.HasSuffix(match[1], "_id") {
+			schema = openapi3.NewUUIDSchema()
+		}
+		operation.AddParameter(openapi3.NewPathParameter(match[1]).WithSchema(schema))
+	}
+	for _, parameter := range op.parameters {
+		operation.AddParameter(parameter)
+	}
+
+	if op.request != nil {
+		schema, err := generator.schemaRef(op.request, false)
+		if err != nil {
+			return nil, err
+		}
+		operation.RequestBody = &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().
+				WithRequired(!op.optionalBody).
+				WithSchemaRef(schema, contentTypes(op.requestContent)),
+		}
+	}
+
+	// несколько ответов с одним статусом объединяются: так у счета JSON, XML и PDF описаны разными схемами
+	for _, resp := range op.responses {
+		result := operation.Responses.Status(resp.status)
+		if result == nil {
+			result = &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription(http.StatusText(resp.status))}
+			operation.Responses.Set(strconv.Itoa(resp.status), result)
+		}
+		if resp.body == nil {
+			continue
+		}
+
+		schema, err := generator.schemaRef(resp.body, true)
+		if err != nil {
+			return nil, err
+		}
+		if result.Value.Content == nil {
+			result.Value.Content = openapi3.NewContent()
+		}
+		for _, contentType := range contentTypes(resp.content) {
+			result.Value.Content[contentType] = openapi3.NewMediaType().WithSchemaRef(schema)
+		}
+	}
+
+	statuses := slices.Clone(op.errors)
+	if op.request != nil && !slices.Contains(statuses, http.StatusUnsupportedMediaType) {
+		statuses = append(statuses, http.StatusUnsupportedMediaType)
+	}
+	for _, status := range append(statuses, http.StatusInternalServerError) {
+		errorResponse, known := errorResponses[status]
+		if !known {
+			return nil, fmt.Errorf("no error response for status %d", status)
+		}
+		operation.Responses.Set(strconv.Itoa(status), &openapi3.ResponseRef{
+			Ref:   "#/components/responses/" + errorResponse.name,
+			Value: responses[errorResponse.name].Value,
+		})
+	}
+
+	return operation, nil
+}
+
+func contentTypes(content []string) []string {
+	if len(content) == 0 {
+		return []string{mimeJSON}
+	}
+	return content
+}
@@ -3,8 +3,11 @@ package router
 import (
 	"time"
 
+	"github.com/AndrivA89/orders/internal/infrastructure/metrics"
+	"github.com/AndrivA89/orders/internal/transport/graphql"
 	"github.com/AndrivA89/orders/internal/transport/http/handlers"
 	"github.com/AndrivA89/orders/internal/transport/http/middleware"
+	"github.com/AndrivA89/orders/internal/transport/http/openapi"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -12,23 +15,62 @@ import (
 )
 
 type Router struct {
-	userHandler    *handlers.UserHandler
-	productHandler *handlers.ProductHandler
-	orderHandler   *handlers.OrderHandler
-	logger         *logrus.Logger
+	userHandler       *handlers.UserHandler
+	productHandler    *handlers.ProductHandler
+	orderHandler      *handlers.OrderHandler
+	shipmentHandler   *handlers.ShipmentHandler
+	returnHandler     *handlers.ReturnHandler
+	invoiceHandler    *handlers.InvoiceHandler
+	reportHandler     *handlers.ReportHandler
+	catalogHandler    *handlers.CatalogHandler
+	categoryHandler   *handlers.CategoryHandler
+	priceHandler      *handlers.PriceHandler
+	stockAlertHandler *handlers.StockAlertHandler
+	emailHandler      *handlers.EmailHandler
+	webhookHandler    *handlers.WebhookHandler
+	graphqlHandler    *graphql.Handler
+	healthHandler     *handlers.HealthHandler
+	metrics           *metrics.Metrics
+	logger            *logrus.Logger
 }
 
 func NewRouter(
 	userHandler *handlers.UserHandler,
 	productHandler *handlers.ProductHandler,
 	orderHandler *handlers.OrderHandler,
+	shipmentHandler *handlers.ShipmentHandler,
+	returnHandler *handlers.ReturnHandler,
+	invoiceHandler *handlers.InvoiceHandler,
+	reportHandler *handlers.ReportHandler,
+	catalogHandler *handlers.CatalogHandler,
+	categoryHandler *handlers.CategoryHandler,
+	priceHandler *handlers.PriceHandler,
+	stockAlertHandler *handlers.StockAlertHandler,
+	emailHandler *handlers.EmailHandler,
+	webhookHandler *handlers.WebhookHandler,
+	graphqlHandler *graphql.Handler,
+	healthHandler *handlers.HealthHandler,
+	metrics *metrics.Metrics,
 	logger *logrus.Logger,
 ) *Router {
 	return &Router{
-		userHandler:    userHandler,
-		productHandler: productHandler,
-		orderHandler:   orderHandler,
-		logger:         logger,
+		userHandler:       userHandler,
+		productHandler:    productHandler,
+		orderHandler:      orderHandler,
+		shipmentHandler:   shipmentHandler,
+		returnHandler:     returnHandler,
+		invoiceHandler:    invoiceHandler,
+		reportHandler:     reportHandler,
+		catalogHandler:    catalogHandler,
+		categoryHandler:   categoryHandler,
+		priceHandler:      priceHandler,
+		stockAlertHandler: stockAlertHandler,
+		emailHandler:      emailHandler,
+		webhookHandler:    webhookHandler,
+		graphqlHandler:    graphqlHandler,
+		healthHandler:     healthHandler,
+		metrics:           metrics,
+		logger:            logger,
 	}
 }
 
@@ -36,17 +78,39 @@ func (r *Router) SetupRoutes() *gin.Engine {
 	router := gin.New()
 
 	// Global middleware
+	router.Use(middleware.Metrics(r.metrics))
+	router.Use(middleware.Tracing())
 	router.Use(middleware.ErrorHandler(r.logger))
 	router.Use(middleware.Logger(r.logger))
 	router.Use(middleware.RequestLogger(r.logger))
+	router.Use(middleware.ReadYourWrites())
 
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
-			"service": "orders",
-		})
-	})
+	// Запросы сверяются со спецификацией OpenAPI, а в тестовом режиме Gin - и ответы
+	doc, err := openapi.Spec()
+	if err != nil {
+		r.logger.WithError(err).Fatal("Failed to build OpenAPI spec")
+	}
+	validator, err := middleware.OpenAPIValidator(doc, gin.Mode() == gin.TestMode, r.logger)
+	if err != nil {
+		r.logger.WithError(err).Fatal("Failed to build OpenAPI validator")
+	}
+	router.Use(validator)
+
+	// Пробы оркестратора: /livez - процесс жив, /readyz - зависимости доступны.
+	// /health оставлен для существующих проверок и отвечает так же, как /readyz.
+	router.GET("/livez", r.healthHandler.Live)
+	router.GET("/readyz", r.healthHandler.Ready)
+	router.GET("/health", r.healthHandler.Ready)
+
+	// Метрики для Prometheus
+	router.GET("/metrics", gin.WrapH(r.metrics.Handler()))
+
+	// Спецификация OpenAPI и Swagger UI для нее
+	router.GET("/openapi.json", openapi.ServeSpec)
+	router.GET("/swagger/*any", openapi.SwaggerUI)
+
+	// GraphQL: пользователи, товары и заказы одним запросом
+	router.POST("/graphql", r.graphqlHandler.Handle)
 
 	v1 := router.Group("/api/v1")
 	{
@@ -54,7 +118,7 @@ func (r *Router) SetupRoutes() *gin.Engine {
 		{
 			// Rate limiting для регистрации: 5 попыток в минуту с burst = 2
 			users.POST("",
-				middleware.RateLimitMiddleware(rate.Every(time.Minute/5), 2),
+				middleware.RateLimitMiddleware(rate.Every(time.Minute/5), 2, r.metrics),
 				r.userHandler.CreateUser)
 			users.GET("/:id", r.userHandler.GetUser)
 			users.GET("/:id/orders", r.orderHandler.GetOrdersByUser)
@@ -64,18 +128,79 @@ func (r *Router) SetupRoutes() *gin.Engine {
 		{
 			products.POST("", r.productHandler.CreateProduct)
 			products.GET("", r.productHandler.GetProducts)
+			products.POST("/import", r.catalogHandler.ImportProducts)
+			products.GET("/export", r.catalogHandler.ExportProducts)
 			products.GET("/:id", r.productHandler.GetProduct)
+			products.POST("/:id/variants", r.productHandler.AddVariant)
+			products.POST("/:id/stock", r.productHandler.ReceiveStock)
+			products.PUT("/:id/categories", r.productHandler.SetProductCategories)
+			products.GET("/:id/prices", r.priceHandler.GetPriceHistory)
+			products.POST("/:id/prices", r.priceHandler.SchedulePrice)
+			products.POST("/:id/subscriptions", r.stockAlertHandler.Subscribe)
+			products.PUT("/:id/low-stock-threshold", r.stockAlertHandler.SetLowStockThreshold)
+		}
+
+		v1.DELETE("/stock-subscriptions/:id", r.stockAlertHandler.Unsubscribe)
+
+		categories := v1.Group("/categories")
+		{
+			categories.POST("", r.categoryHandler.CreateCategory)
+			categories.GET("", r.categoryHandler.GetCategories)
 		}
 
 		orders := v1.Group("/orders")
 		{
 			// Rate limiting для создания заказов: 10 попыток в минуту с burst = 3
 			orders.POST("",
-				middleware.RateLimitMiddleware(rate.Every(time.Minute/10), 3),
+				middleware.RateLimitMiddleware(rate.Every(time.Minute/10), 3, r.metrics),
 				r.orderHandler.CreateOrder)
 			orders.GET("/:id", r.orderHandler.GetOrder)
 			orders.PATCH("/:id/confirm", r.orderHandler.ConfirmOrder)
 			orders.PATCH("/:id/cancel", r.orderHandler.CancelOrder)
+			orders.PATCH("/:id/release", r.orderHandler.ReleaseOrder)
+			orders.POST("/:id/shipments", r.shipmentHandler.CreateShipment)
+			orders.GET("/:id/shipments", r.shipmentHandler.GetOrderShipments)
+			orders.POST("/:id/returns", r.returnHandler.OpenReturn)
+			orders.GET("/:id/returns", r.returnHandler.GetOrderReturns)
+			orders.GET("/:id/invoice", r.invoiceHandler.GetOrderInvoice)
+			orders.GET("/:id/emails", r.emailHandler.GetOrderEmails)
+		}
+
+		returns := v1.Group("/returns")
+		{
+			returns.GET("/:id", r.returnHandler.GetReturn)
+			returns.PATCH("/:id/approve", r.returnHandler.ApproveReturn)
+			returns.PATCH("/:id/reject", r.returnHandler.RejectReturn)
+			returns.PATCH("/:id/receive", r.returnHandler.ReceiveReturn)
+		}
+
+		// Отчеты отдаются в JSON или CSV (параметр format или заголовок Accept)
+		reports := v1.Group("/reports")
+		{
+			reports.GET("/sales", r.reportHandler.GetSalesByPeriod)
+			reports.GET("/sales/summary", r.reportHandler.GetSalesSummary)
+			reports.GET("/sales/by-product", r.reportHandler.GetTopProducts)
+			reports.GET("/sales/by-tag", r.reportHandler.GetSalesByTag)
+			reports.GET("/sales/by-category", r.reportHandler.GetSalesByCategory)
+			reports.GET("/inventory/low-stock", r.reportHandler.GetLowStock)
+		}
+
+		// Подписки партнеров на события заказов и журнал доставок
+		webhooks := v1.Group("/webhooks")
+		{
+			webhooks.POST("", r.webhookHandler.CreateSubscription)
+			webhooks.GET("", r.webhookHandler.GetSubscriptions)
+			webhooks.GET("/:id", r.webhookHandler.GetSubscription)
+			webhooks.PUT("/:id", r.webhookHandler.UpdateSubscription)
+			webhooks.DELETE("/:id", r.webhookHandler.DeleteSubscription)
+			webhooks.GET("/:id/deliveries", r.webhookHandler.GetDeliveries)
+			webhooks.POST("/:id/deliveries/:delivery_id/replay", r.webhookHandler.ReplayDelivery)
+		}
+
+		carriers := v1.Group("/carriers")
+		{
+			// Вебхук для входящих событий трекинга от перевозчиков
+			carriers.POST("/:carrier/tracking", r.shipmentHandler.TrackingWebhook)
 		}
 	}
 
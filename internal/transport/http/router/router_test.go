@@ -0,0 +1,49 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AndrivA89/orders/internal/infrastructure/metrics"
+	"github.com/AndrivA89/orders/internal/transport/http/openapi"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// Маршрут самого Swagger UI отдает статику и в спецификацию не входит
+const swaggerUIRoute = "/swagger/*any"
+
+func TestSetupRoutes_EveryRouteIsInOpenAPISpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// обработчики при регистрации не вызываются, поэтому маршруты можно собрать без зависимостей
+	engine := NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, metrics.New(), logrus.New()).SetupRoutes()
+
+	doc, err := openapi.Spec()
+	assert.NoError(t, err)
+	if err != nil {
+		return
+	}
+	assert.NoError(t, doc.Validate(context.Background()))
+
+	documented := make(map[string]bool)
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			documented[method+" "+path] = true
+		}
+	}
+
+	for _, route := range engine.Routes() {
+		if route.Path == swaggerUIRoute {
+			continue
+		}
+
+		key := route.Method + " " + openapi.SpecPath(route.Path)
+		assert.True(t, documented[key], "route %s is registered in Gin but missing from the OpenAPI spec", key)
+		delete(documented, key)
+	}
+
+	assert.Empty(t, documented, "operations described in the OpenAPI spec but not registered in Gin")
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "github.com/AndrivA89/orders/internal/domain/errors"
+	domainServices "github.com/AndrivA89/orders/internal/domain/services"
+)
+
+// Заказы разных покупателей не блокируют друг друга на пользователе, поэтому резерв одного товара
+// сериализуется только блокировкой его строки: без нее параллельные списания затирают друг друга
+func TestConcurrentReservations_DoNotOversell(t *testing.T) {
+	fixture := setupTestFixture(t)
+	defer fixture.cleanup(t)
+
+	ctx := context.Background()
+	const stock, buyers, perOrder = 10, 8, 2
+
+	product, err := fixture.productService.CreateProduct(ctx, &domainServices.CreateProductRequest{
+		Description: "Limited edition",
+		Price:       1000,
+		Quantity:    stock,
+	})
+	require.NoError(t, err)
+
+	userIDs := make([]uuid.UUID, buyers)
+	for i := range userIDs {
+		user, err := fixture.userService.RegisterUser(ctx, &domainServices.CreateUserRequest{
+			FirstName: "Buyer",
+			LastName:  fmt.Sprintf("N%d", i),
+			Email:     fmt.Sprintf("buyer%d@example.com", i),
+			Age:       30,
+			Password:  "securepass123",
+		})
+		require.NoError(t, err)
+		userIDs[i] = user.ID
+	}
+
+	errs := make([]error, buyers)
+	var wg sync.WaitGroup
+	for i, userID := range userIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = fixture.orderService.CreateOrder(ctx, &domainServices.OrderRequest{
+				UserID: userID,
+				Items:  []domainServices.OrderItemRequest{{ProductID: product.ID, Quantity: perOrder}},
+			})
+		}()
+	}
+	wg.Wait()
+
+	placed := 0
+	for _, err := range errs {
+		if err == nil {
+			placed++
+			continue
+		}
+		assert.ErrorIs(t, err, domainErrors.ErrInsufficientStock)
+	}
+	assert.Equal(t, stock/perOrder, placed)
+
+	stored, err := fixture.productService.GetProductByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, stock-placed*perOrder, stored.Quantity)
+}
@@ -0,0 +1,40 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AndrivA89/orders/internal/transport/http/dto"
+)
+
+func TestHealthProbes_FollowDatabase(t *testing.T) {
+	fixture := setupTestFixture(t)
+	defer fixture.cleanup(t)
+
+	resp := fixture.makeRequest(t, "GET", "/readyz?verbose=true", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var report dto.HealthResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &report))
+	assert.Equal(t, "ok", report.Status)
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, "database", report.Checks[0].Name)
+	assert.Equal(t, "up", report.Checks[0].Status)
+
+	// без БД сервис не готов принимать запросы, но жив: перезапуск не поможет
+	require.NoError(t, fixture.conn.Close())
+
+	resp = fixture.makeRequest(t, "GET", "/readyz?verbose=true", nil)
+	require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &report))
+	assert.Equal(t, "unavailable", report.Status)
+	assert.Equal(t, "down", report.Checks[0].Status)
+	assert.NotEmpty(t, report.Checks[0].Error)
+
+	assert.Equal(t, http.StatusServiceUnavailable, fixture.makeRequest(t, "GET", "/health", nil).Code)
+	assert.Equal(t, http.StatusOK, fixture.makeRequest(t, "GET", "/livez", nil).Code)
+}
@@ -2,6 +2,7 @@ package test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -20,14 +21,30 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/AndrivA89/orders/internal/application/services"
+	"github.com/AndrivA89/orders/internal/domain/constants"
+	domainServices "github.com/AndrivA89/orders/internal/domain/services"
 	"github.com/AndrivA89/orders/internal/infrastructure/config"
 	"github.com/AndrivA89/orders/internal/infrastructure/database"
+	"github.com/AndrivA89/orders/internal/infrastructure/database/migrations"
+	"github.com/AndrivA89/orders/internal/infrastructure/health"
+	"github.com/AndrivA89/orders/internal/infrastructure/invoicing"
+	"github.com/AndrivA89/orders/internal/infrastructure/metrics"
+	"github.com/AndrivA89/orders/internal/infrastructure/notifications"
 	"github.com/AndrivA89/orders/internal/infrastructure/repositories"
+	"github.com/AndrivA89/orders/internal/infrastructure/webhooks"
+	"github.com/AndrivA89/orders/internal/transport/graphql"
 	"github.com/AndrivA89/orders/internal/transport/http/handlers"
 	"github.com/AndrivA89/orders/internal/transport/http/router"
 )
 
 type IntegrationTestFixture struct {
+	// сервисы доступны напрямую для сценариев, которые HTTP не позволяет (конкурентные заказы упираются в rate limit)
+	userService    domainServices.UserService
+	productService domainServices.ProductService
+	orderService   domainServices.OrderService
+
+	conn     *database.Connection
+	migrator *migrations.Migrator
 	db       *gorm.DB
 	router   *gin.Engine
 	server   *httptest.Server
@@ -54,6 +71,9 @@ func setupTestFixture(t *testing.T) *IntegrationTestFixture {
 
 	var dbConn *database.Connection
 
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
 	t.Log("Waiting 3s for PostgreSQL to be ready...")
 	time.Sleep(3 * time.Second)
 
@@ -68,7 +88,7 @@ func setupTestFixture(t *testing.T) *IntegrationTestFixture {
 		}
 
 		var retryErr error
-		dbConn, retryErr = database.NewConnection(cfg)
+		dbConn, retryErr = database.NewConnection(context.Background(), cfg, logger)
 		if retryErr != nil {
 			return retryErr
 		}
@@ -83,28 +103,74 @@ func setupTestFixture(t *testing.T) *IntegrationTestFixture {
 
 	t.Logf("PostgreSQL container started on port %s", resource.GetPort("5432/tcp"))
 
-	err = dbConn.AutoMigrate()
+	// схема создается теми же миграциями, что и в продакшене
+	migrator, err := dbConn.Migrator(logger)
 	require.NoError(t, err)
+	require.NoError(t, migrator.Up(context.Background()))
+	require.NoError(t, dbConn.CheckSchema())
 
 	userRepo := repositories.NewUserRepository(dbConn.DB)
-	productRepo := repositories.NewProductRepository(dbConn.DB)
-	orderRepo := repositories.NewOrderRepository(dbConn.DB)
-	txManager := repositories.NewTransactionManager(dbConn.DB)
+	productRepo := repositories.NewProductRepository(dbConn.DB, dbConn.Reads)
+	orderRepo := repositories.NewOrderRepository(dbConn.DB, dbConn.Reads)
+	shipmentRepo := repositories.NewShipmentRepository(dbConn.DB)
+	returnRepo := repositories.NewReturnRepository(dbConn.DB)
+	invoiceRepo := repositories.NewInvoiceRepository(dbConn.DB)
+	reportRepo := repositories.NewReportRepository(dbConn.Reads)
+	categoryRepo := repositories.NewCategoryRepository(dbConn.DB)
+	priceRepo := repositories.NewPriceRepository(dbConn.DB)
+	stockAlertRepo := repositories.NewStockAlertRepository(dbConn.DB)
+	emailRepo := repositories.NewEmailDeliveryRepository(dbConn.DB)
+	webhookRepo := repositories.NewWebhookRepository(dbConn.DB)
+	txManager := repositories.NewTransactionManager(dbConn.DB, dbConn.Reads)
 
 	userService := services.NewUserService(userRepo)
-	productService := services.NewProductService(productRepo)
-	orderService := services.NewOrderService(orderRepo, userRepo, productRepo, txManager)
+	productService := services.NewProductService(productRepo, categoryRepo, txManager)
+	catalogService := services.NewCatalogService(productRepo, txManager)
+	categoryService := services.NewCategoryService(categoryRepo)
+	priceService := services.NewPriceService(priceRepo, productRepo, txManager)
+	stockAlertService := services.NewStockAlertService(stockAlertRepo, productRepo, userRepo, txManager, notifications.NewLogNotifier(logger))
+	emailRenderer, err := notifications.NewTemplateRenderer(constants.DefaultLanguage)
+	require.NoError(t, err)
+	emailService := services.NewEmailService(emailRepo, orderRepo, userRepo, emailRenderer, notifications.NewFileSender(t.TempDir(), "orders@localhost"))
+	webhookService := services.NewWebhookService(webhookRepo, webhooks.NewHTTPSender(5*time.Second))
+	orderService := services.NewOrderService(orderRepo, userRepo, productRepo, txManager, domainServices.OrderPolicy{})
+	shipmentService := services.NewShipmentService(shipmentRepo, orderRepo, txManager, nil)
+	returnService := services.NewReturnService(returnRepo, orderRepo, txManager)
+	invoiceService := services.NewInvoiceService(invoiceRepo, txManager, invoicing.NewPDFRenderer(), "Orders Service")
+	reportService := services.NewReportService(reportRepo)
 
 	userHandler := handlers.NewUserHandler(userService)
 	productHandler := handlers.NewProductHandler(productService)
 	orderHandler := handlers.NewOrderHandler(orderService)
-
-	logger := logrus.New()
-	logger.SetLevel(logrus.ErrorLevel)
-	r := router.NewRouter(userHandler, productHandler, orderHandler, logger)
+	shipmentHandler := handlers.NewShipmentHandler(shipmentService, "", false)
+	returnHandler := handlers.NewReturnHandler(returnService)
+	invoiceHandler := handlers.NewInvoiceHandler(invoiceService)
+	reportHandler := handlers.NewReportHandler(reportService)
+	catalogHandler := handlers.NewCatalogHandler(catalogService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService)
+	priceHandler := handlers.NewPriceHandler(priceService)
+	stockAlertHandler := handlers.NewStockAlertHandler(stockAlertService)
+	emailHandler := handlers.NewEmailHandler(emailService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	graphqlHandler := graphql.NewHandler(orderService, productService, userService, 7, 1000, logger)
+
+	// без кеша: тест готовности должен видеть состояние БД сразу
+	healthChecks := health.NewRegistry(time.Second, 0)
+	healthChecks.Register("database", dbConn.Ping, 0)
+	healthHandler := handlers.NewHealthHandler(healthChecks, true)
+
+	// в тестовом режиме Gin ответы API тоже сверяются со спецификацией OpenAPI
+	gin.SetMode(gin.TestMode)
+	r := router.NewRouter(userHandler, productHandler, orderHandler, shipmentHandler, returnHandler, invoiceHandler, reportHandler, catalogHandler, categoryHandler, priceHandler, stockAlertHandler, emailHandler, webhookHandler, graphqlHandler, healthHandler, metrics.New(), logger)
 	ginRouter := r.SetupRoutes()
 
 	return &IntegrationTestFixture{
+		userService:    userService,
+		productService: productService,
+		orderService:   orderService,
+
+		conn:     dbConn,
+		migrator: migrator,
 		db:       dbConn.DB,
 		router:   ginRouter,
 		server:   httptest.NewServer(ginRouter),
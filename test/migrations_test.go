@@ -0,0 +1,53 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_RollbackAndReapply(t *testing.T) {
+	fixture := setupTestFixture(t)
+	defer fixture.cleanup(t)
+
+	ctx := context.Background()
+	latest := fixture.migrator.Latest()
+
+	require.NoError(t, fixture.migrator.To(ctx, 0))
+	assert.False(t, fixture.db.Migrator().HasTable("user_models"))
+
+	pending, err := fixture.migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Len(t, pending, int(latest))
+
+	// реплики стартуют одновременно: advisory lock не дает применить миграцию дважды
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = fixture.migrator.Up(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, fixture.conn.CheckSchema())
+
+	require.NoError(t, fixture.migrator.Down(ctx))
+	assert.ErrorContains(t, fixture.conn.CheckSchema(), "webhook_delivery_models")
+
+	statuses, err := fixture.migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, statuses[len(statuses)-1].Applied())
+	assert.True(t, statuses[0].Applied())
+
+	require.NoError(t, fixture.migrator.Up(ctx))
+	assert.NoError(t, fixture.conn.CheckSchema())
+}